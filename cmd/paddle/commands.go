@@ -0,0 +1,657 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/Fakerr/go-paddle/paddle"
+)
+
+var commands = map[string]*command{
+	"users list":          {"list the subscribers of your plans", usersList},
+	"users update":        {"update the quantity, price or plan of a subscription", usersUpdate},
+	"users cancel":        {"cancel a subscription", usersCancel},
+	"plans list":          {"list the subscription plans", plansList},
+	"plans create":        {"create a subscription plan", plansCreate},
+	"modifiers list":      {"list the modifiers of subscriptions", modifiersList},
+	"modifiers create":    {"add a modifier to a subscription", modifiersCreate},
+	"modifiers delete":    {"delete a modifier", modifiersDelete},
+	"payments list":       {"list the payments of subscriptions", paymentsList},
+	"payments reschedule": {"move the date of an upcoming payment", paymentsReschedule},
+	"coupons list":        {"list the coupons of a product", couponsList},
+	"coupons create":      {"create coupons", couponsCreate},
+	"coupons update":      {"update a coupon or a group of coupons", couponsUpdate},
+	"coupons delete":      {"delete a coupon", couponsDelete},
+	"products list":       {"list the one-time products", productsList},
+	"paylink create":      {"generate a pay link", payLinkCreate},
+	"refund":              {"refund an order", refund},
+	"charge":              {"make a one-off charge on a subscription", charge},
+	"webhooks history":    {"list the alerts sent", webhooksHistory},
+	"prices get":          {"get the prices of products or plans", pricesGet},
+	"order get":           {"get the details of the order of a checkout", orderGet},
+	"licenses generate":   {"generate a license code for a one-time product", licensesGenerate},
+	"licenses list":       {"list the license codes delivered with the order of a checkout", licensesList},
+	"transactions list":   {"list the transactions of a user, subscription, order, checkout or product", transactionsList},
+}
+
+// flags returns the flag set of the command.
+func (e *env) flags() *flag.FlagSet {
+	fs := flag.NewFlagSet("paddle "+e.name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	return fs
+}
+
+// parse parses args with fs and checks that the required flags are set.
+func (e *env) parse(fs *flag.FlagSet, args []string, required ...string) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		return e.usageError(fs, "unexpected argument %q", fs.Arg(0))
+	}
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, name := range required {
+		if !set[name] {
+			return e.usageError(fs, "-%s is required", name)
+		}
+	}
+	return nil
+}
+
+// usageError prints an error along with the usage of the command and
+// returns errUsage.
+func (e *env) usageError(fs *flag.FlagSet, format string, a ...interface{}) error {
+	fmt.Fprintf(e.stderr, "paddle %s: %s\n", e.name, fmt.Sprintf(format, a...))
+	fs.Usage()
+	return errUsage
+}
+
+// money parses amount in currency, reporting a usage error if invalid.
+func (e *env) money(fs *flag.FlagSet, currency, amount string) (paddle.Money, error) {
+	m, err := paddle.ParseMoney(currency, amount)
+	if err != nil {
+		return paddle.Money{}, e.usageError(fs, "invalid amount %q", amount)
+	}
+	return m, nil
+}
+
+// decimalValue is a flag.Value holding a paddle.Decimal.
+type decimalValue struct {
+	d *paddle.Decimal
+}
+
+func (v decimalValue) String() string {
+	if v.d == nil {
+		return ""
+	}
+	return v.d.String()
+}
+
+func (v decimalValue) Set(s string) error {
+	d, err := paddle.ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*v.d = d
+	return nil
+}
+
+// The optional flag values below leave their field nil unless the flag is
+// set, so that false, zero or empty values can be sent.
+
+// optionalBool is a flag.Value setting a *bool, such as -prorate=false.
+type optionalBool struct{ p **bool }
+
+func (v optionalBool) IsBoolFlag() bool { return true }
+
+func (v optionalBool) String() string {
+	if v.p == nil || *v.p == nil {
+		return ""
+	}
+	return strconv.FormatBool(**v.p)
+}
+
+func (v optionalBool) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*v.p = &b
+	return nil
+}
+
+// optionalInt is a flag.Value setting an *int.
+type optionalInt struct{ p **int }
+
+func (v optionalInt) String() string {
+	if v.p == nil || *v.p == nil {
+		return ""
+	}
+	return strconv.Itoa(**v.p)
+}
+
+func (v optionalInt) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	*v.p = &n
+	return nil
+}
+
+// optionalString is a flag.Value setting a *string.
+type optionalString struct{ p **string }
+
+func (v optionalString) String() string {
+	if v.p == nil || *v.p == nil {
+		return ""
+	}
+	return **v.p
+}
+
+func (v optionalString) Set(s string) error {
+	*v.p = &s
+	return nil
+}
+
+// optionalDecimal is a flag.Value setting a *paddle.Decimal.
+type optionalDecimal struct{ p **paddle.Decimal }
+
+func (v optionalDecimal) String() string {
+	if v.p == nil || *v.p == nil {
+		return ""
+	}
+	return (*v.p).String()
+}
+
+func (v optionalDecimal) Set(s string) error {
+	d, err := paddle.ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*v.p = &d
+	return nil
+}
+
+// Results of the calls returning a single value.
+type (
+	successResult struct {
+		Success bool `json:"success"`
+	}
+	refundResult struct {
+		RefundRequestID *int `json:"refund_request_id"`
+	}
+	payLinkResult struct {
+		URL *string `json:"url"`
+	}
+	updatedResult struct {
+		Updated *int `json:"updated"`
+	}
+)
+
+func usersList(e *env, args []string) (interface{}, error) {
+	opts := &paddle.UsersOptions{}
+	fs := e.flags()
+	fs.StringVar(&opts.SubscriptionID, "subscription", "", "filter on the subscription `id`")
+	fs.StringVar(&opts.PlanID, "plan", "", "filter on the plan `id`")
+	fs.StringVar(&opts.State, "state", "", "filter on the `state`: active, past_due, trialing, paused or deleted")
+	fs.IntVar(&opts.Page, "page", 0, "`page` to list")
+	fs.IntVar(&opts.ResultsPerPage, "per-page", 0, "`number` of users per page")
+	all := fs.Bool("all", false, "list the users of every page")
+	if err := e.parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	if *all {
+		return e.client.Users.Iter(e.ctx, opts).All()
+	}
+	users, _, err := e.client.Users.List(e.ctx, opts)
+	return users, err
+}
+
+func usersUpdate(e *env, args []string) (interface{}, error) {
+	opts := &paddle.UserUpdateOptions{}
+	fs := e.flags()
+	subscriptionID := fs.Int("subscription", 0, "`id` of the subscription")
+	quantity := fs.Int("quantity", 0, "new `quantity`")
+	price := fs.String("price", "", "new recurring `amount` per unit, in -currency")
+	currency := fs.String("currency", "", "`currency` of -price")
+	fs.IntVar(&opts.PlanID, "plan", 0, "`id` of the plan to move the subscription to")
+	fs.Var(optionalBool{&opts.BillImmediately}, "bill-immediately", "bill the change immediately")
+	fs.Var(optionalBool{&opts.Prorate}, "prorate", "prorate the change, -prorate=false to turn proration off")
+	fs.Var(optionalBool{&opts.KeepModifiers}, "keep-modifiers", "keep the modifiers when changing plan")
+	fs.Var(optionalString{&opts.Passthrough}, "passthrough", "new passthrough `data`")
+	fs.Var(optionalBool{&opts.Pause}, "pause", "pause the subscription, -pause=false to resume it")
+	if err := e.parse(fs, args, "subscription"); err != nil {
+		return nil, err
+	}
+	if *price != "" {
+		if *currency == "" {
+			return nil, e.usageError(fs, "-currency is required with -price")
+		}
+		m, err := e.money(fs, *currency, *price)
+		if err != nil {
+			return nil, err
+		}
+		opts.RecurringPrice = &m
+	}
+
+	user, _, err := e.client.Users.Update(e.ctx, *subscriptionID, *quantity, opts)
+	return user, err
+}
+
+func usersCancel(e *env, args []string) (interface{}, error) {
+	fs := e.flags()
+	subscriptionID := fs.Int("subscription", 0, "`id` of the subscription")
+	if err := e.parse(fs, args, "subscription"); err != nil {
+		return nil, err
+	}
+
+	ok, _, err := e.client.Users.Cancel(e.ctx, *subscriptionID)
+	return &successResult{Success: ok}, err
+}
+
+func plansList(e *env, args []string) (interface{}, error) {
+	opts := &paddle.PlansOptions{}
+	fs := e.flags()
+	fs.IntVar(&opts.PlanID, "plan", 0, "filter on the plan `id`")
+	if err := e.parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	plans, _, err := e.client.Plans.List(e.ctx, opts)
+	return plans, err
+}
+
+func plansCreate(e *env, args []string) (interface{}, error) {
+	opts := &paddle.PlanCreateOptions{}
+	fs := e.flags()
+	name := fs.String("name", "", "`name` of the plan")
+	planType := fs.String("type", "", "billing `period` type: day, week, month or year")
+	length := fs.Int("length", 0, "`number` of periods between payments")
+	fs.IntVar(&opts.PlanTrialDays, "trial-days", 0, "`days` of trial")
+	fs.StringVar(&opts.MainCurrencyCode, "currency", "", "main `currency`: USD, GBP or EUR")
+	prices := []struct {
+		currency string
+		amount   *string
+		price    **paddle.Money
+	}{
+		{"USD", fs.String("price-usd", "", "recurring `amount` in USD"), &opts.RecurringPriceUsd},
+		{"GBP", fs.String("price-gbp", "", "recurring `amount` in GBP"), &opts.RecurringPriceGbp},
+		{"EUR", fs.String("price-eur", "", "recurring `amount` in EUR"), &opts.RecurringPriceEur},
+	}
+	if err := e.parse(fs, args, "name", "type", "length"); err != nil {
+		return nil, err
+	}
+	for _, p := range prices {
+		if *p.amount == "" {
+			continue
+		}
+		m, err := e.money(fs, p.currency, *p.amount)
+		if err != nil {
+			return nil, err
+		}
+		*p.price = &m
+	}
+
+	product, _, err := e.client.Plans.Create(e.ctx, *name, *planType, *length, opts)
+	return product, err
+}
+
+func modifiersList(e *env, args []string) (interface{}, error) {
+	opts := &paddle.ModifiersOptions{}
+	fs := e.flags()
+	fs.IntVar(&opts.SubscriptionID, "subscription", 0, "filter on the subscription `id`")
+	fs.IntVar(&opts.PlanID, "plan", 0, "filter on the plan `id`")
+	if err := e.parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	modifiers, _, err := e.client.Modifiers.List(e.ctx, opts)
+	return modifiers, err
+}
+
+func modifiersCreate(e *env, args []string) (interface{}, error) {
+	opts := &paddle.ModifierCreateOptions{}
+	fs := e.flags()
+	subscriptionID := fs.Int("subscription", 0, "`id` of the subscription")
+	amount := fs.String("amount", "", "`amount` of the modifier, negative for a discount")
+	currency := fs.String("currency", "", "`currency` of the subscription")
+	fs.Var(optionalBool{&opts.ModifierRecurring}, "recurring", "apply the modifier to every payment, -recurring=false for the next one only")
+	fs.StringVar(&opts.ModifierDescription, "description", "", "`description` shown to the customer")
+	if err := e.parse(fs, args, "subscription", "amount"); err != nil {
+		return nil, err
+	}
+	m, err := e.money(fs, *currency, *amount)
+	if err != nil {
+		return nil, err
+	}
+
+	modifier, _, err := e.client.Modifiers.Create(e.ctx, *subscriptionID, m, opts)
+	return modifier, err
+}
+
+func modifiersDelete(e *env, args []string) (interface{}, error) {
+	fs := e.flags()
+	modifierID := fs.Int("modifier", 0, "`id` of the modifier")
+	if err := e.parse(fs, args, "modifier"); err != nil {
+		return nil, err
+	}
+
+	ok, _, err := e.client.Modifiers.Delete(e.ctx, *modifierID)
+	return &successResult{Success: ok}, err
+}
+
+func paymentsList(e *env, args []string) (interface{}, error) {
+	opts := &paddle.PaymentsOptions{}
+	fs := e.flags()
+	fs.IntVar(&opts.SubscriptionID, "subscription", 0, "filter on the subscription `id`")
+	fs.IntVar(&opts.Plan, "plan", 0, "filter on the plan `id`")
+	fs.Var(optionalBool{&opts.IsPaid}, "paid", "list the paid payments only, -paid=false for the upcoming ones")
+	fs.StringVar(&opts.From, "from", "", "list the payments from `date` (YYYY-MM-DD)")
+	fs.StringVar(&opts.To, "to", "", "list the payments up to `date` (YYYY-MM-DD)")
+	fs.Var(optionalBool{&opts.IsOneOffCharge}, "one-off", "list the one-off charges only, -one-off=false for the recurring payments")
+	if err := e.parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	payments, _, err := e.client.Payments.List(e.ctx, opts)
+	return payments, err
+}
+
+func paymentsReschedule(e *env, args []string) (interface{}, error) {
+	fs := e.flags()
+	paymentID := fs.Int("payment", 0, "`id` of the payment")
+	date := fs.String("date", "", "new `date` of the payment (YYYY-MM-DD)")
+	if err := e.parse(fs, args, "payment", "date"); err != nil {
+		return nil, err
+	}
+
+	ok, _, err := e.client.Payments.Update(e.ctx, *paymentID, *date)
+	return &successResult{Success: ok}, err
+}
+
+func couponsList(e *env, args []string) (interface{}, error) {
+	fs := e.flags()
+	productID := fs.Int("product", 0, "`id` of the product or plan")
+	if err := e.parse(fs, args, "product"); err != nil {
+		return nil, err
+	}
+
+	coupons, _, err := e.client.Coupons.List(e.ctx, *productID)
+	return coupons, err
+}
+
+func couponsCreate(e *env, args []string) (interface{}, error) {
+	opts := &paddle.CouponCreateOptions{}
+	var amount paddle.Decimal
+	fs := e.flags()
+	couponType := fs.String("type", "", "coupon `type`: product or checkout")
+	discountType := fs.String("discount-type", "", "discount `type`: flat or percentage")
+	fs.Var(decimalValue{&amount}, "amount", "discount `amount`, in -currency or percents")
+	fs.StringVar(&opts.CouponCode, "code", "", "`code` of the coupon")
+	fs.StringVar(&opts.CouponPrefix, "prefix", "", "`prefix` of the generated codes")
+	fs.IntVar(&opts.NumCoupons, "num", 0, "`number` of coupons to generate")
+	fs.StringVar(&opts.Description, "description", "", "`description` of the coupons")
+	fs.StringVar(&opts.ProductIds, "products", "", "comma separated `ids` of the products the coupons apply to")
+	fs.StringVar(&opts.Currency, "currency", "", "`currency` of flat discounts")
+	fs.IntVar(&opts.AllowedUses, "allowed-uses", 0, "`number` of times each coupon can be used")
+	fs.StringVar(&opts.Expires, "expires", "", "expiry `date` (YYYY-MM-DD)")
+	recurring := fs.Bool("recurring", false, "apply the discount to every subscription payment")
+	fs.StringVar(&opts.Group, "group", "", "`group` of the coupons")
+	if err := e.parse(fs, args, "type", "discount-type", "amount"); err != nil {
+		return nil, err
+	}
+	if *recurring {
+		opts.Recurring = 1
+	}
+
+	codes, _, err := e.client.Coupons.Create(e.ctx, *couponType, *discountType, amount, opts)
+	return codes, err
+}
+
+func couponsUpdate(e *env, args []string) (interface{}, error) {
+	opts := &paddle.CouponUpdateOptions{}
+	fs := e.flags()
+	fs.StringVar(&opts.CouponCode, "code", "", "`code` of the coupon to update")
+	fs.StringVar(&opts.Group, "group", "", "`group` of the coupons to update")
+	fs.StringVar(&opts.NewCouponCode, "new-code", "", "new `code` of the coupon")
+	fs.StringVar(&opts.NewGroup, "new-group", "", "new `group` of the coupons")
+	fs.StringVar(&opts.ProductIds, "products", "", "comma separated `ids` of the products the coupons apply to")
+	fs.StringVar(&opts.Expires, "expires", "", "expiry `date` (YYYY-MM-DD)")
+	fs.Var(optionalInt{&opts.AllowedUses}, "allowed-uses", "`number` of times each coupon can be used")
+	fs.StringVar(&opts.Currency, "currency", "", "`currency` of flat discounts")
+	fs.Var(optionalDecimal{&opts.DiscountAmount}, "amount", "discount `amount`, in -currency or percents")
+	fs.Var(optionalBool{&opts.Recurring}, "recurring", "apply the discount to every subscription payment")
+	if err := e.parse(fs, args); err != nil {
+		return nil, err
+	}
+	if opts.CouponCode == "" && opts.Group == "" {
+		return nil, e.usageError(fs, "-code or -group is required")
+	}
+
+	updated, _, err := e.client.Coupons.Update(e.ctx, opts)
+	return &updatedResult{Updated: updated}, err
+}
+
+func couponsDelete(e *env, args []string) (interface{}, error) {
+	opts := &paddle.CouponDeleteOptions{}
+	fs := e.flags()
+	code := fs.String("code", "", "`code` of the coupon")
+	fs.IntVar(&opts.ProductID, "product", 0, "`id` of the product the coupon applies to")
+	if err := e.parse(fs, args, "code"); err != nil {
+		return nil, err
+	}
+
+	ok, _, err := e.client.Coupons.Delete(e.ctx, *code, opts)
+	return &successResult{Success: ok}, err
+}
+
+func productsList(e *env, args []string) (interface{}, error) {
+	fs := e.flags()
+	if err := e.parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	products, _, err := e.client.Products.List(e.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return products.Products, nil
+}
+
+func payLinkCreate(e *env, args []string) (interface{}, error) {
+	link := &paddle.PayLinkCreate{}
+	fs := e.flags()
+	fs.IntVar(&link.ProductID, "product", 0, "`id` of the product or plan")
+	fs.StringVar(&link.Title, "title", "", "`title` of a custom product")
+	fs.StringVar(&link.WebhookURL, "webhook-url", "", "fulfillment `url` of a custom product")
+	fs.StringVar(&link.Prices, "prices", "", "comma separated `prices`, such as USD:9.99,EUR:8.99")
+	fs.StringVar(&link.RecurringPrices, "recurring-prices", "", "comma separated recurring `prices` of a plan")
+	fs.IntVar(&link.TrialDays, "trial-days", 0, "`days` of trial")
+	fs.StringVar(&link.CustomMessage, "message", "", "`message` shown below the product title")
+	fs.StringVar(&link.CouponCode, "coupon", "", "`code` of a coupon to apply")
+	fs.Var(optionalBool{&link.Discountable}, "discountable", "allow coupons to be applied, -discountable=false to forbid them")
+	fs.StringVar(&link.ImageURL, "image-url", "", "`url` of the product image")
+	fs.StringVar(&link.ReturnURL, "return-url", "", "`url` the customer is sent to after the checkout")
+	fs.Var(optionalBool{&link.QuantityVariable}, "quantity-variable", "let the customer change the quantity")
+	fs.IntVar(&link.Quantity, "quantity", 0, "initial `quantity`")
+	fs.StringVar(&link.Expires, "expires", "", "expiry `date` of the link (YYYY-MM-DD)")
+	fs.StringVar(&link.Affiliates, "affiliates", "", "comma separated `affiliates`, such as 12345:0.25")
+	fs.IntVar(&link.RecurringAffiliateLimit, "recurring-affiliate-limit", 0, "`number` of subscription payments affiliates earn from")
+	fs.Var(optionalBool{&link.MarketingConsent}, "marketing-consent", "the customer agreed to marketing emails")
+	fs.StringVar(&link.CustomerEmail, "email", "", "`email` of the customer")
+	fs.StringVar(&link.CustomerCountry, "country", "", "two letters `code` of the country of the customer")
+	fs.StringVar(&link.CustomerPostcode, "postcode", "", "`postcode` of the customer")
+	fs.StringVar(&link.Passthrough, "passthrough", "", "`data` sent back in the alerts")
+	fs.StringVar(&link.VatNumber, "vat-number", "", "VAT `number` of the customer")
+	fs.StringVar(&link.VatCompanyName, "vat-company-name", "", "company `name` of the customer")
+	fs.StringVar(&link.VatStreet, "vat-street", "", "`street` of the company")
+	fs.StringVar(&link.VatCity, "vat-city", "", "`city` of the company")
+	fs.StringVar(&link.VatState, "vat-state", "", "`state` of the company")
+	fs.StringVar(&link.VatCountry, "vat-country", "", "`country` of the company")
+	fs.StringVar(&link.VatPostcode, "vat-postcode", "", "`postcode` of the company")
+	if err := e.parse(fs, args); err != nil {
+		return nil, err
+	}
+	if link.ProductID == 0 && link.Title == "" {
+		return nil, e.usageError(fs, "-product or -title is required")
+	}
+
+	url, _, err := e.client.PayLink.Create(e.ctx, link)
+	return &payLinkResult{URL: url}, err
+}
+
+func refund(e *env, args []string) (interface{}, error) {
+	opts := &paddle.RefundPaymentOptions{}
+	fs := e.flags()
+	orderID := fs.String("order", "", "`id` of the order")
+	amount := fs.String("amount", "", "`amount` to refund, the whole order if empty")
+	currency := fs.String("currency", "", "`currency` of the order")
+	fs.StringVar(&opts.Reason, "reason", "", "`reason` of the refund")
+	if err := e.parse(fs, args, "order"); err != nil {
+		return nil, err
+	}
+	if *amount != "" {
+		m, err := e.money(fs, *currency, *amount)
+		if err != nil {
+			return nil, err
+		}
+		opts.Amount = m
+	}
+
+	id, _, err := e.client.RefundPayment.Refund(e.ctx, *orderID, opts)
+	return &refundResult{RefundRequestID: id}, err
+}
+
+func charge(e *env, args []string) (interface{}, error) {
+	fs := e.flags()
+	subscriptionID := fs.Int("subscription", 0, "`id` of the subscription")
+	amount := fs.String("amount", "", "`amount` to charge")
+	currency := fs.String("currency", "", "`currency` of the subscription")
+	name := fs.String("name", "", "`name` of the charge, shown on the invoice")
+	if err := e.parse(fs, args, "subscription", "amount", "name"); err != nil {
+		return nil, err
+	}
+	m, err := e.money(fs, *currency, *amount)
+	if err != nil {
+		return nil, err
+	}
+
+	c, _, err := e.client.OneOffCharges.Create(e.ctx, *subscriptionID, m, *name)
+	return c, err
+}
+
+func webhooksHistory(e *env, args []string) (interface{}, error) {
+	opts := &paddle.WebhookEventOptions{}
+	fs := e.flags()
+	fs.StringVar(&opts.QueryHead, "from", "", "list the alerts sent from `time` (YYYY-MM-DD HH:MM:SS)")
+	fs.StringVar(&opts.QueryTail, "to", "", "list the alerts sent up to `time` (YYYY-MM-DD HH:MM:SS)")
+	fs.StringVar(&opts.AlertsPerPage, "per-page", "", "`number` of alerts per page")
+	fs.IntVar(&opts.Page, "page", 0, "`page` to list")
+	all := fs.Bool("all", false, "list the alerts of every page")
+	if err := e.parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	if *all {
+		return e.client.Webhooks.Iter(e.ctx, opts).All()
+	}
+	events, _, err := e.client.Webhooks.Get(e.ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return events.Data, nil
+}
+
+func pricesGet(e *env, args []string) (interface{}, error) {
+	opts := &paddle.PricesOptions{}
+	fs := e.flags()
+	productIDs := fs.String("products", "", "comma separated `ids` of products or plans")
+	fs.StringVar(&opts.CustomerCountry, "country", "", "two letters `code` of the country of the customer")
+	fs.StringVar(&opts.CustomerIP, "ip", "", "IP `address` of the customer")
+	fs.StringVar(&opts.Coupons, "coupons", "", "comma separated `codes` of coupons to apply")
+	if err := e.parse(fs, args, "products"); err != nil {
+		return nil, err
+	}
+
+	prices, _, err := e.client.Prices.Get(e.ctx, *productIDs, opts)
+	if err != nil {
+		return nil, err
+	}
+	return prices.Products, nil
+}
+
+func orderGet(e *env, args []string) (interface{}, error) {
+	fs := e.flags()
+	checkoutID := fs.String("checkout", "", "`id` of the checkout")
+	if err := e.parse(fs, args, "checkout"); err != nil {
+		return nil, err
+	}
+
+	details, _, err := e.client.OrderDetails.Get(e.ctx, *checkoutID)
+	return details, err
+}
+
+func transactionsList(e *env, args []string) (interface{}, error) {
+	opts := &paddle.TransactionsOptions{}
+	fs := e.flags()
+	ids := map[paddle.TransactionEntity]*string{
+		paddle.TransactionEntityUser:         fs.String("user", "", "list the transactions of the user `id`"),
+		paddle.TransactionEntitySubscription: fs.String("subscription", "", "list the transactions of the subscription `id`"),
+		paddle.TransactionEntityOrder:        fs.String("order", "", "list the transactions of the order `id`"),
+		paddle.TransactionEntityCheckout:     fs.String("checkout", "", "list the transactions of the checkout `id`"),
+		paddle.TransactionEntityProduct:      fs.String("product", "", "list the transactions of the product or plan `id`"),
+	}
+	fs.IntVar(&opts.Page, "page", 0, "`page` to list")
+	all := fs.Bool("all", false, "list the transactions of every page")
+	if err := e.parse(fs, args); err != nil {
+		return nil, err
+	}
+	var entity paddle.TransactionEntity
+	for ent, id := range ids {
+		if *id == "" {
+			continue
+		}
+		if entity != "" {
+			return nil, e.usageError(fs, "only one of -user, -subscription, -order, -checkout and -product can be set")
+		}
+		entity = ent
+	}
+	if entity == "" {
+		return nil, e.usageError(fs, "-user, -subscription, -order, -checkout or -product is required")
+	}
+
+	if *all {
+		return e.client.Transactions.Iter(e.ctx, entity, *ids[entity], opts).All()
+	}
+	transactions, _, err := e.client.Transactions.List(e.ctx, entity, *ids[entity], opts)
+	return transactions, err
+}
+
+func licensesGenerate(e *env, args []string) (interface{}, error) {
+	opts := &paddle.LicenseGenerateOptions{}
+	fs := e.flags()
+	productID := fs.Int("product", 0, "`id` of the product")
+	allowedUses := fs.Int("allowed-uses", 0, "`number` of activations allowed")
+	fs.StringVar(&opts.ExpiresAt, "expires", "", "expiry `date` of the license (YYYY-MM-DD)")
+	if err := e.parse(fs, args, "product", "allowed-uses"); err != nil {
+		return nil, err
+	}
+
+	license, _, err := e.client.Licenses.Generate(e.ctx, *productID, *allowedUses, opts)
+	return license, err
+}
+
+func licensesList(e *env, args []string) (interface{}, error) {
+	fs := e.flags()
+	checkoutID := fs.String("checkout", "", "`id` of the checkout")
+	if err := e.parse(fs, args, "checkout"); err != nil {
+		return nil, err
+	}
+
+	licenses, _, err := e.client.Licenses.ListByCheckout(e.ctx, *checkoutID)
+	return licenses, err
+}
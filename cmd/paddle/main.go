@@ -0,0 +1,199 @@
+// The paddle command calls the Paddle vendor and checkout APIs from the
+// command line, one subcommand per service method.
+//
+// Usage:
+//
+//	paddle [-profile sandbox|production] [-output table|json|csv] <service> <action> [flags]
+//
+// For example:
+//
+//	paddle -profile production users list -plan 1234 -all
+//	paddle refund -order 219233-chre53d41f940e0-58aqh94971 -amount 10.10 -currency USD -reason "Duplicate"
+//	paddle -output csv payments list -from 2021-01-01 -to 2021-01-31 > january.csv
+//
+// The credentials of a profile are read from the file <profile>.yaml,
+// <profile>.yml, <profile>.json or <profile>.env of the configuration
+// directory, which is $PADDLE_CONFIG_DIR or the paddle directory of the user
+// configuration directory. The VENDOR_ID and VENDOR_AUTH_CODE environment
+// variables are used when the profile has no file.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Fakerr/go-paddle/paddle"
+)
+
+// Exit codes.
+const (
+	exitOK    = 0
+	exitError = 1 // The call failed.
+	exitUsage = 2 // The command line is invalid.
+)
+
+// Profiles.
+const (
+	profileSandbox    = "sandbox"
+	profileProduction = "production"
+)
+
+// errUsage is returned by commands when their flags are invalid. The usage
+// has already been printed.
+var errUsage = errors.New("invalid usage")
+
+// env is the environment of a command.
+type env struct {
+	ctx    context.Context
+	client *paddle.Client
+	stderr io.Writer
+	name   string // Name of the command, such as "users list".
+}
+
+// command is a subcommand, such as "users list". run parses args and
+// returns the value to print.
+type command struct {
+	summary string
+	run     func(e *env, args []string) (interface{}, error)
+}
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr, os.Getenv))
+}
+
+// run runs the command line args and returns the exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	fs := flag.NewFlagSet("paddle", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	defaultProfile := getenv("PADDLE_PROFILE")
+	if defaultProfile == "" {
+		defaultProfile = profileSandbox
+	}
+	profile := fs.String("profile", defaultProfile, "`profile` to use: sandbox or production")
+	output := fs.String("output", "table", "output `format`: table, json or csv")
+	baseURL := fs.String("base-url", "", "send every request to `url`, for example a paddletest server")
+	fs.Usage = func() { usage(fs) }
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *output != "table" && *output != "json" && *output != "csv" {
+		fmt.Fprintf(stderr, "paddle: unknown output format %q\n", *output)
+		return exitUsage
+	}
+
+	name, cmd, rest := lookup(fs.Args())
+	if cmd == nil {
+		usage(fs)
+		return exitUsage
+	}
+
+	client, err := newClient(*profile, *baseURL, getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "paddle: %v\n", err)
+		return exitUsage
+	}
+
+	result, err := cmd.run(&env{ctx: ctx, client: client, stderr: stderr, name: name}, rest)
+	if errors.Is(err, errUsage) {
+		return exitUsage
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "paddle %s: %v\n", name, err)
+		return exitError
+	}
+
+	if err := render(stdout, *output, result); err != nil {
+		fmt.Fprintf(stderr, "paddle: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// lookup returns the command named by the first one or two words of args,
+// along with the remaining arguments.
+func lookup(args []string) (string, *command, []string) {
+	for n := 2; n >= 1; n-- {
+		if len(args) < n {
+			continue
+		}
+		name := strings.Join(args[:n], " ")
+		if cmd, ok := commands[name]; ok {
+			return name, cmd, args[n:]
+		}
+	}
+	return "", nil, nil
+}
+
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintf(w, "Usage: paddle [flags] <command> [command flags]\n\nFlags:\n")
+	fs.PrintDefaults()
+	fmt.Fprintf(w, "\nCommands:\n")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-22s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintf(w, "\nRun 'paddle <command> -h' for the flags of a command.\n")
+}
+
+// newClient returns a client for profile, whose credentials are read from
+// its configuration file or from the environment.
+func newClient(profile, baseURL string, getenv func(string) string) (*paddle.Client, error) {
+	opts := []paddle.Option{paddle.WithUserAgent("go-paddle-cli")}
+	switch profile {
+	case profileSandbox:
+		opts = append(opts, paddle.WithSandbox())
+	case profileProduction:
+	default:
+		return nil, fmt.Errorf("unknown profile %q", profile)
+	}
+	if baseURL != "" {
+		opts = append(opts, paddle.WithBaseURL(baseURL), paddle.WithCheckoutBaseURL(baseURL))
+	}
+
+	creds := paddle.CredentialsProvider(paddle.CredentialsFunc(func() (*paddle.Credentials, error) {
+		return &paddle.Credentials{
+			VendorID:       getenv("VENDOR_ID"),
+			VendorAuthCode: getenv("VENDOR_AUTH_CODE"),
+		}, nil
+	}))
+	if path := profileFile(profile, getenv); path != "" {
+		creds = paddle.FileCredentials(path)
+	}
+	opts = append(opts, paddle.WithCredentialsProvider(creds))
+
+	return paddle.New(opts...)
+}
+
+// profileFile returns the path of the credentials file of profile, or an
+// empty string if there is none.
+func profileFile(profile string, getenv func(string) string) string {
+	dir := getenv("PADDLE_CONFIG_DIR")
+	if dir == "" {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(configDir, "paddle")
+	}
+	for _, ext := range []string{".yaml", ".yml", ".json", ".env"} {
+		path := filepath.Join(dir, profile+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
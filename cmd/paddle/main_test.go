@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Fakerr/go-paddle/paddle"
+	"github.com/Fakerr/go-paddle/paddle/paddletest"
+)
+
+// setup returns a fake Paddle with one subscriber, along with the
+// environment of the command.
+func setup(t *testing.T) (*paddletest.Server, int, map[string]string) {
+	t.Helper()
+	srv := paddletest.NewServer()
+	t.Cleanup(srv.Close)
+
+	planID := srv.AddPlan("Pro", "month", 1, paddle.MustParseMoney("USD", "9.99"))
+	subscriptionID, err := srv.Subscribe(planID, "jane@example.com", 1)
+	if err != nil {
+		t.Fatalf("Subscribe returned error: %v", err)
+	}
+	environ := map[string]string{
+		"PADDLE_CONFIG_DIR": t.TempDir(),
+		"VENDOR_ID":         srv.VendorID,
+		"VENDOR_AUTH_CODE":  srv.VendorAuthCode,
+	}
+	return srv, subscriptionID, environ
+}
+
+// runCommand runs the command line args against srv and returns its exit
+// code, stdout and stderr.
+func runCommand(srv *paddletest.Server, environ map[string]string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"-base-url", srv.URL}, args...)
+	code := run(context.Background(), args, &stdout, &stderr, func(key string) string { return environ[key] })
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_usersList(t *testing.T) {
+	srv, subscriptionID, environ := setup(t)
+
+	code, stdout, stderr := runCommand(srv, environ, "users", "list")
+	if code != exitOK {
+		t.Fatalf("users list exited with %d: %s", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "SUBSCRIPTION_ID") || !strings.Contains(lines[1], "jane@example.com") {
+		t.Errorf("users list printed the table:\n%s", stdout)
+	}
+
+	code, stdout, stderr = runCommand(srv, environ, "-output", "json", "users", "list", "-all")
+	if code != exitOK {
+		t.Fatalf("users list -all exited with %d: %s", code, stderr)
+	}
+	var users []*paddle.User
+	if err := json.Unmarshal([]byte(stdout), &users); err != nil {
+		t.Fatalf("users list printed invalid JSON: %v", err)
+	}
+	if len(users) != 1 || *users[0].SubscriptionID != subscriptionID {
+		t.Errorf("users list printed %+v", users)
+	}
+
+	code, stdout, stderr = runCommand(srv, environ, "-output", "csv", "users", "list")
+	if code != exitOK {
+		t.Fatalf("users list exited with %d: %s", code, stderr)
+	}
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatalf("users list printed invalid CSV: %v", err)
+	}
+	if len(records) != 2 || records[0][0] != "subscription_id" || records[1][0] != strconv.Itoa(subscriptionID) {
+		t.Errorf("users list printed the CSV %v", records)
+	}
+}
+
+func TestRun_updateAndCharge(t *testing.T) {
+	srv, subscriptionID, environ := setup(t)
+	id := strconv.Itoa(subscriptionID)
+
+	code, _, stderr := runCommand(srv, environ, "users", "update", "-subscription", id, "-quantity", "2", "-price", "19.99", "-currency", "USD")
+	if code != exitOK {
+		t.Fatalf("users update exited with %d: %s", code, stderr)
+	}
+
+	code, stdout, stderr := runCommand(srv, environ, "-output", "csv", "charge", "-subscription", id, "-amount", "5", "-currency", "USD", "-name", "Setup")
+	if code != exitOK {
+		t.Fatalf("charge exited with %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, ",USD 5,") {
+		t.Errorf("charge printed:\n%s", stdout)
+	}
+
+	code, stdout, stderr = runCommand(srv, environ, "-output", "json", "payments", "list", "-subscription", id, "-one-off")
+	if code != exitOK {
+		t.Fatalf("payments list exited with %d: %s", code, stderr)
+	}
+	var payments []*paddle.Payment
+	if err := json.Unmarshal([]byte(stdout), &payments); err != nil {
+		t.Fatalf("payments list printed invalid JSON: %v", err)
+	}
+	if len(payments) != 1 || payments[0].Amount.String() != "USD 5" {
+		t.Errorf("payments list printed %s", stdout)
+	}
+}
+
+func TestRun_transactionsList(t *testing.T) {
+	srv, subscriptionID, environ := setup(t)
+
+	code, stdout, stderr := runCommand(srv, environ, "-output", "csv", "transactions", "list", "-subscription", strconv.Itoa(subscriptionID), "-all")
+	if code != exitOK {
+		t.Fatalf("transactions list exited with %d: %s", code, stderr)
+	}
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatalf("transactions list printed invalid CSV: %v", err)
+	}
+	if len(records) != 2 || records[0][0] != "order_id" {
+		t.Errorf("transactions list printed the CSV %v", records)
+	}
+
+	if code, _, _ := runCommand(srv, environ, "transactions", "list", "-user", "1", "-product", "2"); code != exitUsage {
+		t.Errorf("transactions list of two entities exited with %d, want %d", code, exitUsage)
+	}
+}
+
+func TestRun_profileFile(t *testing.T) {
+	srv, _, environ := setup(t)
+	creds := `{"vendor_id": "` + srv.VendorID + `", "vendor_auth_code": "` + srv.VendorAuthCode + `"}`
+	if err := ioutil.WriteFile(filepath.Join(environ["PADDLE_CONFIG_DIR"], "production.json"), []byte(creds), 0600); err != nil {
+		t.Fatal(err)
+	}
+	delete(environ, "VENDOR_ID")
+	delete(environ, "VENDOR_AUTH_CODE")
+
+	if code, _, stderr := runCommand(srv, environ, "-profile", "production", "plans", "list"); code != exitOK {
+		t.Errorf("plans list with the production profile exited with %d: %s", code, stderr)
+	}
+	if code, _, _ := runCommand(srv, environ, "plans", "list"); code != exitError {
+		t.Errorf("plans list without credentials exited with %d, want %d", code, exitError)
+	}
+}
+
+func TestRun_errors(t *testing.T) {
+	srv, _, environ := setup(t)
+
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{nil, exitUsage},
+		{[]string{"users"}, exitUsage},
+		{[]string{"users", "cancel"}, exitUsage},
+		{[]string{"users", "cancel", "-subscription", "x"}, exitUsage},
+		{[]string{"users", "list", "extra"}, exitUsage},
+		{[]string{"-output", "xml", "users", "list"}, exitUsage},
+		{[]string{"-profile", "staging", "users", "list"}, exitUsage},
+		{[]string{"refund", "-order", "1", "-amount", "abc", "-currency", "USD"}, exitUsage},
+		{[]string{"plans", "create", "-name", "Pro", "-type", "month", "-length", "1", "-price-usd", "9,99"}, exitUsage},
+		{[]string{"users", "cancel", "-subscription", "999"}, exitError},
+	}
+	for _, tt := range tests {
+		if code, _, _ := runCommand(srv, environ, tt.args...); code != tt.code {
+			t.Errorf("paddle %v exited with %d, want %d", tt.args, code, tt.code)
+		}
+	}
+}
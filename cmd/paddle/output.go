@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Fakerr/go-paddle/paddle"
+)
+
+var (
+	stringerType        = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	moneyByCurrencyType = reflect.TypeOf(paddle.MoneyByCurrency{})
+)
+
+// render writes v to w in format: table, json or csv. v is a struct, or a
+// slice of structs printed one per row. Their columns are named after the
+// json tags of the fields, nested structs being flattened as
+// "parent.child".
+func render(w io.Writer, format string, v interface{}) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	header, rows := table(v)
+	switch format {
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write(header)
+		cw.WriteAll(rows)
+		return cw.Error()
+	default:
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+}
+
+// table returns the header and rows of v.
+func table(v interface{}) ([]string, [][]string) {
+	rv := reflect.ValueOf(v)
+	var items []reflect.Value
+	if rv.Kind() == reflect.Slice {
+		for i := 0; i < rv.Len(); i++ {
+			items = append(items, rv.Index(i))
+		}
+	} else {
+		items = append(items, rv)
+	}
+
+	elem := rv.Type()
+	if elem.Kind() == reflect.Slice {
+		elem = elem.Elem()
+	}
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		// Lists of scalars, such as coupon codes, print one value per row.
+		rows := make([][]string, len(items))
+		for i, item := range items {
+			rows[i] = []string{cell(item)}
+		}
+		return []string{"value"}, rows
+	}
+
+	var header []string
+	columns(elem, "", nil, func(name string, _ []int) { header = append(header, name) })
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		item = reflect.Indirect(item)
+		columns(elem, "", nil, func(_ string, index []int) {
+			rows[i] = append(rows[i], cell(field(item, index)))
+		})
+	}
+	return header, rows
+}
+
+// columns calls fn with the name and field index of every column of the
+// struct type t.
+func columns(t reflect.Type, prefix string, index []int, fn func(name string, index []int)) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if f.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		name = prefix + name
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		switch {
+		case printable(ft):
+			fn(name, fieldIndex)
+		case ft.Kind() == reflect.Struct:
+			columns(ft, name+".", fieldIndex, fn)
+		}
+	}
+}
+
+// printable reports whether values of t fit in a cell. Other slices and
+// maps are left out of tables.
+func printable(t reflect.Type) bool {
+	switch {
+	case t.Implements(stringerType), t == moneyByCurrencyType:
+		return true
+	case t.Kind() == reflect.Slice:
+		return t.Elem().Kind() == reflect.String
+	case t.Kind() == reflect.Map, t.Kind() == reflect.Struct,
+		t.Kind() == reflect.Interface, t.Kind() == reflect.Func, t.Kind() == reflect.Chan:
+		return false
+	}
+	return true
+}
+
+// field returns the field of v at index, or an invalid value if a pointer
+// on the way is nil.
+func field(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		v = reflect.Indirect(v)
+		if !v.IsValid() {
+			return v
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+// cell formats v for a table or csv cell.
+func cell(v reflect.Value) string {
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+
+	if prices, ok := v.Interface().(paddle.MoneyByCurrency); ok {
+		s := make([]string, 0, len(prices))
+		for _, m := range prices {
+			s = append(s, m.String())
+		}
+		sort.Strings(s)
+		return strings.Join(s, ", ")
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+	if v.Kind() == reflect.Slice {
+		s := make([]string, v.Len())
+		for i := range s {
+			s[i] = v.Index(i).String()
+		}
+		return strings.Join(s, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
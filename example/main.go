@@ -12,11 +12,15 @@ import (
 
 func main() {
 
-	var vendorID = os.Getenv("VENDOR_ID")
-	var vendorAuthCode = os.Getenv("VENDOR_AUTH_CODE")
 	var planID = os.Getenv("PLAN_ID")
 
-	client := paddle.NewClient(vendorID, vendorAuthCode, nil)
+	// The credentials are read from the VENDOR_ID and VENDOR_AUTH_CODE
+	// environment variables.
+	client, err := paddle.New(paddle.WithCredentialsProvider(paddle.EnvCredentials()))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
 
 	options := &paddle.UsersOptions{
 		PlanID:      planID,
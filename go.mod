@@ -2,4 +2,4 @@ module github.com/Fakerr/go-paddle
 
 go 1.16
 
-require github.com/google/go-querystring v1.1.0 // indirect
+require github.com/google/go-querystring v1.1.0
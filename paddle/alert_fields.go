@@ -0,0 +1,1151 @@
+package paddle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts of the dates sent in the alerts. Paddle sends them in UTC.
+const (
+	alertTimeLayout = "2006-01-02 15:04:05"
+	alertDateLayout = "2006-01-02"
+)
+
+// ErrFieldMissing is wrapped by an *AlertFieldError when the parsed field
+// was not sent with the alert.
+var ErrFieldMissing = errors.New("field is missing")
+
+// AlertFieldError is returned when a field of an alert cannot be parsed.
+type AlertFieldError struct {
+	Field string // Name of the field in the alert payload, for example "event_time".
+	Value string // Raw value of the field.
+	Err   error  // Underlying error.
+}
+
+func (e *AlertFieldError) Error() string {
+	if errors.Is(e.Err, ErrFieldMissing) {
+		return fmt.Sprintf("alert field %s: %v", e.Field, e.Err)
+	}
+	return fmt.Sprintf("alert field %s: cannot parse %q: %v", e.Field, e.Value, e.Err)
+}
+
+func (e *AlertFieldError) Unwrap() error { return e.Err }
+
+// parseAlertTime parses a "YYYY-MM-DD HH:MM:SS" or "YYYY-MM-DD" value as a UTC time.
+func parseAlertTime(field string, v *string) (time.Time, error) {
+	if v == nil {
+		return time.Time{}, &AlertFieldError{Field: field, Err: ErrFieldMissing}
+	}
+	layout := alertTimeLayout
+	if len(*v) == len(alertDateLayout) {
+		layout = alertDateLayout
+	}
+	t, err := time.ParseInLocation(layout, *v, time.UTC)
+	if err != nil {
+		return time.Time{}, &AlertFieldError{Field: field, Value: *v, Err: err}
+	}
+	return t, nil
+}
+
+// parseAlertInt parses an integer value such as an ID or a quantity.
+func parseAlertInt(field string, v *string) (int64, error) {
+	if v == nil {
+		return 0, &AlertFieldError{Field: field, Err: ErrFieldMissing}
+	}
+	i, err := strconv.ParseInt(*v, 10, 64)
+	if err != nil {
+		return 0, &AlertFieldError{Field: field, Value: *v, Err: err}
+	}
+	return i, nil
+}
+
+// parseAlertBool parses a "0"/"1" or "false"/"true" flag.
+func parseAlertBool(field string, v *string) (bool, error) {
+	if v == nil {
+		return false, &AlertFieldError{Field: field, Err: ErrFieldMissing}
+	}
+	switch strings.ToLower(*v) {
+	case "1", "true":
+		return true, nil
+	case "0", "false", "":
+		return false, nil
+	}
+	return false, &AlertFieldError{Field: field, Value: *v, Err: errors.New("invalid boolean")}
+}
+
+// parseAlertMoney parses an amount in the given currency.
+func parseAlertMoney(field string, v *string, currency *string) (Money, error) {
+	if v == nil {
+		return Money{}, &AlertFieldError{Field: field, Err: ErrFieldMissing}
+	}
+	c := ""
+	if currency != nil {
+		c = *currency
+	}
+	m, err := ParseMoney(c, *v)
+	if err != nil {
+		return Money{}, &AlertFieldError{Field: field, Value: *v, Err: err}
+	}
+	return m, nil
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *FulfillmentWebhook) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParsePCouponSavings returns the p_coupon_savings field as an amount in the p_currency currency.
+func (a *FulfillmentWebhook) ParsePCouponSavings() (Money, error) {
+	return parseAlertMoney("p_coupon_savings", a.PCouponSavings, a.PCurrency)
+}
+
+// ParsePEarnings returns the p_earnings field as amounts in the p_currency
+// currency keyed by vendor ID. Paddle sends the earnings of each vendor of the
+// order as a JSON object, such as {"12345":"0.85"}.
+func (a *FulfillmentWebhook) ParsePEarnings() (map[string]Money, error) {
+	if a.PEarnings == nil {
+		return nil, &AlertFieldError{Field: "p_earnings", Err: ErrFieldMissing}
+	}
+	var amounts map[string]Decimal
+	if err := json.Unmarshal([]byte(*a.PEarnings), &amounts); err != nil {
+		return nil, &AlertFieldError{Field: "p_earnings", Value: *a.PEarnings, Err: err}
+	}
+	currency := ""
+	if a.PCurrency != nil {
+		currency = strings.ToUpper(*a.PCurrency)
+	}
+	earnings := make(map[string]Money, len(amounts))
+	for vendorID, amount := range amounts {
+		earnings[vendorID] = Money{Currency: currency, Amount: amount}
+	}
+	return earnings, nil
+}
+
+// ParsePPaddleFee returns the p_paddle_fee field as an amount in the p_currency currency.
+func (a *FulfillmentWebhook) ParsePPaddleFee() (Money, error) {
+	return parseAlertMoney("p_paddle_fee", a.PPaddleFee, a.PCurrency)
+}
+
+// ParsePPrice returns the p_price field as an amount in the p_currency currency.
+func (a *FulfillmentWebhook) ParsePPrice() (Money, error) {
+	return parseAlertMoney("p_price", a.PPrice, a.PCurrency)
+}
+
+// ParsePProductID returns the p_product_id field as an integer.
+func (a *FulfillmentWebhook) ParsePProductID() (int64, error) {
+	return parseAlertInt("p_product_id", a.PProductID)
+}
+
+// ParsePQuantity returns the p_quantity field as an integer.
+func (a *FulfillmentWebhook) ParsePQuantity() (int64, error) {
+	return parseAlertInt("p_quantity", a.PQuantity)
+}
+
+// ParsePSaleGross returns the p_sale_gross field as an amount in the p_currency currency.
+func (a *FulfillmentWebhook) ParsePSaleGross() (Money, error) {
+	return parseAlertMoney("p_sale_gross", a.PSaleGross, a.PCurrency)
+}
+
+// ParsePTaxAmount returns the p_tax_amount field as an amount in the p_currency currency.
+func (a *FulfillmentWebhook) ParsePTaxAmount() (Money, error) {
+	return parseAlertMoney("p_tax_amount", a.PTaxAmount, a.PCurrency)
+}
+
+// ParsePUsedPriceOverride returns the p_used_price_override field as a boolean.
+func (a *FulfillmentWebhook) ParsePUsedPriceOverride() (bool, error) {
+	return parseAlertBool("p_used_price_override", a.PUsedPriceOverride)
+}
+
+// ParseQuantity returns the quantity field as an integer.
+func (a *FulfillmentWebhook) ParseQuantity() (int64, error) {
+	return parseAlertInt("quantity", a.Quantity)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *SubscriptionCreatedAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseMarketingConsent returns the marketing_consent field as a boolean.
+func (a *SubscriptionCreatedAlert) ParseMarketingConsent() (bool, error) {
+	return parseAlertBool("marketing_consent", a.MarketingConsent)
+}
+
+// ParseNextBillDate returns the next_bill_date field as a UTC time.
+func (a *SubscriptionCreatedAlert) ParseNextBillDate() (time.Time, error) {
+	return parseAlertTime("next_bill_date", a.NextBillDate)
+}
+
+// ParseQuantity returns the quantity field as an integer.
+func (a *SubscriptionCreatedAlert) ParseQuantity() (int64, error) {
+	return parseAlertInt("quantity", a.Quantity)
+}
+
+// ParseSubscriptionID returns the subscription_id field as an integer.
+func (a *SubscriptionCreatedAlert) ParseSubscriptionID() (int64, error) {
+	return parseAlertInt("subscription_id", a.SubscriptionID)
+}
+
+// ParseSubscriptionPlanID returns the subscription_plan_id field as an integer.
+func (a *SubscriptionCreatedAlert) ParseSubscriptionPlanID() (int64, error) {
+	return parseAlertInt("subscription_plan_id", a.SubscriptionPlanID)
+}
+
+// ParseUnitPrice returns the unit_price field as an amount in the alert currency.
+func (a *SubscriptionCreatedAlert) ParseUnitPrice() (Money, error) {
+	return parseAlertMoney("unit_price", a.UnitPrice, a.Currency)
+}
+
+// ParseUserID returns the user_id field as an integer.
+func (a *SubscriptionCreatedAlert) ParseUserID() (int64, error) {
+	return parseAlertInt("user_id", a.UserID)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *SubscriptionUpdatedAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseMarketingConsent returns the marketing_consent field as a boolean.
+func (a *SubscriptionUpdatedAlert) ParseMarketingConsent() (bool, error) {
+	return parseAlertBool("marketing_consent", a.MarketingConsent)
+}
+
+// ParseNewPrice returns the new_price field as an amount in the alert currency.
+func (a *SubscriptionUpdatedAlert) ParseNewPrice() (Money, error) {
+	return parseAlertMoney("new_price", a.NewPrice, a.Currency)
+}
+
+// ParseNewQuantity returns the new_quantity field as an integer.
+func (a *SubscriptionUpdatedAlert) ParseNewQuantity() (int64, error) {
+	return parseAlertInt("new_quantity", a.NewQuantity)
+}
+
+// ParseNewUnitPrice returns the new_unit_price field as an amount in the alert currency.
+func (a *SubscriptionUpdatedAlert) ParseNewUnitPrice() (Money, error) {
+	return parseAlertMoney("new_unit_price", a.NewUnitPrice, a.Currency)
+}
+
+// ParseNextBillDate returns the next_bill_date field as a UTC time.
+func (a *SubscriptionUpdatedAlert) ParseNextBillDate() (time.Time, error) {
+	return parseAlertTime("next_bill_date", a.NextBillDate)
+}
+
+// ParseOldPrice returns the old_price field as an amount in the alert currency.
+func (a *SubscriptionUpdatedAlert) ParseOldPrice() (Money, error) {
+	return parseAlertMoney("old_price", a.OldPrice, a.Currency)
+}
+
+// ParseOldQuantity returns the old_quantity field as an integer.
+func (a *SubscriptionUpdatedAlert) ParseOldQuantity() (int64, error) {
+	return parseAlertInt("old_quantity", a.OldQuantity)
+}
+
+// ParseOldUnitPrice returns the old_unit_price field as an amount in the alert currency.
+func (a *SubscriptionUpdatedAlert) ParseOldUnitPrice() (Money, error) {
+	return parseAlertMoney("old_unit_price", a.OldUnitPrice, a.Currency)
+}
+
+// ParseSubscriptionID returns the subscription_id field as an integer.
+func (a *SubscriptionUpdatedAlert) ParseSubscriptionID() (int64, error) {
+	return parseAlertInt("subscription_id", a.SubscriptionID)
+}
+
+// ParseSubscriptionPlanID returns the subscription_plan_id field as an integer.
+func (a *SubscriptionUpdatedAlert) ParseSubscriptionPlanID() (int64, error) {
+	return parseAlertInt("subscription_plan_id", a.SubscriptionPlanID)
+}
+
+// ParseUserID returns the user_id field as an integer.
+func (a *SubscriptionUpdatedAlert) ParseUserID() (int64, error) {
+	return parseAlertInt("user_id", a.UserID)
+}
+
+// ParseOldNextBillDate returns the old_next_bill_date field as a UTC time.
+func (a *SubscriptionUpdatedAlert) ParseOldNextBillDate() (time.Time, error) {
+	return parseAlertTime("old_next_bill_date", a.OldNextBillDate)
+}
+
+// ParseOldSubscriptionPlanID returns the old_subscription_plan_id field as an integer.
+func (a *SubscriptionUpdatedAlert) ParseOldSubscriptionPlanID() (int64, error) {
+	return parseAlertInt("old_subscription_plan_id", a.OldSubscriptionPlanID)
+}
+
+// ParsePausedAt returns the paused_at field as a UTC time.
+func (a *SubscriptionUpdatedAlert) ParsePausedAt() (time.Time, error) {
+	return parseAlertTime("paused_at", a.PausedAt)
+}
+
+// ParsePausedFrom returns the paused_from field as a UTC time.
+func (a *SubscriptionUpdatedAlert) ParsePausedFrom() (time.Time, error) {
+	return parseAlertTime("paused_from", a.PausedFrom)
+}
+
+// ParseCancellationEffectiveDate returns the cancellation_effective_date field as a UTC time.
+func (a *SubscriptionCancelledAlert) ParseCancellationEffectiveDate() (time.Time, error) {
+	return parseAlertTime("cancellation_effective_date", a.CancellationEffectiveDate)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *SubscriptionCancelledAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseMarketingConsent returns the marketing_consent field as a boolean.
+func (a *SubscriptionCancelledAlert) ParseMarketingConsent() (bool, error) {
+	return parseAlertBool("marketing_consent", a.MarketingConsent)
+}
+
+// ParseQuantity returns the quantity field as an integer.
+func (a *SubscriptionCancelledAlert) ParseQuantity() (int64, error) {
+	return parseAlertInt("quantity", a.Quantity)
+}
+
+// ParseSubscriptionID returns the subscription_id field as an integer.
+func (a *SubscriptionCancelledAlert) ParseSubscriptionID() (int64, error) {
+	return parseAlertInt("subscription_id", a.SubscriptionID)
+}
+
+// ParseSubscriptionPlanID returns the subscription_plan_id field as an integer.
+func (a *SubscriptionCancelledAlert) ParseSubscriptionPlanID() (int64, error) {
+	return parseAlertInt("subscription_plan_id", a.SubscriptionPlanID)
+}
+
+// ParseUnitPrice returns the unit_price field as an amount in the alert currency.
+func (a *SubscriptionCancelledAlert) ParseUnitPrice() (Money, error) {
+	return parseAlertMoney("unit_price", a.UnitPrice, a.Currency)
+}
+
+// ParseUserID returns the user_id field as an integer.
+func (a *SubscriptionCancelledAlert) ParseUserID() (int64, error) {
+	return parseAlertInt("user_id", a.UserID)
+}
+
+// ParseBalanceEarnings returns the balance_earnings field as an amount in the balance currency.
+func (a *SubscriptionPaymentSucceededAlert) ParseBalanceEarnings() (Money, error) {
+	return parseAlertMoney("balance_earnings", a.BalanceEarnings, a.BalanceCurrency)
+}
+
+// ParseBalanceFee returns the balance_fee field as an amount in the balance currency.
+func (a *SubscriptionPaymentSucceededAlert) ParseBalanceFee() (Money, error) {
+	return parseAlertMoney("balance_fee", a.BalanceFee, a.BalanceCurrency)
+}
+
+// ParseBalanceGross returns the balance_gross field as an amount in the balance currency.
+func (a *SubscriptionPaymentSucceededAlert) ParseBalanceGross() (Money, error) {
+	return parseAlertMoney("balance_gross", a.BalanceGross, a.BalanceCurrency)
+}
+
+// ParseBalanceTax returns the balance_tax field as an amount in the balance currency.
+func (a *SubscriptionPaymentSucceededAlert) ParseBalanceTax() (Money, error) {
+	return parseAlertMoney("balance_tax", a.BalanceTax, a.BalanceCurrency)
+}
+
+// ParseEarnings returns the earnings field as an amount in the alert currency.
+func (a *SubscriptionPaymentSucceededAlert) ParseEarnings() (Money, error) {
+	return parseAlertMoney("earnings", a.Earnings, a.Currency)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *SubscriptionPaymentSucceededAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseFee returns the fee field as an amount in the alert currency.
+func (a *SubscriptionPaymentSucceededAlert) ParseFee() (Money, error) {
+	return parseAlertMoney("fee", a.Fee, a.Currency)
+}
+
+// ParseInitialPayment returns the initial_payment field as a boolean.
+func (a *SubscriptionPaymentSucceededAlert) ParseInitialPayment() (bool, error) {
+	return parseAlertBool("initial_payment", a.InitialPayment)
+}
+
+// ParseInstalments returns the instalments field as an integer.
+func (a *SubscriptionPaymentSucceededAlert) ParseInstalments() (int64, error) {
+	return parseAlertInt("instalments", a.Instalments)
+}
+
+// ParseMarketingConsent returns the marketing_consent field as a boolean.
+func (a *SubscriptionPaymentSucceededAlert) ParseMarketingConsent() (bool, error) {
+	return parseAlertBool("marketing_consent", a.MarketingConsent)
+}
+
+// ParseNextBillDate returns the next_bill_date field as a UTC time.
+func (a *SubscriptionPaymentSucceededAlert) ParseNextBillDate() (time.Time, error) {
+	return parseAlertTime("next_bill_date", a.NextBillDate)
+}
+
+// ParseNextPaymentAmount returns the next_payment_amount field as an amount in the alert currency.
+func (a *SubscriptionPaymentSucceededAlert) ParseNextPaymentAmount() (Money, error) {
+	return parseAlertMoney("next_payment_amount", a.NextPaymentAmount, a.Currency)
+}
+
+// ParsePaymentTax returns the payment_tax field as an amount in the alert currency.
+func (a *SubscriptionPaymentSucceededAlert) ParsePaymentTax() (Money, error) {
+	return parseAlertMoney("payment_tax", a.PaymentTax, a.Currency)
+}
+
+// ParseQuantity returns the quantity field as an integer.
+func (a *SubscriptionPaymentSucceededAlert) ParseQuantity() (int64, error) {
+	return parseAlertInt("quantity", a.Quantity)
+}
+
+// ParseSaleGross returns the sale_gross field as an amount in the alert currency.
+func (a *SubscriptionPaymentSucceededAlert) ParseSaleGross() (Money, error) {
+	return parseAlertMoney("sale_gross", a.SaleGross, a.Currency)
+}
+
+// ParseSubscriptionID returns the subscription_id field as an integer.
+func (a *SubscriptionPaymentSucceededAlert) ParseSubscriptionID() (int64, error) {
+	return parseAlertInt("subscription_id", a.SubscriptionID)
+}
+
+// ParseSubscriptionPaymentID returns the subscription_payment_id field as an integer.
+func (a *SubscriptionPaymentSucceededAlert) ParseSubscriptionPaymentID() (int64, error) {
+	return parseAlertInt("subscription_payment_id", a.SubscriptionPaymentID)
+}
+
+// ParseSubscriptionPlanID returns the subscription_plan_id field as an integer.
+func (a *SubscriptionPaymentSucceededAlert) ParseSubscriptionPlanID() (int64, error) {
+	return parseAlertInt("subscription_plan_id", a.SubscriptionPlanID)
+}
+
+// ParseUnitPrice returns the unit_price field as an amount in the alert currency.
+func (a *SubscriptionPaymentSucceededAlert) ParseUnitPrice() (Money, error) {
+	return parseAlertMoney("unit_price", a.UnitPrice, a.Currency)
+}
+
+// ParseUserID returns the user_id field as an integer.
+func (a *SubscriptionPaymentSucceededAlert) ParseUserID() (int64, error) {
+	return parseAlertInt("user_id", a.UserID)
+}
+
+// ParseAmount returns the amount field as an amount in the alert currency.
+func (a *SubscriptionPaymentFailedAlert) ParseAmount() (Money, error) {
+	return parseAlertMoney("amount", a.Amount, a.Currency)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *SubscriptionPaymentFailedAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseMarketingConsent returns the marketing_consent field as a boolean.
+func (a *SubscriptionPaymentFailedAlert) ParseMarketingConsent() (bool, error) {
+	return parseAlertBool("marketing_consent", a.MarketingConsent)
+}
+
+// ParseNextRetryDate returns the next_retry_date field as a UTC time.
+func (a *SubscriptionPaymentFailedAlert) ParseNextRetryDate() (time.Time, error) {
+	return parseAlertTime("next_retry_date", a.NextRetryDate)
+}
+
+// ParseQuantity returns the quantity field as an integer.
+func (a *SubscriptionPaymentFailedAlert) ParseQuantity() (int64, error) {
+	return parseAlertInt("quantity", a.Quantity)
+}
+
+// ParseSubscriptionID returns the subscription_id field as an integer.
+func (a *SubscriptionPaymentFailedAlert) ParseSubscriptionID() (int64, error) {
+	return parseAlertInt("subscription_id", a.SubscriptionID)
+}
+
+// ParseSubscriptionPlanID returns the subscription_plan_id field as an integer.
+func (a *SubscriptionPaymentFailedAlert) ParseSubscriptionPlanID() (int64, error) {
+	return parseAlertInt("subscription_plan_id", a.SubscriptionPlanID)
+}
+
+// ParseUnitPrice returns the unit_price field as an amount in the alert currency.
+func (a *SubscriptionPaymentFailedAlert) ParseUnitPrice() (Money, error) {
+	return parseAlertMoney("unit_price", a.UnitPrice, a.Currency)
+}
+
+// ParseSubscriptionPaymentID returns the subscription_payment_id field as an integer.
+func (a *SubscriptionPaymentFailedAlert) ParseSubscriptionPaymentID() (int64, error) {
+	return parseAlertInt("subscription_payment_id", a.SubscriptionPaymentID)
+}
+
+// ParseInstalments returns the instalments field as an integer.
+func (a *SubscriptionPaymentFailedAlert) ParseInstalments() (int64, error) {
+	return parseAlertInt("instalments", a.Instalments)
+}
+
+// ParseUserID returns the user_id field as an integer.
+func (a *SubscriptionPaymentFailedAlert) ParseUserID() (int64, error) {
+	return parseAlertInt("user_id", a.UserID)
+}
+
+// ParseAttemptNumber returns the attempt_number field as an integer.
+func (a *SubscriptionPaymentFailedAlert) ParseAttemptNumber() (int64, error) {
+	return parseAlertInt("attempt_number", a.AttemptNumber)
+}
+
+// ParseAmount returns the amount field as an amount in the alert currency.
+func (a *SubscriptionPaymentRefundedAlert) ParseAmount() (Money, error) {
+	return parseAlertMoney("amount", a.Amount, a.Currency)
+}
+
+// ParseBalanceEarningsDecrease returns the balance_earnings_decrease field as an amount in the balance currency.
+func (a *SubscriptionPaymentRefundedAlert) ParseBalanceEarningsDecrease() (Money, error) {
+	return parseAlertMoney("balance_earnings_decrease", a.BalanceEarningsDecrease, a.BalanceCurrency)
+}
+
+// ParseBalanceFeeRefund returns the balance_fee_refund field as an amount in the balance currency.
+func (a *SubscriptionPaymentRefundedAlert) ParseBalanceFeeRefund() (Money, error) {
+	return parseAlertMoney("balance_fee_refund", a.BalanceFeeRefund, a.BalanceCurrency)
+}
+
+// ParseBalanceGrossRefund returns the balance_gross_refund field as an amount in the balance currency.
+func (a *SubscriptionPaymentRefundedAlert) ParseBalanceGrossRefund() (Money, error) {
+	return parseAlertMoney("balance_gross_refund", a.BalanceGrossRefund, a.BalanceCurrency)
+}
+
+// ParseBalanceTaxRefund returns the balance_tax_refund field as an amount in the balance currency.
+func (a *SubscriptionPaymentRefundedAlert) ParseBalanceTaxRefund() (Money, error) {
+	return parseAlertMoney("balance_tax_refund", a.BalanceTaxRefund, a.BalanceCurrency)
+}
+
+// ParseEarningsDecrease returns the earnings_decrease field as an amount in the alert currency.
+func (a *SubscriptionPaymentRefundedAlert) ParseEarningsDecrease() (Money, error) {
+	return parseAlertMoney("earnings_decrease", a.EarningsDecrease, a.Currency)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *SubscriptionPaymentRefundedAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseFeeRefund returns the fee_refund field as an amount in the alert currency.
+func (a *SubscriptionPaymentRefundedAlert) ParseFeeRefund() (Money, error) {
+	return parseAlertMoney("fee_refund", a.FeeRefund, a.Currency)
+}
+
+// ParseGrossRefund returns the gross_refund field as an amount in the alert currency.
+func (a *SubscriptionPaymentRefundedAlert) ParseGrossRefund() (Money, error) {
+	return parseAlertMoney("gross_refund", a.GrossRefund, a.Currency)
+}
+
+// ParseInitialPayment returns the initial_payment field as a boolean.
+func (a *SubscriptionPaymentRefundedAlert) ParseInitialPayment() (bool, error) {
+	return parseAlertBool("initial_payment", a.InitialPayment)
+}
+
+// ParseInstalments returns the instalments field as an integer.
+func (a *SubscriptionPaymentRefundedAlert) ParseInstalments() (int64, error) {
+	return parseAlertInt("instalments", a.Instalments)
+}
+
+// ParseMarketingConsent returns the marketing_consent field as a boolean.
+func (a *SubscriptionPaymentRefundedAlert) ParseMarketingConsent() (bool, error) {
+	return parseAlertBool("marketing_consent", a.MarketingConsent)
+}
+
+// ParseQuantity returns the quantity field as an integer.
+func (a *SubscriptionPaymentRefundedAlert) ParseQuantity() (int64, error) {
+	return parseAlertInt("quantity", a.Quantity)
+}
+
+// ParseSubscriptionID returns the subscription_id field as an integer.
+func (a *SubscriptionPaymentRefundedAlert) ParseSubscriptionID() (int64, error) {
+	return parseAlertInt("subscription_id", a.SubscriptionID)
+}
+
+// ParseSubscriptionPaymentID returns the subscription_payment_id field as an integer.
+func (a *SubscriptionPaymentRefundedAlert) ParseSubscriptionPaymentID() (int64, error) {
+	return parseAlertInt("subscription_payment_id", a.SubscriptionPaymentID)
+}
+
+// ParseSubscriptionPlanID returns the subscription_plan_id field as an integer.
+func (a *SubscriptionPaymentRefundedAlert) ParseSubscriptionPlanID() (int64, error) {
+	return parseAlertInt("subscription_plan_id", a.SubscriptionPlanID)
+}
+
+// ParseTaxRefund returns the tax_refund field as an amount in the alert currency.
+func (a *SubscriptionPaymentRefundedAlert) ParseTaxRefund() (Money, error) {
+	return parseAlertMoney("tax_refund", a.TaxRefund, a.Currency)
+}
+
+// ParseUnitPrice returns the unit_price field as an amount in the alert currency.
+func (a *SubscriptionPaymentRefundedAlert) ParseUnitPrice() (Money, error) {
+	return parseAlertMoney("unit_price", a.UnitPrice, a.Currency)
+}
+
+// ParseUserID returns the user_id field as an integer.
+func (a *SubscriptionPaymentRefundedAlert) ParseUserID() (int64, error) {
+	return parseAlertInt("user_id", a.UserID)
+}
+
+// ParseBalanceEarnings returns the balance_earnings field as an amount in the balance currency.
+func (a *PaymentSucceededAlert) ParseBalanceEarnings() (Money, error) {
+	return parseAlertMoney("balance_earnings", a.BalanceEarnings, a.BalanceCurrency)
+}
+
+// ParseBalanceFee returns the balance_fee field as an amount in the balance currency.
+func (a *PaymentSucceededAlert) ParseBalanceFee() (Money, error) {
+	return parseAlertMoney("balance_fee", a.BalanceFee, a.BalanceCurrency)
+}
+
+// ParseBalanceGross returns the balance_gross field as an amount in the balance currency.
+func (a *PaymentSucceededAlert) ParseBalanceGross() (Money, error) {
+	return parseAlertMoney("balance_gross", a.BalanceGross, a.BalanceCurrency)
+}
+
+// ParseBalanceTax returns the balance_tax field as an amount in the balance currency.
+func (a *PaymentSucceededAlert) ParseBalanceTax() (Money, error) {
+	return parseAlertMoney("balance_tax", a.BalanceTax, a.BalanceCurrency)
+}
+
+// ParseEarnings returns the earnings field as an amount in the alert currency.
+func (a *PaymentSucceededAlert) ParseEarnings() (Money, error) {
+	return parseAlertMoney("earnings", a.Earnings, a.Currency)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *PaymentSucceededAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseFee returns the fee field as an amount in the alert currency.
+func (a *PaymentSucceededAlert) ParseFee() (Money, error) {
+	return parseAlertMoney("fee", a.Fee, a.Currency)
+}
+
+// ParseMarketingConsent returns the marketing_consent field as a boolean.
+func (a *PaymentSucceededAlert) ParseMarketingConsent() (bool, error) {
+	return parseAlertBool("marketing_consent", a.MarketingConsent)
+}
+
+// ParsePaymentTax returns the payment_tax field as an amount in the alert currency.
+func (a *PaymentSucceededAlert) ParsePaymentTax() (Money, error) {
+	return parseAlertMoney("payment_tax", a.PaymentTax, a.Currency)
+}
+
+// ParseProductID returns the product_id field as an integer.
+func (a *PaymentSucceededAlert) ParseProductID() (int64, error) {
+	return parseAlertInt("product_id", a.ProductID)
+}
+
+// ParseQuantity returns the quantity field as an integer.
+func (a *PaymentSucceededAlert) ParseQuantity() (int64, error) {
+	return parseAlertInt("quantity", a.Quantity)
+}
+
+// ParseSaleGross returns the sale_gross field as an amount in the alert currency.
+func (a *PaymentSucceededAlert) ParseSaleGross() (Money, error) {
+	return parseAlertMoney("sale_gross", a.SaleGross, a.Currency)
+}
+
+// ParseUsedPriceOverride returns the used_price_override field as a boolean.
+func (a *PaymentSucceededAlert) ParseUsedPriceOverride() (bool, error) {
+	return parseAlertBool("used_price_override", a.UsedPriceOverride)
+}
+
+// ParseAmount returns the amount field as an amount in the alert currency.
+func (a *PaymentRefundedAlert) ParseAmount() (Money, error) {
+	return parseAlertMoney("amount", a.Amount, a.Currency)
+}
+
+// ParseBalanceEarningsDecrease returns the balance_earnings_decrease field as an amount in the balance currency.
+func (a *PaymentRefundedAlert) ParseBalanceEarningsDecrease() (Money, error) {
+	return parseAlertMoney("balance_earnings_decrease", a.BalanceEarningsDecrease, a.BalanceCurrency)
+}
+
+// ParseBalanceFeeRefund returns the balance_fee_refund field as an amount in the balance currency.
+func (a *PaymentRefundedAlert) ParseBalanceFeeRefund() (Money, error) {
+	return parseAlertMoney("balance_fee_refund", a.BalanceFeeRefund, a.BalanceCurrency)
+}
+
+// ParseBalanceGrossRefund returns the balance_gross_refund field as an amount in the balance currency.
+func (a *PaymentRefundedAlert) ParseBalanceGrossRefund() (Money, error) {
+	return parseAlertMoney("balance_gross_refund", a.BalanceGrossRefund, a.BalanceCurrency)
+}
+
+// ParseBalanceTaxRefund returns the balance_tax_refund field as an amount in the balance currency.
+func (a *PaymentRefundedAlert) ParseBalanceTaxRefund() (Money, error) {
+	return parseAlertMoney("balance_tax_refund", a.BalanceTaxRefund, a.BalanceCurrency)
+}
+
+// ParseEarningsDecrease returns the earnings_decrease field as an amount in the alert currency.
+func (a *PaymentRefundedAlert) ParseEarningsDecrease() (Money, error) {
+	return parseAlertMoney("earnings_decrease", a.EarningsDecrease, a.Currency)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *PaymentRefundedAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseFeeRefund returns the fee_refund field as an amount in the alert currency.
+func (a *PaymentRefundedAlert) ParseFeeRefund() (Money, error) {
+	return parseAlertMoney("fee_refund", a.FeeRefund, a.Currency)
+}
+
+// ParseGrossRefund returns the gross_refund field as an amount in the alert currency.
+func (a *PaymentRefundedAlert) ParseGrossRefund() (Money, error) {
+	return parseAlertMoney("gross_refund", a.GrossRefund, a.Currency)
+}
+
+// ParseMarketingConsent returns the marketing_consent field as a boolean.
+func (a *PaymentRefundedAlert) ParseMarketingConsent() (bool, error) {
+	return parseAlertBool("marketing_consent", a.MarketingConsent)
+}
+
+// ParseQuantity returns the quantity field as an integer.
+func (a *PaymentRefundedAlert) ParseQuantity() (int64, error) {
+	return parseAlertInt("quantity", a.Quantity)
+}
+
+// ParseTaxRefund returns the tax_refund field as an amount in the alert currency.
+func (a *PaymentRefundedAlert) ParseTaxRefund() (Money, error) {
+	return parseAlertMoney("tax_refund", a.TaxRefund, a.Currency)
+}
+
+// ParseCheckoutRecovery returns the checkout_recovery field as a boolean.
+func (a *LockerProcessedAlert) ParseCheckoutRecovery() (bool, error) {
+	return parseAlertBool("checkout_recovery", a.CheckoutRecovery)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *LockerProcessedAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseMarketingConsent returns the marketing_consent field as a boolean.
+func (a *LockerProcessedAlert) ParseMarketingConsent() (bool, error) {
+	return parseAlertBool("marketing_consent", a.MarketingConsent)
+}
+
+// ParseProductID returns the product_id field as an integer.
+func (a *LockerProcessedAlert) ParseProductID() (int64, error) {
+	return parseAlertInt("product_id", a.ProductID)
+}
+
+// ParseQuantity returns the quantity field as an integer.
+func (a *LockerProcessedAlert) ParseQuantity() (int64, error) {
+	return parseAlertInt("quantity", a.Quantity)
+}
+
+// ParseAmount returns the amount field as an amount in the alert currency.
+func (a *PaymentDisputeCreatedAlert) ParseAmount() (Money, error) {
+	return parseAlertMoney("amount", a.Amount, a.Currency)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *PaymentDisputeCreatedAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseFeeUsd returns the fee_usd field as an amount in US dollars.
+func (a *PaymentDisputeCreatedAlert) ParseFeeUsd() (Money, error) {
+	return parseAlertMoney("fee_usd", a.FeeUsd, String("USD"))
+}
+
+// ParseMarketingConsent returns the marketing_consent field as a boolean.
+func (a *PaymentDisputeCreatedAlert) ParseMarketingConsent() (bool, error) {
+	return parseAlertBool("marketing_consent", a.MarketingConsent)
+}
+
+// ParseAmount returns the amount field as an amount in the alert currency.
+func (a *PaymentDisputeClosedAlert) ParseAmount() (Money, error) {
+	return parseAlertMoney("amount", a.Amount, a.Currency)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *PaymentDisputeClosedAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseFeeUsd returns the fee_usd field as an amount in US dollars.
+func (a *PaymentDisputeClosedAlert) ParseFeeUsd() (Money, error) {
+	return parseAlertMoney("fee_usd", a.FeeUsd, String("USD"))
+}
+
+// ParseMarketingConsent returns the marketing_consent field as a boolean.
+func (a *PaymentDisputeClosedAlert) ParseMarketingConsent() (bool, error) {
+	return parseAlertBool("marketing_consent", a.MarketingConsent)
+}
+
+// ParseCreatedAt returns the created_at field as a UTC time.
+func (a *HighRiskTransactionCreatedAlert) ParseCreatedAt() (time.Time, error) {
+	return parseAlertTime("created_at", a.CreatedAt)
+}
+
+// ParseCustomerUserID returns the customer_user_id field as an integer.
+func (a *HighRiskTransactionCreatedAlert) ParseCustomerUserID() (int64, error) {
+	return parseAlertInt("customer_user_id", a.CustomerUserID)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *HighRiskTransactionCreatedAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseMarketingConsent returns the marketing_consent field as a boolean.
+func (a *HighRiskTransactionCreatedAlert) ParseMarketingConsent() (bool, error) {
+	return parseAlertBool("marketing_consent", a.MarketingConsent)
+}
+
+// ParseProductID returns the product_id field as an integer.
+func (a *HighRiskTransactionCreatedAlert) ParseProductID() (int64, error) {
+	return parseAlertInt("product_id", a.ProductID)
+}
+
+// ParseCreatedAt returns the created_at field as a UTC time.
+func (a *HighRiskTransactionUpdatedAlert) ParseCreatedAt() (time.Time, error) {
+	return parseAlertTime("created_at", a.CreatedAt)
+}
+
+// ParseCustomerUserID returns the customer_user_id field as an integer.
+func (a *HighRiskTransactionUpdatedAlert) ParseCustomerUserID() (int64, error) {
+	return parseAlertInt("customer_user_id", a.CustomerUserID)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *HighRiskTransactionUpdatedAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseMarketingConsent returns the marketing_consent field as a boolean.
+func (a *HighRiskTransactionUpdatedAlert) ParseMarketingConsent() (bool, error) {
+	return parseAlertBool("marketing_consent", a.MarketingConsent)
+}
+
+// ParseProductID returns the product_id field as an integer.
+func (a *HighRiskTransactionUpdatedAlert) ParseProductID() (int64, error) {
+	return parseAlertInt("product_id", a.ProductID)
+}
+
+// ParseAmount returns the amount field as an amount in the alert currency.
+func (a *TransferCreatedAlert) ParseAmount() (Money, error) {
+	return parseAlertMoney("amount", a.Amount, a.Currency)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *TransferCreatedAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParsePayoutID returns the payout_id field as an integer.
+func (a *TransferCreatedAlert) ParsePayoutID() (int64, error) {
+	return parseAlertInt("payout_id", a.PayoutID)
+}
+
+// ParseAmount returns the amount field as an amount in the alert currency.
+func (a *TransferPaidAlert) ParseAmount() (Money, error) {
+	return parseAlertMoney("amount", a.Amount, a.Currency)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *TransferPaidAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParsePayoutID returns the payout_id field as an integer.
+func (a *TransferPaidAlert) ParsePayoutID() (int64, error) {
+	return parseAlertInt("payout_id", a.PayoutID)
+}
+
+// ParseCreatedAt returns the created_at field as a UTC time.
+func (a *NewAudienceMemberAlert) ParseCreatedAt() (time.Time, error) {
+	return parseAlertTime("created_at", a.CreatedAt)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *NewAudienceMemberAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseMarketingConsent returns the marketing_consent field as a boolean.
+func (a *NewAudienceMemberAlert) ParseMarketingConsent() (bool, error) {
+	return parseAlertBool("marketing_consent", a.MarketingConsent)
+}
+
+// ParseSubscribed returns the subscribed field as a boolean.
+func (a *NewAudienceMemberAlert) ParseSubscribed() (bool, error) {
+	return parseAlertBool("subscribed", a.Subscribed)
+}
+
+// ParseUserID returns the user_id field as an integer.
+func (a *NewAudienceMemberAlert) ParseUserID() (int64, error) {
+	return parseAlertInt("user_id", a.UserID)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *UpdateAudienceMemberAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParseNewMarketingConsent returns the new_marketing_consent field as a boolean.
+func (a *UpdateAudienceMemberAlert) ParseNewMarketingConsent() (bool, error) {
+	return parseAlertBool("new_marketing_consent", a.NewMarketingConsent)
+}
+
+// ParseOldMarketingConsent returns the old_marketing_consent field as a boolean.
+func (a *UpdateAudienceMemberAlert) ParseOldMarketingConsent() (bool, error) {
+	return parseAlertBool("old_marketing_consent", a.OldMarketingConsent)
+}
+
+// ParseUpdatedAt returns the updated_at field as a UTC time.
+func (a *UpdateAudienceMemberAlert) ParseUpdatedAt() (time.Time, error) {
+	return parseAlertTime("updated_at", a.UpdatedAt)
+}
+
+// ParseUserID returns the user_id field as an integer.
+func (a *UpdateAudienceMemberAlert) ParseUserID() (int64, error) {
+	return parseAlertInt("user_id", a.UserID)
+}
+
+// ParsePaymentID returns the payment_id field as an integer.
+func (a *InvoicePaidAlert) ParsePaymentID() (int64, error) {
+	return parseAlertInt("payment_id", a.PaymentID)
+}
+
+// ParseAmount returns the amount field as an amount in the alert currency.
+func (a *InvoicePaidAlert) ParseAmount() (Money, error) {
+	return parseAlertMoney("amount", a.Amount, a.Currency)
+}
+
+// ParseSaleGross returns the sale_gross field as an amount in the alert currency.
+func (a *InvoicePaidAlert) ParseSaleGross() (Money, error) {
+	return parseAlertMoney("sale_gross", a.SaleGross, a.Currency)
+}
+
+// ParseTermDays returns the term_days field as an integer.
+func (a *InvoicePaidAlert) ParseTermDays() (int64, error) {
+	return parseAlertInt("term_days", a.TermDays)
+}
+
+// ParseInvoicedAt returns the invoiced_at field as a UTC time.
+func (a *InvoicePaidAlert) ParseInvoicedAt() (time.Time, error) {
+	return parseAlertTime("invoiced_at", a.InvoicedAt)
+}
+
+// ParseProductID returns the product_id field as an integer.
+func (a *InvoicePaidAlert) ParseProductID() (int64, error) {
+	return parseAlertInt("product_id", a.ProductID)
+}
+
+// ParseCustomerID returns the customer_id field as an integer.
+func (a *InvoicePaidAlert) ParseCustomerID() (int64, error) {
+	return parseAlertInt("customer_id", a.CustomerID)
+}
+
+// ParseContractID returns the contract_id field as an integer.
+func (a *InvoicePaidAlert) ParseContractID() (int64, error) {
+	return parseAlertInt("contract_id", a.ContractID)
+}
+
+// ParseContractStartDate returns the contract_start_date field as a UTC time.
+func (a *InvoicePaidAlert) ParseContractStartDate() (time.Time, error) {
+	return parseAlertTime("contract_start_date", a.ContractStartDate)
+}
+
+// ParseContractEndDate returns the contract_end_date field as a UTC time.
+func (a *InvoicePaidAlert) ParseContractEndDate() (time.Time, error) {
+	return parseAlertTime("contract_end_date", a.ContractEndDate)
+}
+
+// ParseDateCreated returns the date_created field as a UTC time.
+func (a *InvoicePaidAlert) ParseDateCreated() (time.Time, error) {
+	return parseAlertTime("date_created", a.DateCreated)
+}
+
+// ParsePaymentTax returns the payment_tax field as an amount in the alert currency.
+func (a *InvoicePaidAlert) ParsePaymentTax() (Money, error) {
+	return parseAlertMoney("payment_tax", a.PaymentTax, a.Currency)
+}
+
+// ParseFee returns the fee field as an amount in the alert currency.
+func (a *InvoicePaidAlert) ParseFee() (Money, error) {
+	return parseAlertMoney("fee", a.Fee, a.Currency)
+}
+
+// ParseEarnings returns the earnings field as an amount in the alert currency.
+func (a *InvoicePaidAlert) ParseEarnings() (Money, error) {
+	return parseAlertMoney("earnings", a.Earnings, a.Currency)
+}
+
+// ParseBalanceEarnings returns the balance_earnings field as an amount in the balance currency.
+func (a *InvoicePaidAlert) ParseBalanceEarnings() (Money, error) {
+	return parseAlertMoney("balance_earnings", a.BalanceEarnings, a.BalanceCurrency)
+}
+
+// ParseBalanceFee returns the balance_fee field as an amount in the balance currency.
+func (a *InvoicePaidAlert) ParseBalanceFee() (Money, error) {
+	return parseAlertMoney("balance_fee", a.BalanceFee, a.BalanceCurrency)
+}
+
+// ParseBalanceTax returns the balance_tax field as an amount in the balance currency.
+func (a *InvoicePaidAlert) ParseBalanceTax() (Money, error) {
+	return parseAlertMoney("balance_tax", a.BalanceTax, a.BalanceCurrency)
+}
+
+// ParseBalanceGross returns the balance_gross field as an amount in the balance currency.
+func (a *InvoicePaidAlert) ParseBalanceGross() (Money, error) {
+	return parseAlertMoney("balance_gross", a.BalanceGross, a.BalanceCurrency)
+}
+
+// ParseDateReconciled returns the date_reconciled field as a UTC time.
+func (a *InvoicePaidAlert) ParseDateReconciled() (time.Time, error) {
+	return parseAlertTime("date_reconciled", a.DateReconciled)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *InvoicePaidAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParsePaymentID returns the payment_id field as an integer.
+func (a *InvoiceSentAlert) ParsePaymentID() (int64, error) {
+	return parseAlertInt("payment_id", a.PaymentID)
+}
+
+// ParseAmount returns the amount field as an amount in the alert currency.
+func (a *InvoiceSentAlert) ParseAmount() (Money, error) {
+	return parseAlertMoney("amount", a.Amount, a.Currency)
+}
+
+// ParseSaleGross returns the sale_gross field as an amount in the alert currency.
+func (a *InvoiceSentAlert) ParseSaleGross() (Money, error) {
+	return parseAlertMoney("sale_gross", a.SaleGross, a.Currency)
+}
+
+// ParseTermDays returns the term_days field as an integer.
+func (a *InvoiceSentAlert) ParseTermDays() (int64, error) {
+	return parseAlertInt("term_days", a.TermDays)
+}
+
+// ParseInvoicedAt returns the invoiced_at field as a UTC time.
+func (a *InvoiceSentAlert) ParseInvoicedAt() (time.Time, error) {
+	return parseAlertTime("invoiced_at", a.InvoicedAt)
+}
+
+// ParseProductID returns the product_id field as an integer.
+func (a *InvoiceSentAlert) ParseProductID() (int64, error) {
+	return parseAlertInt("product_id", a.ProductID)
+}
+
+// ParseCustomerID returns the customer_id field as an integer.
+func (a *InvoiceSentAlert) ParseCustomerID() (int64, error) {
+	return parseAlertInt("customer_id", a.CustomerID)
+}
+
+// ParseContractID returns the contract_id field as an integer.
+func (a *InvoiceSentAlert) ParseContractID() (int64, error) {
+	return parseAlertInt("contract_id", a.ContractID)
+}
+
+// ParseContractStartDate returns the contract_start_date field as a UTC time.
+func (a *InvoiceSentAlert) ParseContractStartDate() (time.Time, error) {
+	return parseAlertTime("contract_start_date", a.ContractStartDate)
+}
+
+// ParseContractEndDate returns the contract_end_date field as a UTC time.
+func (a *InvoiceSentAlert) ParseContractEndDate() (time.Time, error) {
+	return parseAlertTime("contract_end_date", a.ContractEndDate)
+}
+
+// ParseDateCreated returns the date_created field as a UTC time.
+func (a *InvoiceSentAlert) ParseDateCreated() (time.Time, error) {
+	return parseAlertTime("date_created", a.DateCreated)
+}
+
+// ParsePaymentTax returns the payment_tax field as an amount in the alert currency.
+func (a *InvoiceSentAlert) ParsePaymentTax() (Money, error) {
+	return parseAlertMoney("payment_tax", a.PaymentTax, a.Currency)
+}
+
+// ParseFee returns the fee field as an amount in the alert currency.
+func (a *InvoiceSentAlert) ParseFee() (Money, error) {
+	return parseAlertMoney("fee", a.Fee, a.Currency)
+}
+
+// ParseEarnings returns the earnings field as an amount in the alert currency.
+func (a *InvoiceSentAlert) ParseEarnings() (Money, error) {
+	return parseAlertMoney("earnings", a.Earnings, a.Currency)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *InvoiceSentAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
+
+// ParsePaymentID returns the payment_id field as an integer.
+func (a *InvoiceOverdueAlert) ParsePaymentID() (int64, error) {
+	return parseAlertInt("payment_id", a.PaymentID)
+}
+
+// ParseAmount returns the amount field as an amount in the alert currency.
+func (a *InvoiceOverdueAlert) ParseAmount() (Money, error) {
+	return parseAlertMoney("amount", a.Amount, a.Currency)
+}
+
+// ParseSaleGross returns the sale_gross field as an amount in the alert currency.
+func (a *InvoiceOverdueAlert) ParseSaleGross() (Money, error) {
+	return parseAlertMoney("sale_gross", a.SaleGross, a.Currency)
+}
+
+// ParseTermDays returns the term_days field as an integer.
+func (a *InvoiceOverdueAlert) ParseTermDays() (int64, error) {
+	return parseAlertInt("term_days", a.TermDays)
+}
+
+// ParseInvoicedAt returns the invoiced_at field as a UTC time.
+func (a *InvoiceOverdueAlert) ParseInvoicedAt() (time.Time, error) {
+	return parseAlertTime("invoiced_at", a.InvoicedAt)
+}
+
+// ParseProductID returns the product_id field as an integer.
+func (a *InvoiceOverdueAlert) ParseProductID() (int64, error) {
+	return parseAlertInt("product_id", a.ProductID)
+}
+
+// ParseCustomerID returns the customer_id field as an integer.
+func (a *InvoiceOverdueAlert) ParseCustomerID() (int64, error) {
+	return parseAlertInt("customer_id", a.CustomerID)
+}
+
+// ParseContractID returns the contract_id field as an integer.
+func (a *InvoiceOverdueAlert) ParseContractID() (int64, error) {
+	return parseAlertInt("contract_id", a.ContractID)
+}
+
+// ParseContractStartDate returns the contract_start_date field as a UTC time.
+func (a *InvoiceOverdueAlert) ParseContractStartDate() (time.Time, error) {
+	return parseAlertTime("contract_start_date", a.ContractStartDate)
+}
+
+// ParseContractEndDate returns the contract_end_date field as a UTC time.
+func (a *InvoiceOverdueAlert) ParseContractEndDate() (time.Time, error) {
+	return parseAlertTime("contract_end_date", a.ContractEndDate)
+}
+
+// ParseDateCreated returns the date_created field as a UTC time.
+func (a *InvoiceOverdueAlert) ParseDateCreated() (time.Time, error) {
+	return parseAlertTime("date_created", a.DateCreated)
+}
+
+// ParsePaymentTax returns the payment_tax field as an amount in the alert currency.
+func (a *InvoiceOverdueAlert) ParsePaymentTax() (Money, error) {
+	return parseAlertMoney("payment_tax", a.PaymentTax, a.Currency)
+}
+
+// ParseFee returns the fee field as an amount in the alert currency.
+func (a *InvoiceOverdueAlert) ParseFee() (Money, error) {
+	return parseAlertMoney("fee", a.Fee, a.Currency)
+}
+
+// ParseEarnings returns the earnings field as an amount in the alert currency.
+func (a *InvoiceOverdueAlert) ParseEarnings() (Money, error) {
+	return parseAlertMoney("earnings", a.Earnings, a.Currency)
+}
+
+// ParseEventTime returns the event_time field as a UTC time.
+func (a *InvoiceOverdueAlert) ParseEventTime() (time.Time, error) {
+	return parseAlertTime("event_time", a.EventTime)
+}
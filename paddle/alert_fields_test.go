@@ -0,0 +1,95 @@
+package paddle
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAlertFields(t *testing.T) {
+	alert, err := ParsePayload(map[string]string{
+		"alert_name":        "subscription_payment_succeeded",
+		"event_time":        "2021-06-01 12:30:45",
+		"next_bill_date":    "2021-07-01",
+		"subscription_id":   "123",
+		"quantity":          "2",
+		"marketing_consent": "1",
+		"initial_payment":   "0",
+		"currency":          "EUR",
+		"sale_gross":        "19.98",
+		"balance_currency":  "USD",
+		"balance_gross":     "23.50",
+	})
+	if err != nil {
+		t.Fatalf("ParsePayload returned error: %v", err)
+	}
+	a := alert.(*SubscriptionPaymentSucceededAlert)
+
+	if got, err := a.ParseEventTime(); err != nil || !got.Equal(time.Date(2021, 6, 1, 12, 30, 45, 0, time.UTC)) {
+		t.Errorf("ParseEventTime returned %v, %v", got, err)
+	}
+	if got, err := a.ParseNextBillDate(); err != nil || !got.Equal(time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParseNextBillDate returned %v, %v", got, err)
+	}
+	if got, err := a.ParseSubscriptionID(); err != nil || got != 123 {
+		t.Errorf("ParseSubscriptionID returned %v, %v", got, err)
+	}
+	if got, err := a.ParseMarketingConsent(); err != nil || !got {
+		t.Errorf("ParseMarketingConsent returned %v, %v", got, err)
+	}
+	if got, err := a.ParseInitialPayment(); err != nil || got {
+		t.Errorf("ParseInitialPayment returned %v, %v", got, err)
+	}
+	if got, err := a.ParseSaleGross(); err != nil || got != MustParseMoney("EUR", "19.98") {
+		t.Errorf("ParseSaleGross returned %v, %v", got, err)
+	}
+	if got, err := a.ParseBalanceGross(); err != nil || got != MustParseMoney("USD", "23.5") {
+		t.Errorf("ParseBalanceGross returned %v, %v", got, err)
+	}
+}
+
+func TestFulfillmentWebhook_ParsePEarnings(t *testing.T) {
+	alert, err := ParsePayload(map[string]string{
+		"p_order_id":   "1234",
+		"p_currency":   "eur",
+		"p_price":      "10.00",
+		"p_earnings":   `{"12345":"8.35","67890":"0.85"}`,
+		"p_paddle_fee": "0.80",
+	})
+	if err != nil {
+		t.Fatalf("ParsePayload returned error: %v", err)
+	}
+	a := alert.(*FulfillmentWebhook)
+
+	got, err := a.ParsePEarnings()
+	if err != nil {
+		t.Fatalf("ParsePEarnings returned error: %v", err)
+	}
+	want := map[string]Money{
+		"12345": MustParseMoney("EUR", "8.35"),
+		"67890": MustParseMoney("EUR", "0.85"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParsePEarnings returned %v, want %v", got, want)
+	}
+
+	a.PEarnings = String("9.99")
+	var fieldError *AlertFieldError
+	if _, err := a.ParsePEarnings(); !errors.As(err, &fieldError) || fieldError.Field != "p_earnings" {
+		t.Errorf("ParsePEarnings of a plain amount returned error %v, want an *AlertFieldError for p_earnings", err)
+	}
+}
+
+func TestAlertFields_errors(t *testing.T) {
+	a := &SubscriptionCreatedAlert{EventTime: String("01/06/2021")}
+
+	var fieldError *AlertFieldError
+	if _, err := a.ParseEventTime(); !errors.As(err, &fieldError) || fieldError.Field != "event_time" {
+		t.Errorf("ParseEventTime returned error %v, want an *AlertFieldError for event_time", err)
+	}
+
+	if _, err := a.ParseQuantity(); !errors.Is(err, ErrFieldMissing) {
+		t.Errorf("ParseQuantity returned error %v, want %v", err, ErrFieldMissing)
+	}
+}
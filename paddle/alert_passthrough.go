@@ -0,0 +1,85 @@
+package paddle
+
+// The DecodePassthrough methods verify and decode the passthrough field of
+// the alerts with PassthroughCodec.Decode. ErrNoPassthrough is returned if
+// the alert has no passthrough.
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *FulfillmentWebhook) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *SubscriptionCreatedAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *SubscriptionUpdatedAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *SubscriptionCancelledAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *SubscriptionPaymentSucceededAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *SubscriptionPaymentFailedAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *SubscriptionPaymentRefundedAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *PaymentSucceededAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *PaymentRefundedAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *PaymentDisputeCreatedAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *PaymentDisputeClosedAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *HighRiskTransactionCreatedAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *HighRiskTransactionUpdatedAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *InvoicePaidAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *InvoiceSentAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
+
+// DecodePassthrough decodes the passthrough field into v with c.
+func (a *InvoiceOverdueAlert) DecodePassthrough(c *PassthroughCodec, v interface{}) error {
+	return c.decodeField(a.Passthrough, v)
+}
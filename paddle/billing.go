@@ -0,0 +1,352 @@
+package paddle
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// billingSignatureHeader is the header carrying the signature of Paddle
+// Billing notifications.
+const billingSignatureHeader = "Paddle-Signature"
+
+// DefaultBillingTolerance is the maximum difference between the timestamp of a
+// Paddle Billing signature and the current time accepted by default.
+const DefaultBillingTolerance = 5 * time.Second
+
+// maxBillingBodySize is the size of the largest notification body read by
+// VerifyRequest. Paddle Billing notifications are a few kilobytes.
+const maxBillingBodySize = 1 << 20
+
+var (
+	// ErrMissingBillingSignature is returned when a Paddle Billing
+	// notification has no Paddle-Signature header.
+	ErrMissingBillingSignature = errors.New("paddle: missing Paddle-Signature header")
+
+	// ErrMalformedBillingSignature is returned when the Paddle-Signature
+	// header cannot be parsed.
+	ErrMalformedBillingSignature = errors.New("paddle: malformed Paddle-Signature header")
+
+	// ErrBillingSignatureExpired is returned when the timestamp of the
+	// Paddle-Signature header is outside of the tolerance.
+	ErrBillingSignatureExpired = errors.New("paddle: Paddle-Signature timestamp outside of the tolerance")
+
+	// ErrBillingBodyTooLarge is returned when the body of a notification is
+	// larger than 1 MiB.
+	ErrBillingBodyTooLarge = errors.New("paddle: notification body too large")
+)
+
+// namedSecret is a Paddle Billing secret key along with its name.
+type namedSecret struct {
+	name   string
+	secret []byte
+}
+
+// BillingVerifier verifies the Paddle-Signature header of Paddle Billing
+// notifications against a set of named secret keys. Its zero value matches
+// no key.
+//
+// Paddle Reference: https://developer.paddle.com/webhooks/signature-verification
+type BillingVerifier struct {
+	// Tolerance is the maximum difference between the timestamp of a
+	// signature and the current time. It is not checked if 0 or less.
+	Tolerance time.Duration
+
+	secrets []namedSecret
+	now     func() time.Time // Overridden by the tests, time.Now if nil.
+}
+
+// NewBillingVerifier returns a BillingVerifier for the given secret keys of
+// notification destinations, indexed by name.
+func NewBillingVerifier(secrets map[string]string) (*BillingVerifier, error) {
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	v := &BillingVerifier{Tolerance: DefaultBillingTolerance, now: time.Now}
+	for _, name := range names {
+		if secrets[name] == "" {
+			return nil, fmt.Errorf("paddle: empty secret key %q", name)
+		}
+		v.secrets = append(v.secrets, namedSecret{name: name, secret: []byte(secrets[name])})
+	}
+	return v, nil
+}
+
+// Verify verifies the Paddle-Signature header of a notification against its
+// raw body and returns the name of the secret key it matches.
+func (v *BillingVerifier) Verify(header string, body []byte) (string, error) {
+	if header == "" {
+		return "", ErrMissingBillingSignature
+	}
+
+	// The header has the form "ts=1671552777;h1=eb4d0dc8...". Several h1
+	// signatures are sent while a secret key is being rotated.
+	var ts string
+	var signatures [][]byte
+	for _, part := range strings.Split(header, ";") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			return "", ErrMalformedBillingSignature
+		}
+		switch kv[0] {
+		case "ts":
+			ts = kv[1]
+		case "h1":
+			sig, err := hex.DecodeString(kv[1])
+			if err != nil {
+				return "", ErrMalformedBillingSignature
+			}
+			signatures = append(signatures, sig)
+		}
+	}
+	timestamp, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return "", ErrMalformedBillingSignature
+	}
+
+	if v.Tolerance > 0 {
+		now := time.Now
+		if v.now != nil {
+			now = v.now
+		}
+		diff := now().Sub(time.Unix(timestamp, 0))
+		if diff > v.Tolerance || diff < -v.Tolerance {
+			return "", ErrBillingSignatureExpired
+		}
+	}
+
+	for _, s := range v.secrets {
+		expected := signBilling(s.secret, ts, body)
+		for _, sig := range signatures {
+			if hmac.Equal(sig, expected) {
+				return s.name, nil
+			}
+		}
+	}
+	return "", ErrSignatureMismatch
+}
+
+// signBilling returns the HMAC-SHA256 of "ts:body" keyed with secret.
+func signBilling(secret []byte, ts string, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts + ":"))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// SignBillingPayload returns the Paddle-Signature header of a Paddle Billing
+// notification with the given body, signed with secret at time t. It is meant
+// to build signed notifications in tests.
+func SignBillingPayload(body []byte, secret string, t time.Time) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "ts=" + ts + ";h1=" + hex.EncodeToString(signBilling([]byte(secret), ts, body))
+}
+
+// VerifyRequest verifies the Paddle Billing notification sent in r and parses
+// it. It also returns the name of the matching secret key. Bodies larger than
+// 1 MiB are rejected with ErrBillingBodyTooLarge.
+func (v *BillingVerifier) VerifyRequest(r *http.Request) (*BillingEvent, string, error) {
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBillingBodySize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(body) > maxBillingBodySize {
+		return nil, "", ErrBillingBodyTooLarge
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	secretName, err := v.Verify(r.Header.Get(billingSignatureHeader), body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	event := new(BillingEvent)
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, "", err
+	}
+	return event, secretName, nil
+}
+
+// BillingEvent is a Paddle Billing notification.
+//
+// Paddle Reference: https://developer.paddle.com/webhooks/overview
+type BillingEvent struct {
+	EventID        *string         `json:"event_id,omitempty"`
+	EventType      *string         `json:"event_type,omitempty"`
+	OccurredAt     *time.Time      `json:"occurred_at,omitempty"`
+	NotificationID *string         `json:"notification_id,omitempty"`
+	Data           json.RawMessage `json:"data,omitempty"`
+}
+
+// ParseData parses the data of the event into the struct matching its type:
+// a *BillingSubscription for subscription.* events, a *BillingTransaction for
+// transaction.* events and a *BillingCustomer for customer.* events. The data
+// of the other events is returned as a map[string]interface{}.
+func (e *BillingEvent) ParseData() (interface{}, error) {
+	var eventType string
+	if e.EventType != nil {
+		eventType = *e.EventType
+	}
+
+	var data interface{}
+	switch strings.SplitN(eventType, ".", 2)[0] {
+	case "subscription":
+		data = &BillingSubscription{}
+	case "transaction":
+		data = &BillingTransaction{}
+	case "customer":
+		data = &BillingCustomer{}
+	default:
+		data = &map[string]interface{}{}
+	}
+
+	if err := json.Unmarshal(e.Data, data); err != nil {
+		return nil, err
+	}
+	if m, ok := data.(*map[string]interface{}); ok {
+		return *m, nil
+	}
+	return data, nil
+}
+
+// BillingAmount is an amount of a Paddle Billing entity, expressed in the
+// lowest denomination of its currency.
+type BillingAmount struct {
+	Amount       *string `json:"amount,omitempty"`
+	CurrencyCode *string `json:"currency_code,omitempty"`
+}
+
+// Money returns the amount as a Money value.
+func (a *BillingAmount) Money() (Money, error) {
+	if a == nil || a.Amount == nil || a.CurrencyCode == nil {
+		return Money{}, errors.New("paddle: incomplete amount")
+	}
+	minorUnits, err := strconv.ParseInt(*a.Amount, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("paddle: invalid amount %q", *a.Amount)
+	}
+	return NewMoney(*a.CurrencyCode, minorUnits), nil
+}
+
+// BillingPrice is a Paddle Billing price.
+type BillingPrice struct {
+	ID          *string        `json:"id,omitempty"`
+	ProductID   *string        `json:"product_id,omitempty"`
+	Description *string        `json:"description,omitempty"`
+	UnitPrice   *BillingAmount `json:"unit_price,omitempty"`
+	Status      *string        `json:"status,omitempty"`
+}
+
+// BillingCycle is the billing interval of a Paddle Billing subscription.
+type BillingCycle struct {
+	Interval  *string `json:"interval,omitempty"`
+	Frequency *int    `json:"frequency,omitempty"`
+}
+
+// BillingPeriod is a period of time of a Paddle Billing subscription.
+type BillingPeriod struct {
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+}
+
+// BillingSubscriptionItem is an item of a Paddle Billing subscription.
+type BillingSubscriptionItem struct {
+	Status    *string       `json:"status,omitempty"`
+	Quantity  *int          `json:"quantity,omitempty"`
+	Recurring *bool         `json:"recurring,omitempty"`
+	Price     *BillingPrice `json:"price,omitempty"`
+}
+
+// BillingSubscription is the data of subscription.* events.
+type BillingSubscription struct {
+	ID                   *string                    `json:"id,omitempty"`
+	Status               *string                    `json:"status,omitempty"`
+	CustomerID           *string                    `json:"customer_id,omitempty"`
+	AddressID            *string                    `json:"address_id,omitempty"`
+	BusinessID           *string                    `json:"business_id,omitempty"`
+	CurrencyCode         *string                    `json:"currency_code,omitempty"`
+	CollectionMode       *string                    `json:"collection_mode,omitempty"`
+	CreatedAt            *time.Time                 `json:"created_at,omitempty"`
+	UpdatedAt            *time.Time                 `json:"updated_at,omitempty"`
+	StartedAt            *time.Time                 `json:"started_at,omitempty"`
+	FirstBilledAt        *time.Time                 `json:"first_billed_at,omitempty"`
+	NextBilledAt         *time.Time                 `json:"next_billed_at,omitempty"`
+	PausedAt             *time.Time                 `json:"paused_at,omitempty"`
+	CanceledAt           *time.Time                 `json:"canceled_at,omitempty"`
+	BillingCycle         *BillingCycle              `json:"billing_cycle,omitempty"`
+	CurrentBillingPeriod *BillingPeriod             `json:"current_billing_period,omitempty"`
+	Items                []*BillingSubscriptionItem `json:"items,omitempty"`
+	CustomData           map[string]interface{}     `json:"custom_data,omitempty"`
+}
+
+// BillingTransactionItem is an item of a Paddle Billing transaction.
+type BillingTransactionItem struct {
+	Quantity *int          `json:"quantity,omitempty"`
+	Price    *BillingPrice `json:"price,omitempty"`
+}
+
+// BillingTotals are the totals of a Paddle Billing transaction, expressed in
+// the lowest denomination of its currency.
+type BillingTotals struct {
+	Subtotal     *string `json:"subtotal,omitempty"`
+	Discount     *string `json:"discount,omitempty"`
+	Tax          *string `json:"tax,omitempty"`
+	Total        *string `json:"total,omitempty"`
+	GrandTotal   *string `json:"grand_total,omitempty"`
+	Fee          *string `json:"fee,omitempty"`
+	Earnings     *string `json:"earnings,omitempty"`
+	CurrencyCode *string `json:"currency_code,omitempty"`
+}
+
+// BillingTransactionDetails holds the breakdown of a Paddle Billing transaction.
+type BillingTransactionDetails struct {
+	Totals *BillingTotals `json:"totals,omitempty"`
+}
+
+// BillingTransaction is the data of transaction.* events.
+type BillingTransaction struct {
+	ID             *string                    `json:"id,omitempty"`
+	Status         *string                    `json:"status,omitempty"`
+	CustomerID     *string                    `json:"customer_id,omitempty"`
+	AddressID      *string                    `json:"address_id,omitempty"`
+	BusinessID     *string                    `json:"business_id,omitempty"`
+	SubscriptionID *string                    `json:"subscription_id,omitempty"`
+	InvoiceID      *string                    `json:"invoice_id,omitempty"`
+	InvoiceNumber  *string                    `json:"invoice_number,omitempty"`
+	Origin         *string                    `json:"origin,omitempty"`
+	CurrencyCode   *string                    `json:"currency_code,omitempty"`
+	CollectionMode *string                    `json:"collection_mode,omitempty"`
+	CreatedAt      *time.Time                 `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time                 `json:"updated_at,omitempty"`
+	BilledAt       *time.Time                 `json:"billed_at,omitempty"`
+	Items          []*BillingTransactionItem  `json:"items,omitempty"`
+	Details        *BillingTransactionDetails `json:"details,omitempty"`
+	CustomData     map[string]interface{}     `json:"custom_data,omitempty"`
+}
+
+// BillingCustomer is the data of customer.* events.
+type BillingCustomer struct {
+	ID               *string                `json:"id,omitempty"`
+	Name             *string                `json:"name,omitempty"`
+	Email            *string                `json:"email,omitempty"`
+	Status           *string                `json:"status,omitempty"`
+	MarketingConsent *bool                  `json:"marketing_consent,omitempty"`
+	Locale           *string                `json:"locale,omitempty"`
+	CreatedAt        *time.Time             `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time             `json:"updated_at,omitempty"`
+	CustomData       map[string]interface{} `json:"custom_data,omitempty"`
+}
@@ -0,0 +1,174 @@
+package paddle
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testBillingEvent = `{
+	"event_id": "evt_01h8441jx8x5fzfvmwcrdnr4pd",
+	"event_type": "subscription.created",
+	"occurred_at": "2023-08-21T11:57:47.390028Z",
+	"notification_id": "ntf_01h8441k1y8x7ghm3b3ra7txjx",
+	"data": {
+		"id": "sub_01h8441jn5pcwrfhwh78jqt8hk",
+		"status": "active",
+		"customer_id": "ctm_01h8441gxx2hq0ak2mt8rprm20",
+		"billing_cycle": {"interval": "month", "frequency": 1},
+		"items": [{"quantity": 1, "price": {"id": "pri_01gsz8x8sawmvhz1pv30nge1ke", "unit_price": {"amount": "3000", "currency_code": "USD"}}}]
+	}
+}`
+
+func TestBillingVerifier_Verify(t *testing.T) {
+	v, err := NewBillingVerifier(map[string]string{"old": "secret-1", "new": "secret-2"})
+	if err != nil {
+		t.Fatalf("NewBillingVerifier returned error: %v", err)
+	}
+	now := time.Unix(1692619067, 0)
+	v.now = func() time.Time { return now }
+
+	body := []byte(testBillingEvent)
+	rotated := SignBillingPayload(body, "unknown", now) + ";h1=" + hex.EncodeToString(signBilling([]byte("secret-2"), "1692619067", body))
+
+	tests := []struct {
+		name    string
+		header  string
+		want    string
+		wantErr error
+	}{
+		{"old secret", SignBillingPayload(body, "secret-1", now), "old", nil},
+		{"new secret", SignBillingPayload(body, "secret-2", now.Add(-time.Second)), "new", nil},
+		{"several signatures", rotated, "new", nil},
+		{"unknown secret", SignBillingPayload(body, "unknown", now), "", ErrSignatureMismatch},
+		{"expired", SignBillingPayload(body, "secret-1", now.Add(-time.Minute)), "", ErrBillingSignatureExpired},
+		{"missing", "", "", ErrMissingBillingSignature},
+		{"malformed", "ts=1692619067;h1=xyz", "", ErrMalformedBillingSignature},
+		{"no signature", "ts=1692619067", "", ErrMalformedBillingSignature},
+	}
+	for _, tt := range tests {
+		got, err := v.Verify(tt.header, body)
+		if got != tt.want || err != tt.wantErr {
+			t.Errorf("%s: Verify returned %q, %v, want %q, %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	if _, err := v.Verify(SignBillingPayload(body, "secret-1", now), append(body, ' ')); err != ErrSignatureMismatch {
+		t.Errorf("Verify returned %v for a tampered body, want %v", err, ErrSignatureMismatch)
+	}
+}
+
+func TestBillingVerifier_zeroValue(t *testing.T) {
+	v := &BillingVerifier{Tolerance: DefaultBillingTolerance}
+	body := []byte(testBillingEvent)
+	if _, err := v.Verify(SignBillingPayload(body, "secret", time.Now()), body); err != ErrSignatureMismatch {
+		t.Errorf("Verify of a zero BillingVerifier returned %v, want %v", err, ErrSignatureMismatch)
+	}
+}
+
+func TestBillingVerifier_VerifyRequest_tooLarge(t *testing.T) {
+	v, err := NewBillingVerifier(map[string]string{"default": "secret"})
+	if err != nil {
+		t.Fatalf("NewBillingVerifier returned error: %v", err)
+	}
+	body := bytes.Repeat([]byte(" "), maxBillingBodySize+1)
+	r := httptest.NewRequest("POST", "/webhooks", bytes.NewReader(body))
+	r.Header.Set(billingSignatureHeader, SignBillingPayload(body, "secret", time.Now()))
+	if _, _, err := v.VerifyRequest(r); err != ErrBillingBodyTooLarge {
+		t.Errorf("VerifyRequest of a large body returned %v, want %v", err, ErrBillingBodyTooLarge)
+	}
+}
+
+func TestBillingEvent_ParseData(t *testing.T) {
+	v, _ := NewBillingVerifier(map[string]string{"default": "secret"})
+	r := httptest.NewRequest("POST", "/webhooks", bytes.NewBufferString(testBillingEvent))
+	r.Header.Set("Paddle-Signature", SignBillingPayload([]byte(testBillingEvent), "secret", time.Now()))
+
+	event, _, err := v.VerifyRequest(r)
+	if err != nil {
+		t.Fatalf("VerifyRequest returned error: %v", err)
+	}
+	data, err := event.ParseData()
+	if err != nil {
+		t.Fatalf("ParseData returned error: %v", err)
+	}
+
+	subscription, ok := data.(*BillingSubscription)
+	if !ok {
+		t.Fatalf("ParseData returned %T, want *BillingSubscription", data)
+	}
+	want := &BillingSubscription{
+		ID:           String("sub_01h8441jn5pcwrfhwh78jqt8hk"),
+		Status:       String("active"),
+		CustomerID:   String("ctm_01h8441gxx2hq0ak2mt8rprm20"),
+		BillingCycle: &BillingCycle{Interval: String("month"), Frequency: Int(1)},
+		Items: []*BillingSubscriptionItem{{
+			Quantity: Int(1),
+			Price: &BillingPrice{
+				ID:        String("pri_01gsz8x8sawmvhz1pv30nge1ke"),
+				UnitPrice: &BillingAmount{Amount: String("3000"), CurrencyCode: String("USD")},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(subscription, want) {
+		t.Errorf("ParseData returned %+v, want %+v", subscription, want)
+	}
+
+	price, err := subscription.Items[0].Price.UnitPrice.Money()
+	if err != nil || price != MustParseMoney("USD", "30") {
+		t.Errorf("BillingAmount.Money returned %v, %v, want USD 30", price, err)
+	}
+}
+
+func TestWebhookHandler_AcceptBilling(t *testing.T) {
+	privateKey, publicKey := testWebhookKey(t)
+	v, _ := NewBillingVerifier(map[string]string{"billing": "secret"})
+
+	h := NewWebhookHandler(publicKey)
+	h.AcceptBilling(v)
+
+	var got []string
+	h.OnTransferPaid(func(ctx context.Context, alert *TransferPaidAlert) error {
+		got = append(got, "transfer_paid")
+		return nil
+	})
+	h.OnBillingEvent("subscription.created", func(ctx context.Context, event *BillingEvent) error {
+		got = append(got, *event.EventType+" "+DeliveryFromContext(ctx).AlertID)
+		return nil
+	})
+
+	billing := func(secret string) *http.Request {
+		r := httptest.NewRequest("POST", "/webhooks", bytes.NewBufferString(testBillingEvent))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Paddle-Signature", SignBillingPayload([]byte(testBillingEvent), secret, time.Now()))
+		return r
+	}
+
+	tests := []struct {
+		name string
+		r    *http.Request
+		want int
+	}{
+		{"classic", testWebhookRequest(t, url.Values{"alert_name": {"transfer_paid"}}, privateKey), http.StatusOK},
+		{"billing", billing("secret"), http.StatusOK},
+		{"billing with another secret", billing("other"), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, tt.r)
+		if w.Code != tt.want {
+			t.Errorf("%s: WebhookHandler responded %d, want %d", tt.name, w.Code, tt.want)
+		}
+	}
+
+	want := []string{"transfer_paid", "subscription.created evt_01h8441jx8x5fzfvmwcrdnr4pd"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Callbacks called with %v, want %v", got, want)
+	}
+}
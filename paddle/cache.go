@@ -0,0 +1,334 @@
+package paddle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultCacheTTL is the time responses are cached for when NewCache is
+// given no TTL.
+const defaultCacheTTL = 5 * time.Minute
+
+// cacheFetchTimeout bounds the requests sent for cache misses, which are not
+// cancelled along with the context of the caller as several callers may be
+// waiting for them.
+const cacheFetchTimeout = time.Minute
+
+// memorySweepInterval is the minimum interval between two removals of the
+// expired entries of a MemoryCacheBackend.
+const memorySweepInterval = time.Minute
+
+// CacheScope names a catalogue endpoint whose responses are cached, and
+// invalidated, together.
+type CacheScope string
+
+// Scopes of the responses cached by a Cache.
+const (
+	CachePlans    CacheScope = "plans"    // PlansService.List
+	CacheProducts CacheScope = "products" // ProductsService.List
+	CachePrices   CacheScope = "prices"   // PricesService.Get
+)
+
+// CacheBackend stores the responses cached by a Cache, such as an in-memory
+// map or a shared Redis instance. Implementations must be safe for
+// concurrent use.
+type CacheBackend interface {
+	// Get returns the value stored under key, and false if there is none
+	// or it expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores value under key for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// DeletePrefix removes the values whose key starts with prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// Cache is a read-through cache of the catalogue endpoints of the Paddle
+// API: PlansService.List, ProductsService.List and PricesService.Get.
+// Responses are keyed on every parameter of the request, such as the
+// country and coupons of a price lookup, and concurrent misses of the same
+// key send a single request.
+//
+// Plans.Create invalidates the cached plans and prices, and any change of
+// a coupon the cached prices. Use Invalidate after changes made outside of
+// the Client, for example in the Paddle dashboard. Errors of the backend do
+// not fail the calls, and are reported to the OnError function instead.
+//
+// Responses served from the cache do not go through the middlewares of
+// the Client, and are returned with a nil *http.Response.
+//
+// Example usage:
+//
+//	client, err := paddle.New(
+//		paddle.WithCredentials(vendorID, vendorAuthCode),
+//		paddle.WithCache(paddle.NewCache(paddle.NewMemoryCacheBackend(), 10*time.Minute)),
+//	)
+type Cache struct {
+	backend CacheBackend
+	ttl     time.Duration
+
+	onError func(ctx context.Context, err error)
+
+	mu          sync.Mutex
+	calls       map[string]*cacheCall
+	generations map[CacheScope]int // Incremented by Invalidate.
+}
+
+// cacheCall is a request in flight for a key missing from the cache.
+type cacheCall struct {
+	done     chan struct{}
+	value    json.RawMessage
+	response *http.Response
+	err      error
+}
+
+// NewCache returns a Cache storing the responses in backend for ttl. A nil
+// backend stores them in memory, and a zero ttl caches them for five
+// minutes.
+func NewCache(backend CacheBackend, ttl time.Duration) *Cache {
+	if backend == nil {
+		backend = NewMemoryCacheBackend()
+	}
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	return &Cache{
+		backend:     backend,
+		ttl:         ttl,
+		calls:       make(map[string]*cacheCall),
+		generations: make(map[CacheScope]int),
+	}
+}
+
+// OnError registers a function called with the errors of the backend which
+// do not fail the calls, for logging purposes: failures to read or store a
+// response, and to invalidate the cache after a successful change. It must
+// be called before the Cache is used.
+func (c *Cache) OnError(fn func(ctx context.Context, err error)) {
+	c.onError = fn
+}
+
+func (c *Cache) reportError(ctx context.Context, err error) {
+	if c.onError != nil {
+		c.onError(ctx, err)
+	}
+}
+
+// Invalidate removes the cached responses of the given scopes, or of every
+// scope if none is given.
+func (c *Cache) Invalidate(ctx context.Context, scopes ...CacheScope) error {
+	if len(scopes) == 0 {
+		scopes = []CacheScope{CachePlans, CacheProducts, CachePrices}
+	}
+
+	c.mu.Lock()
+	for _, scope := range scopes {
+		c.generations[scope]++
+	}
+	c.mu.Unlock()
+
+	for _, scope := range scopes {
+		if err := c.backend.DeletePrefix(ctx, cacheKeyPrefix(scope)); err != nil {
+			return fmt.Errorf("paddle: invalidating the %s cache: %w", scope, err)
+		}
+	}
+	return nil
+}
+
+// get returns the raw response to req, from the backend if it holds one and
+// from fetch otherwise. Concurrent misses of the same key share a single
+// call to fetch, which runs detached from the context of the callers so
+// that the first one giving up does not fail the others. Errors of the
+// backend are treated as misses, and failing to store a response does not
+// fail the call.
+func (c *Cache) get(ctx context.Context, scope CacheScope, req *http.Request, fetch func(ctx context.Context) (json.RawMessage, *http.Response, error)) (json.RawMessage, *http.Response, error) {
+	key := cacheKey(scope, req)
+	value, ok, err := c.backend.Get(ctx, key)
+	if err != nil {
+		c.reportError(ctx, fmt.Errorf("paddle: reading the %s cache: %w", scope, err))
+	} else if ok {
+		return value, nil, nil
+	}
+
+	c.mu.Lock()
+	call, ok := c.calls[key]
+	if !ok {
+		call = &cacheCall{done: make(chan struct{})}
+		c.calls[key] = call
+		go c.fetch(detachedContext{ctx}, scope, key, c.generations[scope], call, fetch)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.response, call.err
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
+// fetch runs the call for a key missing from the cache, and stores its
+// response unless the scope was invalidated since generation.
+func (c *Cache) fetch(ctx context.Context, scope CacheScope, key string, generation int, call *cacheCall, fetch func(ctx context.Context) (json.RawMessage, *http.Response, error)) {
+	ctx, cancel := context.WithTimeout(ctx, cacheFetchTimeout)
+	defer cancel()
+
+	call.value, call.response, call.err = fetch(ctx)
+
+	c.mu.Lock()
+	// A response fetched while the scope was invalidated may be stale.
+	fresh := generation == c.generations[scope]
+	c.mu.Unlock()
+
+	// The response is stored before the waiters are released, so that
+	// their next calls find it.
+	if call.err == nil && fresh {
+		if err := c.backend.Set(ctx, key, call.value, c.ttl); err != nil {
+			c.reportError(ctx, fmt.Errorf("paddle: storing in the %s cache: %w", scope, err))
+		}
+	}
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	c.mu.Unlock()
+	close(call.done)
+}
+
+// detachedContext carries the values of its parent, such as the operation,
+// but neither its deadline nor its cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}               { return nil }
+func (detachedContext) Err() error                          { return nil }
+func (c detachedContext) Value(key interface{}) interface{} { return c.parent.Value(key) }
+
+// cacheKeyPrefix returns the prefix of the keys of scope.
+func cacheKeyPrefix(scope CacheScope) string {
+	return "paddle:" + string(scope) + ":"
+}
+
+// cacheKey returns the key of the response to req: its URL and its
+// parameters, sorted, without the vendor auth code.
+func cacheKey(scope CacheScope, req *http.Request) string {
+	form := requestForm(req)
+	form.Del(vendorAuthCodeAttribute)
+	return cacheKeyPrefix(scope) + req.Method + " " + req.URL.Host + req.URL.Path + "?" + form.Encode()
+}
+
+// doCached is like Do, but goes through the Cache of the client when set.
+func (c *Client) doCached(ctx context.Context, scope CacheScope, req *http.Request, v interface{}) (*http.Response, error) {
+	if c.Cache == nil {
+		return c.Do(ctx, req, v)
+	}
+
+	data, response, err := c.Cache.get(ctx, scope, req, func(ctx context.Context) (json.RawMessage, *http.Response, error) {
+		var data json.RawMessage
+		response, err := c.Do(ctx, req, &data)
+		return data, response, err
+	})
+	if err != nil {
+		return response, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return response, fmt.Errorf("Unmarshal error %s\n", err)
+	}
+	return response, nil
+}
+
+// invalidateCache invalidates the given scopes of the Cache of the client,
+// if set, after a successful change. A failure does not fail the change,
+// and is reported to the OnError function of the Cache.
+func (c *Client) invalidateCache(ctx context.Context, scopes ...CacheScope) {
+	if c.Cache == nil {
+		return
+	}
+	if err := c.Cache.Invalidate(ctx, scopes...); err != nil {
+		c.Cache.reportError(ctx, err)
+	}
+}
+
+// MemoryCacheBackend is a CacheBackend keeping the values in memory.
+// Expired values are removed lazily.
+type MemoryCacheBackend struct {
+	mu        sync.Mutex
+	entries   map[string]memoryCacheEntry
+	nextSweep time.Time
+	now       func() time.Time // Overridden by the tests.
+}
+
+type memoryCacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCacheBackend returns an empty MemoryCacheBackend.
+func NewMemoryCacheBackend() *MemoryCacheBackend {
+	return &MemoryCacheBackend{
+		entries: make(map[string]memoryCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// Get implements CacheBackend.
+func (m *MemoryCacheBackend) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !m.now().Before(entry.expires) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+// Set implements CacheBackend.
+func (m *MemoryCacheBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if now.After(m.nextSweep) {
+		for k, entry := range m.entries {
+			if !now.Before(entry.expires) {
+				delete(m.entries, k)
+			}
+		}
+		m.nextSweep = now.Add(memorySweepInterval)
+	}
+	m.entries[key] = memoryCacheEntry{value: append([]byte(nil), value...), expires: now.Add(ttl)}
+	return nil
+}
+
+// DeletePrefix implements CacheBackend.
+func (m *MemoryCacheBackend) DeletePrefix(ctx context.Context, prefix string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.entries, key)
+		}
+	}
+	return nil
+}
+
+// Len returns the number of values stored, expired ones included until
+// they are removed.
+func (m *MemoryCacheBackend) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
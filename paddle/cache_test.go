@@ -0,0 +1,253 @@
+package paddle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache_plansAndProducts(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewCache(nil, time.Minute)
+
+	var plans, products int32
+	mux.HandleFunc("/2.0/subscription/plans", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&plans, 1)
+		fmt.Fprint(w, `{"success":true, "response": [{"id": 1, "recurring_price": {"USD": "9.99"}}]}`)
+	})
+	mux.HandleFunc("/2.0/product/get_products", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&products, 1)
+		fmt.Fprint(w, `{"success":true, "response": {"total": 1, "count": 1, "products": [{"id": 2}]}}`)
+	})
+	mux.HandleFunc("/2.0/subscription/plans_create", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true, "response": {"product_id": 3}}`)
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		got, _, err := client.Plans.List(ctx, nil)
+		if err != nil {
+			t.Fatalf("Plans.List returned error: %v", err)
+		}
+		want := []*Plan{{ID: Int(1), RecurringPrice: MoneyByCurrency{"USD": MustParseMoney("USD", "9.99")}}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Plans.List returned %+v, want %+v", got, want)
+		}
+		if _, _, err := client.Products.List(ctx); err != nil {
+			t.Fatalf("Products.List returned error: %v", err)
+		}
+	}
+	if _, _, err := client.Plans.List(ctx, &PlansOptions{PlanID: 1}); err != nil {
+		t.Fatalf("Plans.List returned error: %v", err)
+	}
+	if plans != 2 || products != 1 {
+		t.Errorf("Sent %d plans and %d products requests, want 2 and 1", plans, products)
+	}
+
+	if _, _, err := client.Plans.Create(ctx, "Pro", "month", 1, nil); err != nil {
+		t.Fatalf("Plans.Create returned error: %v", err)
+	}
+	client.Plans.List(ctx, nil)
+	client.Products.List(ctx)
+	if plans != 3 || products != 1 {
+		t.Errorf("Sent %d plans and %d products requests after Plans.Create, want 3 and 1", plans, products)
+	}
+}
+
+func TestCache_prices(t *testing.T) {
+	client, mux, _, teardown := checkoutSetup()
+	defer teardown()
+	client.Cache = NewCache(nil, time.Minute)
+
+	var requests int32
+	mux.HandleFunc("/2.0/prices", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprintf(w, `{"success":true, "response": {"customer_country": %q}}`, r.URL.Query().Get("customer_country"))
+	})
+	mux.HandleFunc("/2.1/product/update_coupon", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true, "response": {"updated": 1}}`)
+	})
+
+	ctx := context.Background()
+	lookups := []*PricesOptions{
+		nil,
+		{CustomerCountry: "GB"},
+		{CustomerCountry: "GB", Coupons: "SALE"},
+		{CustomerCountry: "GB", CustomerIP: "1.2.3.4"},
+	}
+	for i := 0; i < 2; i++ {
+		for _, opts := range lookups {
+			prices, _, err := client.Prices.Get(ctx, "1", opts)
+			if err != nil {
+				t.Fatalf("Prices.Get returned error: %v", err)
+			}
+			if opts != nil && *prices.CustomerCountry != opts.CustomerCountry {
+				t.Errorf("Prices.Get(%+v) returned %+v", opts, prices)
+			}
+		}
+	}
+	if requests != int32(len(lookups)) {
+		t.Errorf("Sent %d prices requests, want %d", requests, len(lookups))
+	}
+
+	if _, _, err := client.Coupons.Update(ctx, &CouponUpdateOptions{CouponCode: "SALE"}); err != nil {
+		t.Fatalf("Coupons.Update returned error: %v", err)
+	}
+	client.Prices.Get(ctx, "1", nil)
+	if requests != int32(len(lookups))+1 {
+		t.Errorf("Sent %d prices requests after Coupons.Update, want %d", requests, len(lookups)+1)
+	}
+}
+
+func TestCache_singleFlight(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewCache(nil, time.Minute)
+
+	var requests int32
+	release := make(chan struct{})
+	mux.HandleFunc("/2.0/product/get_products", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		fmt.Fprint(w, `{"success":true, "response": {"total": 0}}`)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.Products.List(context.Background()); err != nil {
+				t.Errorf("Products.List returned error: %v", err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if requests != 1 {
+		t.Errorf("Sent %d requests for concurrent misses, want 1", requests)
+	}
+}
+
+func TestCache_singleFlight_cancelledCaller(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewCache(nil, time.Minute)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	mux.HandleFunc("/2.0/product/get_products", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		fmt.Fprint(w, `{"success":true, "response": {"total": 0}}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, _, err := client.Products.List(ctx)
+		first <- err
+	}()
+	<-started
+
+	second := make(chan error)
+	go func() {
+		_, _, err := client.Products.List(context.Background())
+		second <- err
+	}()
+	cancel()
+	if err := <-first; err != context.Canceled {
+		t.Errorf("Products.List with a cancelled context returned %v, want %v", err, context.Canceled)
+	}
+
+	close(release)
+	if err := <-second; err != nil {
+		t.Errorf("Products.List waiting for a cancelled caller returned error: %v", err)
+	}
+}
+
+// failingCacheBackend is a CacheBackend whose invalidations fail.
+type failingCacheBackend struct {
+	*MemoryCacheBackend
+}
+
+func (failingCacheBackend) DeletePrefix(ctx context.Context, prefix string) error {
+	return errors.New("backend unavailable")
+}
+
+func TestCache_invalidationErrorsAreReported(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewCache(failingCacheBackend{NewMemoryCacheBackend()}, time.Minute)
+
+	var reported []error
+	client.Cache.OnError(func(ctx context.Context, err error) {
+		reported = append(reported, err)
+	})
+	mux.HandleFunc("/2.0/subscription/plans_create", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true, "response": {"product_id": 3}}`)
+	})
+
+	plan, _, err := client.Plans.Create(context.Background(), "Pro", "month", 1, nil)
+	if err != nil || *plan.ProductID != 3 {
+		t.Errorf("Plans.Create returned %+v, %v, want the created plan", plan, err)
+	}
+	if len(reported) != 1 {
+		t.Errorf("OnError was called with %v, want the invalidation error", reported)
+	}
+}
+
+func TestCache_errorsAreNotCached(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewCache(nil, time.Minute)
+
+	var requests int32
+	mux.HandleFunc("/2.0/product/get_products", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `{"success":false, "error": {"code": 120, "message": "Internal error"}}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Products.List(context.Background()); ErrorCode(err) != ErrCodeInternal {
+			t.Errorf("Products.List returned %v, want error %d", err, ErrCodeInternal)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Sent %d requests, want 2", requests)
+	}
+}
+
+func TestMemoryCacheBackend(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewMemoryCacheBackend()
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	m.Set(ctx, "paddle:plans:a", []byte("1"), time.Minute)
+	m.Set(ctx, "paddle:prices:b", []byte("2"), 2*time.Minute)
+	if value, ok, _ := m.Get(ctx, "paddle:plans:a"); !ok || string(value) != "1" {
+		t.Errorf("Get returned %q, %v, want %q, true", value, ok, "1")
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok, _ := m.Get(ctx, "paddle:plans:a"); ok {
+		t.Error("Get returned an expired value")
+	}
+	if _, ok, _ := m.Get(ctx, "paddle:prices:b"); !ok {
+		t.Error("Get did not return a value before its expiry")
+	}
+
+	m.DeletePrefix(ctx, "paddle:prices:")
+	if m.Len() != 0 {
+		t.Errorf("Len returned %d after DeletePrefix, want 0", m.Len())
+	}
+}
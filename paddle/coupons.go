@@ -17,7 +17,7 @@ type Coupon struct {
 	Coupon           *string  `json:"coupon,omitempty"`
 	Description      *string  `json:"description,omitempty"`
 	DiscountType     *string  `json:"discount_type,omitempty"`
-	DiscountAmount   *float64 `json:"discount_amount,omitempty"`
+	DiscountAmount   *Decimal `json:"discount_amount,omitempty"`
 	DiscountCurrency *string  `json:"discount_currency,omitempty"`
 	AllowedUses      *int     `json:"allowed_uses,omitempty"`
 	TimesUsed        *int     `json:"times_used,omitempty"`
@@ -52,7 +52,7 @@ func (s *CouponsService) List(ctx context.Context, productID int) ([]*Coupon, *h
 	}
 
 	couponsResponse := new(CouponsResponse)
-	response, err := s.client.Do(ctx, req, couponsResponse)
+	response, err := s.client.Do(withOperation(ctx, "Coupons.List"), req, couponsResponse)
 	if err != nil {
 		return nil, response, err
 	}
@@ -68,7 +68,7 @@ type CouponCreate struct {
 	CouponType     string  `url:"coupon_type,omitempty"`
 	ProductIds     string  `url:"product_ids,omitempty"`
 	DiscountType   string  `url:"discount_type,omitempty"`
-	DiscountAmount float64 `url:"discount_amount,omitempty"`
+	DiscountAmount Decimal `url:"discount_amount,omitempty"`
 	Currency       string  `url:"currency,omitempty"`
 	AllowedUses    int     `url:"allowed_uses,omitempty"`
 	Expires        string  `url:"expires,omitempty"`
@@ -98,10 +98,12 @@ type CouponCodes struct {
 	CouponCode []string `json:"coupon_code,omitempty"`
 }
 
-// Create a new coupon for the given product or a checkout
+// Create a new coupon for the given product or a checkout. discountAmount is
+// a percentage for percentage discounts, or an amount in the currency given
+// in the options for flat discounts.
 //
 // Paddle API docs: https://developer.paddle.com/api-reference/product-api/coupons/createcoupon
-func (s *CouponsService) Create(ctx context.Context, couponType, discountType string, discountAmount float64, options *CouponCreateOptions) (*CouponCodes, *http.Response, error) {
+func (s *CouponsService) Create(ctx context.Context, couponType, discountType string, discountAmount Decimal, options *CouponCreateOptions) (*CouponCodes, *http.Response, error) {
 	u := "2.1/product/create_coupon"
 
 	create := &CouponCreate{
@@ -127,11 +129,12 @@ func (s *CouponsService) Create(ctx context.Context, couponType, discountType st
 	}
 
 	couponCreateResponse := new(CouponCreateResponse)
-	response, err := s.client.Do(ctx, req, couponCreateResponse)
+	response, err := s.client.Do(withOperation(ctx, "Coupons.Create"), req, couponCreateResponse)
 	if err != nil {
 		return nil, response, err
 	}
 
+	s.client.invalidateCache(ctx, CachePrices)
 	return couponCreateResponse.Response, response, nil
 }
 
@@ -166,25 +169,28 @@ func (s *CouponsService) Delete(ctx context.Context, couponCode string, options
 	}
 
 	couponDeleteResponse := new(CouponDeleteResponse)
-	response, err := s.client.Do(ctx, req, couponDeleteResponse)
+	response, err := s.client.Do(withOperation(ctx, "Coupons.Delete"), req, couponDeleteResponse)
 	if err != nil {
 		return false, response, err
 	}
 
+	s.client.invalidateCache(ctx, CachePrices)
 	return couponDeleteResponse.Success, response, nil
 }
 
+// CouponUpdateOptions specifies the parameters to the CouponsService.Update
+// method. Nil fields are left unchanged.
 type CouponUpdateOptions struct {
-	CouponCode     string  `url:"coupon_code,omitempty"`
-	Group          string  `url:"group,omitempty"`
-	NewCouponCode  string  `url:"new_coupon_code,omitempty"`
-	NewGroup       string  `url:"new_group,omitempty"`
-	ProductIds     string  `url:"product_ids,omitempty"`
-	Expires        string  `url:"expires,omitempty"`
-	AllowedUses    int     `url:"allowed_uses,omitempty"`
-	Currency       string  `url:"currency,omitempty"`
-	DiscountAmount float64 `url:"discount_amount,omitempty"`
-	Recurring      int     `url:"recurring,omitempty"`
+	CouponCode     string   `url:"coupon_code,omitempty"`
+	Group          string   `url:"group,omitempty"`
+	NewCouponCode  string   `url:"new_coupon_code,omitempty"`
+	NewGroup       string   `url:"new_group,omitempty"`
+	ProductIds     string   `url:"product_ids,omitempty"`
+	Expires        string   `url:"expires,omitempty"`
+	AllowedUses    *int     `url:"allowed_uses,omitempty"`
+	Currency       string   `url:"currency,omitempty"`
+	DiscountAmount *Decimal `url:"discount_amount,omitempty"`
+	Recurring      *bool    `url:"recurring,int,omitempty"` // Sent as 1 or 0.
 }
 
 type CouponUpdateResponse struct {
@@ -205,10 +211,11 @@ func (s *CouponsService) Update(ctx context.Context, options *CouponUpdateOption
 	}
 
 	couponUpdateResponse := new(CouponUpdateResponse)
-	response, err := s.client.Do(ctx, req, couponUpdateResponse)
+	response, err := s.client.Do(withOperation(ctx, "Coupons.Update"), req, couponUpdateResponse)
 	if err != nil {
 		return nil, response, err
 	}
 
+	s.client.invalidateCache(ctx, CachePrices)
 	return couponUpdateResponse.Response.Updated, response, nil
 }
@@ -35,12 +35,12 @@ func TestCouponsService_Create(t *testing.T) {
 
 	mux.HandleFunc("/2.1/product/create_coupon", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "POST")
-		testFormValues(t, r, values{"coupon_type": "a", "discount_type": "a", "discount_amount": "10", "coupon_code": "1"})
+		testFormValues(t, r, values{"coupon_type": "a", "discount_type": "a", "discount_amount": "10.5", "coupon_code": "1"})
 		fmt.Fprint(w, `{"success":true, "response": {"coupon_code": ["1"]}}`)
 	})
 
 	options := &CouponCreateOptions{CouponCode: "1"}
-	codes, _, err := client.Coupons.Create(context.Background(), "a", "a", 10, options)
+	codes, _, err := client.Coupons.Create(context.Background(), "a", "a", MustParseDecimal("10.50"), options)
 	if err != nil {
 		t.Errorf("Coupons.Create returned error: %v", err)
 	}
@@ -0,0 +1,317 @@
+package paddle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Environment variables read by EnvCredentials. The same names, in any case,
+// are the keys of the files read by FileCredentials.
+const (
+	envVendorID         = "VENDOR_ID"
+	envVendorAuthCode   = "VENDOR_AUTH_CODE"
+	envWebhookPublicKey = "WEBHOOK_PUBLIC_KEY"
+)
+
+// Credentials holds the secrets used to talk to Paddle.
+type Credentials struct {
+	// VendorID identifies your seller account.
+	VendorID string
+
+	// VendorAuthCode is the private API key authenticating API requests.
+	VendorAuthCode string
+
+	// WebhookPublicKey is the PEM encoded public key alerts are signed with.
+	WebhookPublicKey string
+}
+
+// CredentialsProvider supplies the credentials of a Client. It is queried
+// on every request, so that the credentials can be rotated at runtime.
+// Implementations must be safe for concurrent use.
+type CredentialsProvider interface {
+	Credentials() (*Credentials, error)
+}
+
+// CredentialsFunc is an adapter allowing the use of an ordinary function as
+// a CredentialsProvider.
+type CredentialsFunc func() (*Credentials, error)
+
+// Credentials calls f.
+func (f CredentialsFunc) Credentials() (*Credentials, error) {
+	return f()
+}
+
+// StaticCredentials returns a CredentialsProvider always supplying creds.
+func StaticCredentials(creds Credentials) CredentialsProvider {
+	return CredentialsFunc(func() (*Credentials, error) {
+		c := creds
+		return &c, nil
+	})
+}
+
+// EnvCredentials returns a CredentialsProvider reading the VENDOR_ID,
+// VENDOR_AUTH_CODE and WEBHOOK_PUBLIC_KEY environment variables.
+func EnvCredentials() CredentialsProvider {
+	return CredentialsFunc(func() (*Credentials, error) {
+		return &Credentials{
+			VendorID:         os.Getenv(envVendorID),
+			VendorAuthCode:   os.Getenv(envVendorAuthCode),
+			WebhookPublicKey: os.Getenv(envWebhookPublicKey),
+		}, nil
+	})
+}
+
+// FileCredentials returns a CredentialsProvider reading the credentials from
+// the file at path. The file is parsed again whenever it changes.
+//
+// Files with a .json extension hold a JSON object, and files with a .yaml or
+// .yml extension a YAML mapping, whose keys are vendor_id, vendor_auth_code
+// and webhook_public_key. Any other file is read as a dotenv file setting
+// VENDOR_ID, VENDOR_AUTH_CODE and WEBHOOK_PUBLIC_KEY.
+func FileCredentials(path string) CredentialsProvider {
+	return &fileCredentials{path: path}
+}
+
+// fileCredentials caches the credentials read from a file along with the
+// modification time of the file.
+type fileCredentials struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	creds   *Credentials
+}
+
+func (f *fileCredentials) Credentials() (*Credentials, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.creds != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		c := *f.creds
+		return &c, nil
+	}
+
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]string
+	switch strings.ToLower(filepath.Ext(f.path)) {
+	case ".json":
+		values, err = parseJSONCredentials(data)
+	case ".yaml", ".yml":
+		values, err = parseYAMLCredentials(data)
+	default:
+		values, err = parseDotenvCredentials(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("paddle: parsing %s: %v", f.path, err)
+	}
+
+	f.creds = &Credentials{
+		VendorID:         values[strings.ToLower(envVendorID)],
+		VendorAuthCode:   values[strings.ToLower(envVendorAuthCode)],
+		WebhookPublicKey: values[strings.ToLower(envWebhookPublicKey)],
+	}
+	f.modTime, f.size = info.ModTime(), info.Size()
+
+	c := *f.creds
+	return &c, nil
+}
+
+// parseJSONCredentials parses a JSON object of strings or numbers. The keys
+// are lowercased.
+func parseJSONCredentials(data []byte) (map[string]string, error) {
+	var raw map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	for k, v := range raw {
+		switch v := v.(type) {
+		case string:
+			values[strings.ToLower(k)] = v
+		case json.Number:
+			values[strings.ToLower(k)] = v.String()
+		}
+	}
+	return values, nil
+}
+
+// parseYAMLCredentials parses the subset of YAML used by credential files: a
+// flat mapping of plain keys to scalars. Values are plain, single or double
+// quoted, or literal block scalars ("key: |"), which hold PEM keys, and may
+// contain colons. Comments start with a "#" at the beginning of a line or
+// after a space. Other YAML constructs, such as nested mappings, sequences,
+// flow collections, anchors, tags or folded scalars, are rejected rather
+// than misread. The keys are lowercased.
+func parseYAMLCredentials(data []byte) (map[string]string, error) {
+	values := map[string]string{}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			return nil, fmt.Errorf("line %d: unexpected indentation", i+1)
+		}
+
+		sep := yamlKeySeparator(trimmed)
+		if sep < 0 {
+			return nil, fmt.Errorf("line %d: expected key: value", i+1)
+		}
+		key, value := strings.ToLower(strings.TrimSpace(trimmed[:sep])), strings.TrimSpace(trimmed[sep+1:])
+		if key == "" || strings.ContainsAny(key[:1], "\"'-?[{&*!|>%@`") {
+			return nil, fmt.Errorf("line %d: unsupported YAML key %q", i+1, key)
+		}
+		if value != "" && strings.ContainsAny(value[:1], "[{&*!>%@`") || strings.HasPrefix(value, "|") && value != "|" && value != "|-" {
+			return nil, fmt.Errorf("line %d: unsupported YAML value %q", i+1, value)
+		}
+
+		if value == "|" || value == "|-" {
+			// Literal block: the following indented lines.
+			var block []string
+			for i+1 < len(lines) && (strings.TrimSpace(lines[i+1]) == "" || lines[i+1][0] == ' ' || lines[i+1][0] == '\t') {
+				i++
+				block = append(block, strings.TrimSpace(lines[i]))
+			}
+			for len(block) > 0 && block[len(block)-1] == "" {
+				block = block[:len(block)-1]
+			}
+			joined := strings.Join(block, "\n")
+			if value == "|" {
+				joined += "\n"
+			}
+			values[key] = joined
+			continue
+		}
+
+		unquoted, err := unquoteValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		values[key] = unquoted
+	}
+	return values, nil
+}
+
+// yamlKeySeparator returns the index of the colon ending the key of a YAML
+// mapping line, which is followed by a space or ends the line, or -1.
+func yamlKeySeparator(line string) int {
+	for i := 0; i < len(line); i++ {
+		if line[i] == ':' && (i+1 == len(line) || line[i+1] == ' ' || line[i+1] == '\t') {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseDotenvCredentials parses KEY=VALUE lines, optionally prefixed with
+// "export". Double quoted values may hold escaped newlines. The keys are
+// lowercased.
+func parseDotenvCredentials(data []byte) (map[string]string, error) {
+	values := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", n)
+		}
+		value, err := unquoteValue(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+		values[strings.ToLower(strings.TrimSpace(kv[0]))] = value
+	}
+	return values, scanner.Err()
+}
+
+// unquoteValue removes the quotes around a value. Escape sequences of double
+// quoted values are interpreted, and comments after the value dropped.
+func unquoteValue(value string) (string, error) {
+	if value == "" || value[0] != '"' && value[0] != '\'' {
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		return value, nil
+	}
+
+	end := closingQuote(value)
+	if end < 0 {
+		return "", errors.New("unterminated quoted value")
+	}
+	if rest := strings.TrimSpace(value[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+		return "", fmt.Errorf("unexpected %q after quoted value", rest)
+	}
+	if value[0] == '"' {
+		return strconv.Unquote(value[:end+1])
+	}
+	return value[1:end], nil
+}
+
+// closingQuote returns the index of the quote closing the quoted value, or
+// -1 if there is none. Quotes escaped with a backslash do not close double
+// quoted values.
+func closingQuote(value string) int {
+	for i := 1; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && value[0] == '"':
+			i++
+		case value[i] == value[0]:
+			return i
+		}
+	}
+	return -1
+}
+
+// WithCredentialsProvider sets the CredentialsProvider queried on every
+// request for the vendor credentials.
+func WithCredentialsProvider(provider CredentialsProvider) Option {
+	return func(c *Client) error {
+		c.Credentials = provider
+		return nil
+	}
+}
+
+// NewWebhookVerifierFromCredentials returns a WebhookVerifier for the
+// WebhookPublicKey supplied by provider, so that a single configuration
+// source sets up both the Client and the verification of alerts.
+func NewWebhookVerifierFromCredentials(provider CredentialsProvider) (*WebhookVerifier, error) {
+	creds, err := provider.Credentials()
+	if err != nil {
+		return nil, err
+	}
+	if creds.WebhookPublicKey == "" {
+		return nil, errors.New("paddle: no webhook public key in the credentials")
+	}
+	return NewWebhookVerifier(map[string][]byte{defaultKeyName: []byte(creds.WebhookPublicKey)})
+}
@@ -0,0 +1,168 @@
+package paddle
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileCredentials(t *testing.T) {
+	_, publicKey := testWebhookKey(t)
+	dir := t.TempDir()
+
+	indented := "  " + strings.ReplaceAll(strings.TrimSpace(string(publicKey)), "\n", "\n  ")
+	files := map[string]string{
+		"paddle.json": fmt.Sprintf(`{"vendor_id": 123, "vendor_auth_code": "abc", "webhook_public_key": %q}`, publicKey),
+		"paddle.yaml": "# Paddle\nvendor_id: 123\nvendor_auth_code: \"abc\"\nwebhook_public_key: |\n" + indented + "\n",
+		".env":        fmt.Sprintf("export VENDOR_ID=123\nVENDOR_AUTH_CODE='abc'\nWEBHOOK_PUBLIC_KEY=%q\n", publicKey),
+	}
+
+	want := &Credentials{VendorID: "123", VendorAuthCode: "abc", WebhookPublicKey: string(publicKey)}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := FileCredentials(path).Credentials()
+		if err != nil {
+			t.Errorf("%s: Credentials returned error: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Credentials returned %+v, want %+v", name, got, want)
+		}
+	}
+}
+
+func TestParseYAMLCredentials(t *testing.T) {
+	tests := []struct {
+		in   string
+		want map[string]string
+	}{
+		{"vendor_id: 123 # test account\n", map[string]string{"vendor_id": "123"}},
+		{"vendor_auth_code: 'a#b' # comment\n", map[string]string{"vendor_auth_code": "a#b"}},
+		{`vendor_auth_code: "a\"b:c" # comment`, map[string]string{"vendor_auth_code": `a"b:c`}},
+		{"vendor_auth_code: abc:def#1\n", map[string]string{"vendor_auth_code": "abc:def#1"}},
+		{"url: https://example.com:8080/\n", map[string]string{"url": "https://example.com:8080/"}},
+		{"---\n# comment: here\nVendor_ID:\t42\n", map[string]string{"vendor_id": "42"}},
+		{"key: |-\n  line 1\n  line 2\nother: x\n", map[string]string{"key": "line 1\nline 2", "other": "x"}},
+	}
+	for _, tt := range tests {
+		got, err := parseYAMLCredentials([]byte(tt.in))
+		if err != nil {
+			t.Errorf("parseYAMLCredentials(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseYAMLCredentials(%q) returned %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{
+		"vendor_id:123",
+		"vendor:\n  id: 123",
+		"- vendor_id: 123",
+		`"vendor_id": 123`,
+		"vendor_id: [123]",
+		"vendor_id: &id 123",
+		"webhook_public_key: >\n  folded",
+		"webhook_public_key: |+\n  kept",
+		"vendor_auth_code: 'abc",
+		`vendor_auth_code: "abc" def`,
+	} {
+		if got, err := parseYAMLCredentials([]byte(in)); err == nil {
+			t.Errorf("parseYAMLCredentials(%q) returned %q, want an error", in, got)
+		}
+	}
+}
+
+func TestFileCredentials_rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "paddle.env")
+	ioutil.WriteFile(path, []byte("VENDOR_ID=1\nVENDOR_AUTH_CODE=old\n"), 0600)
+
+	p := FileCredentials(path)
+	if creds, _ := p.Credentials(); creds.VendorAuthCode != "old" {
+		t.Errorf("Credentials returned %+v, want the old auth code", creds)
+	}
+
+	ioutil.WriteFile(path, []byte("VENDOR_ID=1\nVENDOR_AUTH_CODE=rotated\n"), 0600)
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(path, later, later)
+	if creds, _ := p.Credentials(); creds.VendorAuthCode != "rotated" {
+		t.Errorf("Credentials returned %+v, want the rotated auth code", creds)
+	}
+}
+
+func TestEnvCredentials(t *testing.T) {
+	os.Setenv("VENDOR_ID", "1")
+	os.Setenv("VENDOR_AUTH_CODE", "2")
+	defer os.Unsetenv("VENDOR_ID")
+	defer os.Unsetenv("VENDOR_AUTH_CODE")
+
+	got, _ := EnvCredentials().Credentials()
+	if want := (&Credentials{VendorID: "1", VendorAuthCode: "2"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Credentials returned %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_CredentialsProvider(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	authCode := "1"
+	client.Credentials = CredentialsFunc(func() (*Credentials, error) {
+		return &Credentials{VendorID: vendorId, VendorAuthCode: authCode}, nil
+	})
+
+	var got []string
+	mux.HandleFunc("/2.0/subscription/plans", func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.FormValue(vendorAuthCodeAttribute))
+		fmt.Fprint(w, `{"success":true, "response": []}`)
+	})
+
+	for _, code := range []string{"1", "2"} {
+		authCode = code
+		if _, _, err := client.Plans.List(context.Background(), nil); err != nil {
+			t.Errorf("Plans.List returned error: %v", err)
+		}
+	}
+	if want := []string{"1", "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Requests sent the auth codes %v, want %v", got, want)
+	}
+}
+
+func TestClient_CredentialsProvider_empty(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.Credentials = CredentialsFunc(func() (*Credentials, error) {
+		return &Credentials{VendorID: vendorId}, nil
+	})
+	mux.HandleFunc("/2.0/subscription/plans", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Plans.List sent a request without credentials")
+	})
+
+	_, _, err := client.Plans.List(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "empty vendor ID or auth code") {
+		t.Errorf("Plans.List returned %v, want an empty credentials error", err)
+	}
+}
+
+func TestNewWebhookVerifierFromCredentials(t *testing.T) {
+	_, publicKey := testWebhookKey(t)
+
+	if _, err := NewWebhookVerifierFromCredentials(StaticCredentials(Credentials{WebhookPublicKey: string(publicKey)})); err != nil {
+		t.Errorf("NewWebhookVerifierFromCredentials returned error: %v", err)
+	}
+	if _, err := NewWebhookVerifierFromCredentials(StaticCredentials(Credentials{})); err == nil {
+		t.Errorf("NewWebhookVerifierFromCredentials returned no error without a public key")
+	}
+}
@@ -0,0 +1,171 @@
+package paddle
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error codes returned by the Paddle Dashboard API.
+//
+// Paddle API docs: https://developer.paddle.com/api-reference/intro/api-error-codes
+const (
+	ErrCodeLicenseNotFound         = 100 // Unable to find requested license
+	ErrCodeBadMethodCall           = 101 // Bad method call
+	ErrCodeBadAPIKey               = 102 // Bad api key
+	ErrCodeTimestampInvalid        = 103 // Timestamp is too old or not valid
+	ErrCodeLicenseUtilized         = 104 // License code has already been utilized
+	ErrCodeLicenseNotActive        = 105 // License code is not active
+	ErrCodeActivationNotFound      = 106 // Unable to find requested activation
+	ErrCodePermissionDenied        = 107 // You don't have permission to access this resource
+	ErrCodeProductNotFound         = 108 // Unable to find requested product
+	ErrCodeInvalidCurrency         = 109 // Provided currency is not valid
+	ErrCodePurchaseNotFound        = 110 // Unable to find requested purchase
+	ErrCodeInvalidAuthToken        = 111 // Invalid authentication token
+	ErrCodeInvalidVerificationCode = 112 // Invalid verification token
+	ErrCodeInvalidPadding          = 113 // Invalid padding on decrypted string
+	ErrCodeInvalidAffiliate        = 114 // Invalid or duplicated affiliate
+	ErrCodeInvalidCommission       = 115 // Invalid or missing affiliate commission
+	ErrCodeMissingArguments        = 116 // One or more required arguments are missing
+	ErrCodeInvalidExpiration       = 117 // Provided expiration time is incorrect
+	ErrCodePriceTooLow             = 118 // Price is too low
+	ErrCodeSubscriptionNotFound    = 119 // Unable to find requested subscription
+	ErrCodeInternal                = 120 // Internal error
+	ErrCodePaymentNotFound         = 121 // Unable to find requested payment
+	ErrCodeInvalidDate             = 122 // Provided date is not valid
+	ErrCodeModifierNotFound        = 123 // Unable to find requested modifier
+)
+
+// Sentinel errors used to classify an *ErrorResponse with errors.Is.
+//
+// Example usage:
+//
+//	_, _, err := client.Users.Cancel(ctx, subscriptionID)
+//	if errors.Is(err, paddle.ErrNotFound) { ... }
+var (
+	// ErrAuth is matched by errors caused by invalid or missing vendor credentials.
+	ErrAuth = errors.New("paddle: authentication failed")
+
+	// ErrNotFound is matched by errors caused by an unknown resource.
+	ErrNotFound = errors.New("paddle: resource not found")
+
+	// ErrValidation is matched by errors caused by missing or invalid arguments.
+	ErrValidation = errors.New("paddle: validation failed")
+
+	// ErrRateLimited is matched by errors caused by Paddle throttling the requests.
+	ErrRateLimited = errors.New("paddle: rate limited")
+)
+
+var (
+	authErrorCodes = map[int]bool{
+		ErrCodeBadAPIKey:               true,
+		ErrCodeTimestampInvalid:        true,
+		ErrCodePermissionDenied:        true,
+		ErrCodeInvalidAuthToken:        true,
+		ErrCodeInvalidVerificationCode: true,
+	}
+
+	notFoundErrorCodes = map[int]bool{
+		ErrCodeLicenseNotFound:      true,
+		ErrCodeActivationNotFound:   true,
+		ErrCodeProductNotFound:      true,
+		ErrCodePurchaseNotFound:     true,
+		ErrCodeSubscriptionNotFound: true,
+		ErrCodePaymentNotFound:      true,
+		ErrCodeModifierNotFound:     true,
+	}
+
+	validationErrorCodes = map[int]bool{
+		ErrCodeBadMethodCall:     true,
+		ErrCodeLicenseUtilized:   true,
+		ErrCodeLicenseNotActive:  true,
+		ErrCodeInvalidCurrency:   true,
+		ErrCodeInvalidPadding:    true,
+		ErrCodeInvalidAffiliate:  true,
+		ErrCodeInvalidCommission: true,
+		ErrCodeMissingArguments:  true,
+		ErrCodeInvalidExpiration: true,
+		ErrCodePriceTooLow:       true,
+		ErrCodeInvalidDate:       true,
+	}
+)
+
+// Error reports the code and the message of a failed API call.
+func (e Error) Error() string {
+	return fmt.Sprintf("%d %s", e.Code, e.Message)
+}
+
+// Error reports the request that failed along with the Paddle error code and message.
+func (r *ErrorResponse) Error() string {
+	if r.Response != nil && r.Response.Request != nil {
+		return fmt.Sprintf("%v %v: %d %s",
+			r.Response.Request.Method, r.Path(), r.ErrorField.Code, r.ErrorField.Message)
+	}
+	return fmt.Sprintf("%d %s", r.ErrorField.Code, r.ErrorField.Message)
+}
+
+// Code returns the Paddle error code, or 0 if the API did not return one.
+func (r *ErrorResponse) Code() int {
+	return r.ErrorField.Code
+}
+
+// Message returns the human-readable message returned by the API.
+func (r *ErrorResponse) Message() string {
+	return r.ErrorField.Message
+}
+
+// StatusCode returns the HTTP status code of the response, or 0 if unknown.
+func (r *ErrorResponse) StatusCode() int {
+	if r.Response == nil {
+		return 0
+	}
+	return r.Response.StatusCode
+}
+
+// Path returns the path of the request that caused the error.
+func (r *ErrorResponse) Path() string {
+	if r.Response == nil || r.Response.Request == nil || r.Response.Request.URL == nil {
+		return ""
+	}
+	return r.Response.Request.URL.Path
+}
+
+// Is reports whether the error belongs to the class described by target.
+// It allows an *ErrorResponse to be matched against ErrAuth, ErrNotFound,
+// ErrValidation and ErrRateLimited with errors.Is.
+func (r *ErrorResponse) Is(target error) bool {
+	code := r.ErrorField.Code
+	switch target {
+	case ErrAuth:
+		return authErrorCodes[code] || r.StatusCode() == http.StatusUnauthorized || r.StatusCode() == http.StatusForbidden
+	case ErrNotFound:
+		return notFoundErrorCodes[code]
+	case ErrValidation:
+		return validationErrorCodes[code]
+	case ErrRateLimited:
+		return r.StatusCode() == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// IsAuthError reports whether err was caused by invalid or missing vendor credentials.
+func IsAuthError(err error) bool { return errors.Is(err, ErrAuth) }
+
+// IsNotFound reports whether err was caused by an unknown resource.
+func IsNotFound(err error) bool { return errors.Is(err, ErrNotFound) }
+
+// IsValidationError reports whether err was caused by missing or invalid arguments.
+func IsValidationError(err error) bool { return errors.Is(err, ErrValidation) }
+
+// IsRateLimited reports whether err was caused by Paddle throttling the requests.
+func IsRateLimited(err error) bool { return errors.Is(err, ErrRateLimited) }
+
+// ErrorCode returns the Paddle error code carried by err, or 0 if err
+// is not an *ErrorResponse.
+func ErrorCode(err error) int {
+	var errorResponse *ErrorResponse
+	if errors.As(err, &errorResponse) {
+		return errorResponse.Code()
+	}
+	return 0
+}
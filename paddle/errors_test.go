@@ -0,0 +1,76 @@
+package paddle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDo_errorResponse(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/2.0/subscription/users_cancel", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":false, "error": {"code": 119, "message": "Unable to find requested subscription"}}`)
+	})
+
+	_, _, err := client.Users.Cancel(context.Background(), 1)
+
+	var errorResponse *ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		t.Fatalf("Users.Cancel returned error %v, want *ErrorResponse", err)
+	}
+	if got, want := errorResponse.Code(), ErrCodeSubscriptionNotFound; got != want {
+		t.Errorf("ErrorResponse.Code returned %v, want %v", got, want)
+	}
+	if got, want := errorResponse.Path(), "/2.0/subscription/users_cancel"; got != want {
+		t.Errorf("ErrorResponse.Path returned %v, want %v", got, want)
+	}
+	if got, want := err.Error(), "POST /2.0/subscription/users_cancel: 119 Unable to find requested subscription"; got != want {
+		t.Errorf("ErrorResponse.Error returned %q, want %q", got, want)
+	}
+	if !IsNotFound(err) || IsAuthError(err) || IsValidationError(err) || IsRateLimited(err) {
+		t.Errorf("ErrorResponse classified incorrectly: %v", err)
+	}
+}
+
+func TestDo_errorResponseNonJSON(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/2.0/subscription/users", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `<html>Too many requests</html>`)
+	})
+
+	_, _, err := client.Users.List(context.Background(), nil)
+	if !IsRateLimited(err) {
+		t.Errorf("Users.List returned error %v, want rate limited", err)
+	}
+	if got, want := ErrorCode(err), 0; got != want {
+		t.Errorf("ErrorCode returned %v, want %v", got, want)
+	}
+}
+
+func TestErrorResponse_Is(t *testing.T) {
+	tests := []struct {
+		code int
+		want error
+	}{
+		{ErrCodeBadAPIKey, ErrAuth},
+		{ErrCodePermissionDenied, ErrAuth},
+		{ErrCodeSubscriptionNotFound, ErrNotFound},
+		{ErrCodeModifierNotFound, ErrNotFound},
+		{ErrCodeMissingArguments, ErrValidation},
+		{ErrCodeInvalidCurrency, ErrValidation},
+	}
+
+	for _, tt := range tests {
+		err := &ErrorResponse{ErrorField: Error{Code: tt.code}}
+		if !errors.Is(err, tt.want) {
+			t.Errorf("errors.Is(%d, %v) returned false, want true", tt.code, tt.want)
+		}
+	}
+}
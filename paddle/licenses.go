@@ -0,0 +1,124 @@
+package paddle
+
+import (
+	"context"
+	"net/http"
+)
+
+// LicensesService handles communication with the license related
+// methods of the Paddle API.
+//
+// Paddle API docs: https://developer.paddle.com/api-reference/product-api/licenses/
+type LicensesService service
+
+// License represents a license code generated by Paddle.
+type License struct {
+	LicenseCode *string `json:"license_code,omitempty"`
+	ExpiresAt   *string `json:"expires_at,omitempty"`
+}
+
+// LicenseGenerate is the request sent by LicensesService.Generate.
+type LicenseGenerate struct {
+	ProductID   int    `url:"product_id"`
+	AllowedUses int    `url:"allowed_uses"`
+	ExpiresAt   string `url:"expires_at,omitempty"`
+}
+
+// LicenseGenerateOptions specifies the optional parameters to the
+// LicensesService.Generate method.
+type LicenseGenerateOptions struct {
+	// ExpiresAt is the date the license expires on, in the format
+	// YYYY-MM-DD. The license never expires if empty.
+	ExpiresAt string
+}
+
+// LicenseGenerateResponse is the response to LicensesService.Generate.
+type LicenseGenerateResponse struct {
+	Success  bool     `json:"success"`
+	Response *License `json:"response"`
+}
+
+// Generate a license code for the product productID, which can be activated
+// allowedUses times. The product must have Paddle licensing enabled.
+//
+// The endpoint takes no name: the license is issued under the name of the
+// product, which is set on the product in the Paddle dashboard.
+//
+// Paddle API docs: https://developer.paddle.com/api-reference/product-api/licenses/createlicense
+func (s *LicensesService) Generate(ctx context.Context, productID, allowedUses int, options *LicenseGenerateOptions) (*License, *http.Response, error) {
+	u := "2.0/product/generate_license"
+
+	generate := &LicenseGenerate{
+		ProductID:   productID,
+		AllowedUses: allowedUses,
+	}
+	if options != nil {
+		generate.ExpiresAt = options.ExpiresAt
+	}
+	req, err := s.client.NewRequest("POST", u, generate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	licenseGenerateResponse := new(LicenseGenerateResponse)
+	response, err := s.client.Do(withOperation(ctx, "Licenses.Generate"), req, licenseGenerateResponse)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return licenseGenerateResponse.Response, response, nil
+}
+
+// OrderLicense is a license code delivered with an order, in one of its
+// lockers.
+type OrderLicense struct {
+	LicenseCode string
+	CheckoutID  string
+	OrderID     int
+	ProductID   int
+	LockerID    int
+}
+
+// Licenses returns the license codes held by the lockers of the order,
+// linked to the order and to the product they were issued for. Lockers
+// without a license code are skipped, and nil order details have none.
+func (d *OrderDetails) Licenses() []*OrderLicense {
+	if d == nil {
+		return nil
+	}
+	var licenses []*OrderLicense
+	for _, locker := range d.Lockers {
+		if locker == nil || locker.LicenseCode == nil || *locker.LicenseCode == "" {
+			continue
+		}
+		license := &OrderLicense{LicenseCode: *locker.LicenseCode}
+		if locker.ProductID != nil {
+			license.ProductID = *locker.ProductID
+		}
+		if locker.LockerID != nil {
+			license.LockerID = *locker.LockerID
+		}
+		if d.Checkout != nil && d.Checkout.CheckoutID != nil {
+			license.CheckoutID = *d.Checkout.CheckoutID
+		}
+		if d.Order != nil && d.Order.OrderID != nil {
+			license.OrderID = *d.Order.OrderID
+		}
+		licenses = append(licenses, license)
+	}
+	return licenses
+}
+
+// ListByCheckout returns the license codes delivered with the order of the
+// checkout checkoutID, from the lockers of its order details. An empty list
+// is returned if Paddle sends no order details.
+func (s *LicensesService) ListByCheckout(ctx context.Context, checkoutID string) ([]*OrderLicense, *http.Response, error) {
+	details, response, err := (*OrderDetailsService)(s).Get(ctx, checkoutID)
+	if err != nil {
+		return nil, response, err
+	}
+	if details == nil {
+		return []*OrderLicense{}, response, nil
+	}
+	return details.Licenses(), response, nil
+}
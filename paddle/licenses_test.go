@@ -0,0 +1,76 @@
+package paddle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestLicensesService_Generate(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/2.0/product/generate_license", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"product_id": "1", "allowed_uses": "3", "expires_at": "2030-01-01"})
+		fmt.Fprint(w, `{"success":true, "response": {"license_code": "A1B2-C3D4", "expires_at": "2030-01-01"}}`)
+	})
+
+	license, _, err := client.Licenses.Generate(context.Background(), 1, 3, &LicenseGenerateOptions{ExpiresAt: "2030-01-01"})
+	if err != nil {
+		t.Errorf("Licenses.Generate returned error: %v", err)
+	}
+
+	want := &License{LicenseCode: String("A1B2-C3D4"), ExpiresAt: String("2030-01-01")}
+	if !reflect.DeepEqual(license, want) {
+		t.Errorf("Licenses.Generate returned %+v, want %+v", license, want)
+	}
+}
+
+func TestLicensesService_ListByCheckout(t *testing.T) {
+	client, mux, _, teardown := checkoutSetup()
+	defer teardown()
+
+	mux.HandleFunc("/1.0/order", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testSoftFormValues(t, r, values{"checkout_id": "1-chk"})
+		fmt.Fprint(w, `{"success":true, "response": {
+			"state": "processed",
+			"checkout": {"checkout_id": "1-chk"},
+			"order": {"order_id": 2},
+			"lockers": [
+				{"locker_id": 3, "product_id": 4, "license_code": "A1B2-C3D4"},
+				{"locker_id": 5, "product_id": 6, "download": "https://example.com/file"}
+			]
+		}}`)
+	})
+
+	licenses, _, err := client.Licenses.ListByCheckout(context.Background(), "1-chk")
+	if err != nil {
+		t.Errorf("Licenses.ListByCheckout returned error: %v", err)
+	}
+
+	want := []*OrderLicense{{LicenseCode: "A1B2-C3D4", CheckoutID: "1-chk", OrderID: 2, ProductID: 4, LockerID: 3}}
+	if !reflect.DeepEqual(licenses, want) {
+		t.Errorf("Licenses.ListByCheckout returned %+v, want %+v", licenses, want)
+	}
+}
+
+func TestLicensesService_ListByCheckout_noDetails(t *testing.T) {
+	client, mux, _, teardown := checkoutSetup()
+	defer teardown()
+
+	mux.HandleFunc("/1.0/order", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true, "response": null}`)
+	})
+
+	licenses, _, err := client.Licenses.ListByCheckout(context.Background(), "1-chk")
+	if err != nil || licenses == nil || len(licenses) != 0 {
+		t.Errorf("Licenses.ListByCheckout returned %+v, %v, want an empty list", licenses, err)
+	}
+	if got := (*OrderDetails)(nil).Licenses(); got != nil {
+		t.Errorf("Licenses of nil order details returned %+v, want nil", got)
+	}
+}
@@ -2,13 +2,11 @@ package paddle
 
 import (
 	"crypto"
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha1"
-	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
-	"encoding/pem"
-	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -19,7 +17,8 @@ import (
 // and returns the (map[string]string) payload.
 // The Content-Type header of the payload needs to be "application/x-www-form-urlencoded".
 // If the Content-Type is different then an error is returned.
-// publicKey is the Paddle public key.
+// publicKey is the Paddle public key. It is parsed on every call, use a
+// WebhookVerifier to parse it once or to accept several keys.
 //
 // Example usage:
 //
@@ -29,74 +28,32 @@ import (
 //      // Process payload...
 //    }
 func ValidatePayload(r *http.Request, publicKey []byte) (map[string]string, error) {
-	payload := map[string]string{}
-
-	ct := r.Header.Get("Content-Type")
-	if ct != "application/x-www-form-urlencoded" {
-		return nil, fmt.Errorf("Webhook request has unsupported Content-Type %q", ct)
-	}
-
-	if err := r.ParseForm(); err != nil {
-		return nil, err
-	}
-
-	// Get the p_signature parameter.
-	p_signature := r.Form.Get("p_signature")
-
-	// Remove the p_signature parameter from Form
-	r.Form.Del("p_signature")
-
-	// Verify signature to make sure the request was sent by Paddle
-	if err := validateSignature(r.Form, p_signature, publicKey); err != nil {
+	v, err := NewWebhookVerifier(map[string][]byte{defaultKeyName: publicKey})
+	if err != nil {
 		return nil, err
 	}
 
-	// Construct payload from fields sent in the request
-	for k := range r.Form {
-		payload[k] = r.Form.Get(k) // r.Form is a map[string][]string
-	}
-
-	return payload, nil
+	payload, _, err := v.VerifyRequest(r)
+	return payload, err
 }
 
-// validateSignature validates the signature for the given payload.
-// The signature is included on each webhook with the attribute p_signature.
-// payload is the Form payload sent by Paddle Webhooks.
-// publicKey is the Paddle public key.
-//
-// Paddle Reference: https://paddle.com/docs/reference-verifying-webhooks/
-func validateSignature(form url.Values, p_signature string, publicKey []byte) error {
-	// Find PEM public key block.
-	der, _ := pem.Decode(publicKey)
-	if der == nil {
-		return errors.New("Could not parse public key pem")
+// SignPayload computes the p_signature of a webhook payload with privateKey,
+// the way Paddle signs its alerts. A p_signature field in values is ignored.
+// It is meant to build signed alerts in tests.
+func SignPayload(values url.Values, privateKey *rsa.PrivateKey) (string, error) {
+	form := url.Values{}
+	for k, v := range values {
+		if k != "p_signature" {
+			form[k] = v
+		}
 	}
 
-	// Parse public key in PKIX, ASN.1 DER form.
-	pub, err := x509.ParsePKIXPublicKey(der.Bytes)
-	if err != nil {
-		return errors.New("Could not parse public key pem der")
-	}
-
-	signingKey, ok := pub.(*rsa.PublicKey)
-	if !ok {
-		return errors.New("Not the correct key format")
-	}
-
-	// base64 decode p_signature
-	sig, err := base64.StdEncoding.DecodeString(p_signature)
-	if err != nil {
-		return err
-	}
-
-	// ksort() and serialize the Form
 	sha1Sum := sha1.Sum(phpserialize(form))
-
-	err = rsa.VerifyPKCS1v15(signingKey, crypto.SHA1, sha1Sum[:], sig)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA1, sha1Sum[:])
 	if err != nil {
-		return err
+		return "", err
 	}
-	return nil
+	return base64.StdEncoding.EncodeToString(sig), nil
 }
 
 // php serialize Form in sorted order
@@ -116,6 +73,30 @@ func phpserialize(form url.Values) []byte {
 	return []byte(serialized)
 }
 
+// alertTypes maps the alert names to the types they are parsed into.
+var alertTypes = map[string]func() interface{}{
+	"subscription_created":           func() interface{} { return &SubscriptionCreatedAlert{} },
+	"subscription_updated":           func() interface{} { return &SubscriptionUpdatedAlert{} },
+	"subscription_cancelled":         func() interface{} { return &SubscriptionCancelledAlert{} },
+	"subscription_payment_succeeded": func() interface{} { return &SubscriptionPaymentSucceededAlert{} },
+	"subscription_payment_failed":    func() interface{} { return &SubscriptionPaymentFailedAlert{} },
+	"subscription_payment_refunded":  func() interface{} { return &SubscriptionPaymentRefundedAlert{} },
+	"payment_succeeded":              func() interface{} { return &PaymentSucceededAlert{} },
+	"payment_refunded":               func() interface{} { return &PaymentRefundedAlert{} },
+	"locker_processed":               func() interface{} { return &LockerProcessedAlert{} },
+	"payment_dispute_created":        func() interface{} { return &PaymentDisputeCreatedAlert{} },
+	"payment_dispute_closed":         func() interface{} { return &PaymentDisputeClosedAlert{} },
+	"high_risk_transaction_created":  func() interface{} { return &HighRiskTransactionCreatedAlert{} },
+	"high_risk_transaction_updated":  func() interface{} { return &HighRiskTransactionUpdatedAlert{} },
+	"transfer_created":               func() interface{} { return &TransferCreatedAlert{} },
+	"transfer_paid":                  func() interface{} { return &TransferPaidAlert{} },
+	"new_audience_member":            func() interface{} { return &NewAudienceMemberAlert{} },
+	"update_audience_member":         func() interface{} { return &UpdateAudienceMemberAlert{} },
+	"invoice_paid":                   func() interface{} { return &InvoicePaidAlert{} },
+	"invoice_sent":                   func() interface{} { return &InvoiceSentAlert{} },
+	"invoice_overdue":                func() interface{} { return &InvoiceOverdueAlert{} },
+}
+
 // ParsePayload parses the alert payload. For recognized alert types, a
 // value of the corresponding struct type will be returned.
 // An error will be returned for unrecognized alert types.
@@ -140,48 +121,12 @@ func ParsePayload(payload map[string]string) (interface{}, error) {
 	var parsedPayload interface{}
 	alert_type := payload["alert_name"]
 
-	switch alert_type {
-	case "subscription_created":
-		parsedPayload = &SubscriptionCreatedAlert{}
-	case "subscription_updated":
-		parsedPayload = &SubscriptionUpdatedAlert{}
-	case "subscription_cancelled":
-		parsedPayload = &SubscriptionCancelledAlert{}
-	case "subscription_payment_succeeded":
-		parsedPayload = &SubscriptionPaymentSucceededAlert{}
-	case "subscription_payment_failed":
-		parsedPayload = &SubscriptionPaymentFailedAlert{}
-	case "subscription_payment_refunded":
-		parsedPayload = &SubscriptionPaymentRefundedAlert{}
-	case "payment_succeeded":
-		parsedPayload = &PaymentSucceededAlert{}
-	case "payment_refunded":
-		parsedPayload = &PaymentRefundedAlert{}
-	case "locker_processed":
-		parsedPayload = &LockerProcessedAlert{}
-	case "payment_dispute_created":
-		parsedPayload = &PaymentDisputeCreatedAlert{}
-	case "payment_dispute_closed":
-		parsedPayload = &PaymentDisputeClosedAlert{}
-	case "high_risk_transaction_created":
-		parsedPayload = &HighRiskTransactionCreatedAlert{}
-	case "high_risk_transaction_updated":
-		parsedPayload = &HighRiskTransactionUpdatedAlert{}
-	case "transfer_created":
-		parsedPayload = &TransferCreatedAlert{}
-	case "transfer_paid":
-		parsedPayload = &TransferPaidAlert{}
-	case "new_audience_member":
-		parsedPayload = &NewAudienceMemberAlert{}
-	case "update_audience_member":
-		parsedPayload = &UpdateAudienceMemberAlert{}
-	case "invoice_paid":
-		parsedPayload = &InvoicePaidAlert{}
-	case "invoice_sent":
-		parsedPayload = &InvoiceSentAlert{}
-	case "invoice_overdue":
-		parsedPayload = &InvoiceOverdueAlert{}
-	default:
+	if newAlert, ok := alertTypes[alert_type]; ok {
+		parsedPayload = newAlert()
+	} else if _, ok := payload["p_order_id"]; ok && alert_type == "" {
+		// Fulfillment webhooks are the only alerts sent without an alert_name.
+		parsedPayload = &FulfillmentWebhook{}
+	} else {
 		return nil, fmt.Errorf("unknown alert_type: %v", alert_type)
 	}
 
@@ -0,0 +1,126 @@
+package paddle
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const redacted = "REDACTED"
+
+// Call describes a call to the Paddle API passed along the middleware chain.
+type Call struct {
+	// Operation is the name of the service method being called, for example "Users.List".
+	// It is empty for requests sent directly with Client.Do.
+	Operation string
+
+	// Request is the HTTP request about to be sent. Middlewares may modify
+	// its headers or replace it.
+	Request *http.Request
+
+	// Form holds the decoded request parameters, with the vendor auth code redacted.
+	Form url.Values
+}
+
+// CallHandler sends a call to the Paddle API and decodes its response.
+// The error returned is an *ErrorResponse for API errors.
+type CallHandler func(ctx context.Context, call *Call) (*http.Response, error)
+
+// Middleware wraps a CallHandler to run code before and after each call,
+// for example to log, trace or add headers to the requests.
+//
+// Example usage:
+//
+//	client.Use(func(next paddle.CallHandler) paddle.CallHandler {
+//		return func(ctx context.Context, call *paddle.Call) (*http.Response, error) {
+//			call.Request.Header.Set("X-Request-ID", requestID(ctx))
+//			return next(ctx, call)
+//		}
+//	})
+type Middleware func(next CallHandler) CallHandler
+
+// CallResult describes a call to the Paddle API once it has completed.
+type CallResult struct {
+	*Call
+
+	Response *http.Response
+	Duration time.Duration
+	Err      error
+}
+
+// Use appends middlewares to the chain wrapping every call of the Client.
+// The first middleware is the outermost one. Use must not be called
+// concurrently with requests.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// Observe returns a Middleware calling fn after each call with its
+// duration and outcome. It is meant for logging and metrics.
+func Observe(fn func(ctx context.Context, result *CallResult)) Middleware {
+	return func(next CallHandler) CallHandler {
+		return func(ctx context.Context, call *Call) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, call)
+			fn(ctx, &CallResult{
+				Call:     call,
+				Response: resp,
+				Duration: time.Since(start),
+				Err:      err,
+			})
+			return resp, err
+		}
+	}
+}
+
+type operationKey struct{}
+
+// withOperation returns a copy of ctx carrying the name of the service
+// method being called.
+func withOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// OperationFromContext returns the name of the service method being called,
+// for example "Users.List", or an empty string.
+func OperationFromContext(ctx context.Context) string {
+	operation, _ := ctx.Value(operationKey{}).(string)
+	return operation
+}
+
+// newCall describes req for the middlewares.
+func newCall(ctx context.Context, req *http.Request) *Call {
+	return &Call{
+		Operation: OperationFromContext(ctx),
+		Request:   req,
+		Form:      requestForm(req),
+	}
+}
+
+// requestForm decodes the parameters of req without consuming its body.
+// The vendor auth code is redacted.
+func requestForm(req *http.Request) url.Values {
+	form := url.Values{}
+	for k, v := range req.URL.Query() {
+		form[k] = v
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := ioutil.ReadAll(body)
+			body.Close()
+			if values, err := url.ParseQuery(string(data)); err == nil {
+				for k, v := range values {
+					form[k] = v
+				}
+			}
+		}
+	}
+
+	if form.Get(vendorAuthCodeAttribute) != "" {
+		form.Set(vendorAuthCodeAttribute, redacted)
+	}
+	return form
+}
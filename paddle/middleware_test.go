@@ -0,0 +1,62 @@
+package paddle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClient_Use(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/2.0/subscription/users_cancel", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("X-Test"), "1"; got != want {
+			t.Errorf("Header X-Test is %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `{"success":false, "error": {"code": 119, "message": "Unable to find requested subscription"}}`)
+	})
+
+	var order []string
+	client.Use(func(next CallHandler) CallHandler {
+		return func(ctx context.Context, call *Call) (*http.Response, error) {
+			order = append(order, "outer")
+			call.Request.Header.Set("X-Test", "1")
+			return next(ctx, call)
+		}
+	})
+
+	var result *CallResult
+	client.Use(Observe(func(ctx context.Context, r *CallResult) {
+		order = append(order, "inner")
+		result = r
+	}))
+
+	_, _, err := client.Users.Cancel(context.Background(), 1)
+	if !IsNotFound(err) {
+		t.Errorf("Users.Cancel returned error %v, want not found", err)
+	}
+
+	if got, want := fmt.Sprint(order), "[outer inner]"; got != want {
+		t.Errorf("Middlewares called in order %v, want %v", got, want)
+	}
+	if result == nil {
+		t.Fatal("Observe callback was not called")
+	}
+	if got, want := result.Operation, "Users.Cancel"; got != want {
+		t.Errorf("CallResult.Operation is %q, want %q", got, want)
+	}
+	if got, want := result.Form.Get("subscription_id"), "1"; got != want {
+		t.Errorf("CallResult.Form subscription_id is %q, want %q", got, want)
+	}
+	if got, want := result.Form.Get(vendorAuthCodeAttribute), redacted; got != want {
+		t.Errorf("CallResult.Form vendor_auth_code is %q, want %q", got, want)
+	}
+	if result.Err != err {
+		t.Errorf("CallResult.Err is %v, want %v", result.Err, err)
+	}
+	if result.Duration <= 0 {
+		t.Errorf("CallResult.Duration is %v, want a positive duration", result.Duration)
+	}
+}
@@ -2,6 +2,7 @@ package paddle
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 )
 
@@ -15,12 +16,22 @@ type ModifiersService service
 type Modifier struct {
 	ModifierID     *int    `json:"modifier_id,omitempty"`
 	SubscriptionID *int    `json:"subscription_id,omitempty"`
-	Amount         *string `json:"amount,omitempty"`
+	Amount         *Money  `json:"amount,omitempty"`
 	Currency       *string `json:"currency,omitempty"`
 	IsRecurring    *bool   `json:"is_recurring,omitempty"`
 	Description    *string `json:"description,omitempty"`
 }
 
+// UnmarshalJSON decodes a modifier and sets the currency of its amount.
+func (m *Modifier) UnmarshalJSON(data []byte) error {
+	type modifier Modifier
+	if err := json.Unmarshal(data, (*modifier)(m)); err != nil {
+		return err
+	}
+	setCurrency(m.Currency, m.Amount)
+	return nil
+}
+
 type ModifiersResponse struct {
 	Success  bool        `json:"success"`
 	Response []*Modifier `json:"response"`
@@ -46,7 +57,7 @@ func (s *ModifiersService) List(ctx context.Context, options *ModifiersOptions)
 	}
 
 	modifiersResponse := new(ModifiersResponse)
-	response, err := s.client.Do(ctx, req, modifiersResponse)
+	response, err := s.client.Do(withOperation(ctx, "Modifiers.List"), req, modifiersResponse)
 	if err != nil {
 		return nil, response, err
 	}
@@ -55,14 +66,18 @@ func (s *ModifiersService) List(ctx context.Context, options *ModifiersOptions)
 }
 
 type ModifierCreate struct {
-	SubscriptionID      int     `url:"subscription_id,omitempty"`
-	ModifierAmount      float64 `url:"modifier_amount,omitempty"`
-	ModifierRecurring   bool    `url:"modifier_recurring,omitempty"`
-	ModifierDescription string  `url:"modifier_description,omitempty"`
+	SubscriptionID      int    `url:"subscription_id,omitempty"`
+	ModifierAmount      Money  `url:"modifier_amount"`
+	ModifierRecurring   *bool  `url:"modifier_recurring,omitempty"`
+	ModifierDescription string `url:"modifier_description,omitempty"`
 }
 
+// ModifierCreateOptions specifies the optional parameters to the
+// ModifiersService.Create method.
 type ModifierCreateOptions struct {
-	ModifierRecurring   bool
+	// ModifierRecurring tells whether the modifier applies to every payment
+	// of the subscription, or only to the next one. Paddle defaults to true.
+	ModifierRecurring   *bool
 	ModifierDescription string
 }
 
@@ -71,10 +86,11 @@ type ModifierCreateResponse struct {
 	Response *Modifier `json:"response"`
 }
 
-// Create a subscription modifier to dynamically change the subscription payment amount
+// Create a subscription modifier to dynamically change the subscription payment amount.
+// modifierAmount is charged in the currency of the subscription.
 //
 // Paddle API docs:  https://developer.paddle.com/api-reference/subscription-api/modifiers/createmodifier
-func (s *ModifiersService) Create(ctx context.Context, subscriptionID int, modifierAmount float64, options *ModifierCreateOptions) (*Modifier, *http.Response, error) {
+func (s *ModifiersService) Create(ctx context.Context, subscriptionID int, modifierAmount Money, options *ModifierCreateOptions) (*Modifier, *http.Response, error) {
 	u := "2.0/subscription/modifiers/create"
 
 	create := &ModifierCreate{
@@ -91,7 +107,7 @@ func (s *ModifiersService) Create(ctx context.Context, subscriptionID int, modif
 	}
 
 	modifierCreateResponse := new(ModifierCreateResponse)
-	response, err := s.client.Do(ctx, req, modifierCreateResponse)
+	response, err := s.client.Do(withOperation(ctx, "Modifiers.Create"), req, modifierCreateResponse)
 	if err != nil {
 		return nil, response, err
 	}
@@ -122,7 +138,7 @@ func (s *ModifiersService) Delete(ctx context.Context, modifierID int) (bool, *h
 	}
 
 	modifierDeleteResponse := new(ModifierDeleteResponse)
-	response, err := s.client.Do(ctx, req, modifierDeleteResponse)
+	response, err := s.client.Do(withOperation(ctx, "Modifiers.Delete"), req, modifierDeleteResponse)
 	if err != nil {
 		return false, response, err
 	}
@@ -36,17 +36,18 @@ func TestModifiersService_Create(t *testing.T) {
 
 	mux.HandleFunc("/2.0/subscription/modifiers/create", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "POST")
-		testFormValues(t, r, values{"subscription_id": "1", "modifier_amount": "1", "modifier_recurring": "true"})
-		fmt.Fprint(w, `{"success":true, "response": {"subscription_id": 1, "modifier_id":1}}`)
+		testFormValues(t, r, values{"subscription_id": "1", "modifier_amount": "1.10", "modifier_recurring": "true"})
+		fmt.Fprint(w, `{"success":true, "response": {"subscription_id": 1, "modifier_id":1, "amount": "1.10", "currency": "EUR"}}`)
 	})
 
-	opt := &ModifierCreateOptions{ModifierRecurring: true}
-	modifier, _, err := client.Modifiers.Create(context.Background(), 1, 1, opt)
+	opt := &ModifierCreateOptions{ModifierRecurring: Bool(true)}
+	modifier, _, err := client.Modifiers.Create(context.Background(), 1, MustParseMoney("EUR", "1.10"), opt)
 	if err != nil {
 		t.Errorf("Modifiers.Create returned error: %v", err)
 	}
 
-	want := &Modifier{SubscriptionID: Int(1), ModifierID: Int(1)}
+	amount := MustParseMoney("EUR", "1.10")
+	want := &Modifier{SubscriptionID: Int(1), ModifierID: Int(1), Amount: &amount, Currency: String("EUR")}
 	if !reflect.DeepEqual(modifier, want) {
 		t.Errorf("Modifiers.Create returned %+v, want %+v", modifier, want)
 	}
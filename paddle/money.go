@@ -0,0 +1,465 @@
+package paddle
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxDecimalDigits is the number of significant digits a Decimal can hold.
+const maxDecimalDigits = 18
+
+// ErrDecimalOverflow is returned when the result of an operation on Decimal
+// values does not fit in the 64 bits of its coefficient.
+var ErrDecimalOverflow = errors.New("paddle: decimal overflow")
+
+// Decimal is an exact decimal number such as "9.99". Its zero value is 0.
+type Decimal struct {
+	coef  int64 // Digits of the number, without the decimal point.
+	scale int   // Number of digits after the decimal point.
+}
+
+// ParseDecimal parses a decimal number such as "9.99", "-0.5" or "100".
+func ParseDecimal(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	neg := false
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		neg = str[0] == '-'
+		str = str[1:]
+	}
+
+	intPart, fracPart := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	for _, r := range intPart + fracPart {
+		if r < '0' || r > '9' {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+	}
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	if len(digits) > maxDecimalDigits {
+		return Decimal{}, fmt.Errorf("decimal %q has too many digits", s)
+	}
+
+	var coef int64
+	if digits != "" {
+		var err error
+		if coef, err = strconv.ParseInt(digits, 10, 64); err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+	}
+	if neg {
+		coef = -coef
+	}
+	return Decimal{coef: coef, scale: len(fracPart)}.normalize(), nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics if s cannot be parsed.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// NewDecimal returns the decimal number coef * 10^-scale. It panics with
+// ErrDecimalOverflow if scale is negative and the number does not fit in a
+// Decimal.
+func NewDecimal(coef int64, scale int) Decimal {
+	if scale < 0 {
+		var err error
+		if coef, err = (Decimal{coef: coef}).rescale(-scale); err != nil {
+			panic(err)
+		}
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: scale}.normalize()
+}
+
+// normalize removes the trailing zeros after the decimal point.
+func (d Decimal) normalize() Decimal {
+	for d.scale > 0 && d.coef%10 == 0 {
+		d.coef /= 10
+		d.scale--
+	}
+	if d.coef == 0 {
+		d.scale = 0
+	}
+	return d
+}
+
+// rescale returns the coefficient of d expressed with the given scale,
+// which must be greater or equal to the scale of d.
+func (d Decimal) rescale(scale int) (int64, error) {
+	coef := d.coef
+	for s := d.scale; s < scale; s++ {
+		if coef > math.MaxInt64/10 || coef < math.MinInt64/10 {
+			return 0, ErrDecimalOverflow
+		}
+		coef *= 10
+	}
+	return coef, nil
+}
+
+// String returns the decimal number in plain notation, for example "9.99".
+func (d Decimal) String() string {
+	return d.StringFixed(d.scale)
+}
+
+// StringFixed returns the decimal number with exactly places digits after the
+// decimal point. Extra digits are rounded half away from zero.
+func (d Decimal) StringFixed(places int) string {
+	if places < 0 {
+		places = 0
+	}
+	d = d.Round(places)
+
+	// The digits are padded rather than the coefficient rescaled, which
+	// could overflow.
+	neg := d.coef < 0
+	digits := strconv.FormatInt(d.coef, 10)
+	if neg {
+		digits = digits[1:]
+	}
+	digits += strings.Repeat("0", places-d.scale)
+	if places > 0 {
+		if len(digits) <= places {
+			digits = strings.Repeat("0", places-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-places] + "." + digits[len(digits)-places:]
+	}
+	if neg {
+		digits = "-" + digits
+	}
+	return digits
+}
+
+// Round rounds d half away from zero to the given number of decimal places.
+func (d Decimal) Round(places int) Decimal {
+	if d.scale <= places {
+		return d
+	}
+	coef := d.coef
+	for s := d.scale; s > places+1; s-- {
+		coef /= 10
+	}
+	last := coef % 10
+	coef /= 10
+	if last >= 5 {
+		coef++
+	} else if last <= -5 {
+		coef--
+	}
+	return Decimal{coef: coef, scale: places}.normalize()
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int { return d.scale }
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool { return d.coef == 0 }
+
+// Sign returns -1, 0 or 1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	switch {
+	case d.coef < 0:
+		return -1
+	case d.coef > 0:
+		return 1
+	}
+	return 0
+}
+
+// Neg returns -d. It panics with ErrDecimalOverflow if d is the smallest
+// Decimal of its scale, whose opposite does not fit in a Decimal.
+func (d Decimal) Neg() Decimal {
+	if d.coef == math.MinInt64 {
+		panic(ErrDecimalOverflow)
+	}
+	return Decimal{coef: -d.coef, scale: d.scale}
+}
+
+// Add returns d + o. It panics with ErrDecimalOverflow if the sum does not
+// fit in a Decimal; use AddChecked for amounts which are not known to be
+// small enough, such as the ones given by users.
+func (d Decimal) Add(o Decimal) Decimal {
+	sum, err := d.AddChecked(o)
+	if err != nil {
+		panic(err)
+	}
+	return sum
+}
+
+// AddChecked returns d + o, or ErrDecimalOverflow if the sum does not fit
+// in a Decimal.
+func (d Decimal) AddChecked(o Decimal) (Decimal, error) {
+	scale := d.scale
+	if o.scale > scale {
+		scale = o.scale
+	}
+	a, err := d.rescale(scale)
+	if err != nil {
+		return Decimal{}, err
+	}
+	b, err := o.rescale(scale)
+	if err != nil {
+		return Decimal{}, err
+	}
+	if b > 0 && a > math.MaxInt64-b || b < 0 && a < math.MinInt64-b {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	return Decimal{coef: a + b, scale: scale}.normalize(), nil
+}
+
+// Sub returns d - o. Like Add, it panics with ErrDecimalOverflow if the
+// difference does not fit in a Decimal.
+func (d Decimal) Sub(o Decimal) Decimal {
+	diff, err := d.SubChecked(o)
+	if err != nil {
+		panic(err)
+	}
+	return diff
+}
+
+// SubChecked returns d - o, or ErrDecimalOverflow if the difference does
+// not fit in a Decimal.
+func (d Decimal) SubChecked(o Decimal) (Decimal, error) {
+	if o.coef == math.MinInt64 {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	return d.AddChecked(Decimal{coef: -o.coef, scale: o.scale})
+}
+
+// Cmp compares d and o and returns -1, 0 or 1. The comparison is exact
+// whatever the values.
+func (d Decimal) Cmp(o Decimal) int {
+	scale := d.scale
+	if o.scale > scale {
+		scale = o.scale
+	}
+	return d.bigCoef(scale).Cmp(o.bigCoef(scale))
+}
+
+// bigCoef returns the coefficient of d expressed with the given scale, which
+// must be greater or equal to the scale of d, without overflowing.
+func (d Decimal) bigCoef(scale int) *big.Int {
+	coef := big.NewInt(d.coef)
+	if scale > d.scale {
+		exp := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale-d.scale)), nil)
+		coef.Mul(coef, exp)
+	}
+	return coef
+}
+
+// Float64 returns the nearest float64 value of d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// MarshalJSON encodes d as a JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON decodes d from a JSON number or string, as Paddle sends
+// amounts either way.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	} else if strings.ContainsAny(s, "eE") {
+		// Exponent notation, such as 1e-2.
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("invalid decimal %s", data)
+		}
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// EncodeValues encodes d into the form values of a request, implementing
+// the query.Encoder interface.
+func (d Decimal) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, d.String())
+	return nil
+}
+
+// zeroDecimalCurrencies lists the currencies without minor units.
+var zeroDecimalCurrencies = map[string]bool{
+	"CLP": true,
+	"ISK": true,
+	"JPY": true,
+	"KRW": true,
+	"VND": true,
+}
+
+// currencyExponent returns the number of decimal places of the minor unit of currency.
+func currencyExponent(currency string) int {
+	if zeroDecimalCurrencies[strings.ToUpper(currency)] {
+		return 0
+	}
+	return 2
+}
+
+// Money is an exact amount of money in a given currency. Only the amount is
+// encoded, to JSON and to the form values of the requests, as Paddle carries
+// the currency in a separate field: keep the currency alongside when storing
+// a marshaled Money.
+type Money struct {
+	// Currency is the ISO 4217 code of the currency, for example "USD".
+	Currency string
+
+	// Amount is expressed in major units, for example 9.99 for $9.99.
+	Amount Decimal
+}
+
+// ParseMoney parses amount, given in major units such as "9.99", into a
+// Money value of the given currency.
+func ParseMoney(currency, amount string) (Money, error) {
+	d, err := ParseDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Currency: strings.ToUpper(currency), Amount: d}, nil
+}
+
+// MustParseMoney is like ParseMoney but panics if amount cannot be parsed.
+func MustParseMoney(currency, amount string) Money {
+	m, err := ParseMoney(currency, amount)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// NewMoney returns the Money value of the given amount of minor units,
+// for example NewMoney("USD", 999) for $9.99.
+func NewMoney(currency string, minorUnits int64) Money {
+	return Money{
+		Currency: strings.ToUpper(currency),
+		Amount:   NewDecimal(minorUnits, currencyExponent(currency)),
+	}
+}
+
+// MinorUnits returns the amount in minor units of the currency, for example
+// 999 for $9.99. An error is returned if the amount is more precise than the
+// minor unit.
+func (m Money) MinorUnits() (int64, error) {
+	exponent := currencyExponent(m.Currency)
+	if m.Amount.scale > exponent {
+		return 0, fmt.Errorf("%v is more precise than the minor unit of %s", m.Amount, m.Currency)
+	}
+	return m.Amount.rescale(exponent)
+}
+
+// IsZero reports whether m is the zero value.
+func (m Money) IsZero() bool {
+	return m.Currency == "" && m.Amount.IsZero()
+}
+
+// MarshalJSON encodes the amount of m as a JSON number, dropping the
+// currency, which Paddle sends in a separate field.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return m.Amount.MarshalJSON()
+}
+
+// UnmarshalJSON decodes the amount of m from a JSON number or string. The
+// currency is filled in by the struct holding m, from its currency field.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	return m.Amount.UnmarshalJSON(data)
+}
+
+// EncodeValues encodes the amount of m into the form values of a request,
+// implementing the query.Encoder interface. Amounts are padded to the
+// minor unit of their currency, such as "10.10". The currency is not
+// encoded, the requests taking one carry it in a separate field.
+func (m Money) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, m.formValue())
+	return nil
+}
+
+// formValue returns the amount of m as sent in requests, padded to the minor
+// unit of its currency.
+func (m Money) formValue() string {
+	if exponent := currencyExponent(m.Currency); m.Currency != "" && m.Amount.scale < exponent {
+		return m.Amount.StringFixed(exponent)
+	}
+	return m.Amount.String()
+}
+
+// setCurrency sets the currency of amounts, which Paddle sends in a separate
+// field of the responses.
+func setCurrency(currency *string, amounts ...*Money) {
+	if currency == nil {
+		return
+	}
+	for _, m := range amounts {
+		if m != nil {
+			m.Currency = strings.ToUpper(*currency)
+		}
+	}
+}
+
+// MoneyByCurrency holds an amount per currency, such as the prices of a plan.
+// It is keyed on the ISO 4217 code of the currency.
+type MoneyByCurrency map[string]Money
+
+// UnmarshalJSON decodes an object of amounts keyed on currency codes. An
+// empty JSON array, which Paddle sends instead of an empty object, is
+// accepted as well.
+func (m *MoneyByCurrency) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("[]")) {
+		*m = MoneyByCurrency{}
+		return nil
+	}
+
+	var amounts map[string]Decimal
+	if err := json.Unmarshal(data, &amounts); err != nil {
+		return err
+	}
+	if amounts == nil {
+		return nil
+	}
+
+	*m = make(MoneyByCurrency, len(amounts))
+	for currency, amount := range amounts {
+		currency = strings.ToUpper(currency)
+		(*m)[currency] = Money{Currency: currency, Amount: amount}
+	}
+	return nil
+}
+
+// String returns the currency code followed by the amount, for example "USD 9.99".
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Amount.String()
+	}
+	return m.Currency + " " + m.Amount.String()
+}
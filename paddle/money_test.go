@@ -0,0 +1,193 @@
+package paddle
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"9.99", "9.99"},
+		{"10", "10"},
+		{"10.50", "10.5"},
+		{"-0.05", "-0.05"},
+		{".5", "0.5"},
+		{"0.00", "0"},
+		{"123456789012.345678", "123456789012.345678"},
+	}
+
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.in)
+		if err != nil {
+			t.Errorf("ParseDecimal(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got := d.String(); got != tt.want {
+			t.Errorf("ParseDecimal(%q) returned %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", ".", "1,5", "abc", "1e3", "1234567890123456789"} {
+		if _, err := ParseDecimal(in); err == nil {
+			t.Errorf("ParseDecimal(%q) returned no error", in)
+		}
+	}
+}
+
+func TestDecimal_arithmetic(t *testing.T) {
+	a, b := MustParseDecimal("0.1"), MustParseDecimal("0.2")
+	if got, want := a.Add(b).String(), "0.3"; got != want {
+		t.Errorf("0.1 + 0.2 returned %v, want %v", got, want)
+	}
+	if got, want := a.Sub(b).String(), "-0.1"; got != want {
+		t.Errorf("0.1 - 0.2 returned %v, want %v", got, want)
+	}
+	if got := a.Cmp(b); got != -1 {
+		t.Errorf("0.1 Cmp 0.2 returned %v, want -1", got)
+	}
+	if got, want := MustParseDecimal("2.345").StringFixed(2), "2.35"; got != want {
+		t.Errorf("StringFixed returned %v, want %v", got, want)
+	}
+	if got, want := MustParseDecimal("-2.345").StringFixed(2), "-2.35"; got != want {
+		t.Errorf("StringFixed returned %v, want %v", got, want)
+	}
+	if got, want := MustParseDecimal("0.5").StringFixed(2), "0.50"; got != want {
+		t.Errorf("StringFixed returned %v, want %v", got, want)
+	}
+}
+
+func TestDecimal_overflow(t *testing.T) {
+	max := MustParseDecimal("999999999999999999")
+	small := MustParseDecimal("0.1")
+
+	if _, err := max.AddChecked(small); !errors.Is(err, ErrDecimalOverflow) {
+		t.Errorf("AddChecked rescaling past the limit returned %v, want %v", err, ErrDecimalOverflow)
+	}
+	one := NewDecimal(1, 0)
+	if _, err := NewDecimal(math.MaxInt64, 0).AddChecked(one); !errors.Is(err, ErrDecimalOverflow) {
+		t.Errorf("AddChecked of large numbers returned %v, want %v", err, ErrDecimalOverflow)
+	}
+	if _, err := NewDecimal(math.MinInt64, 0).SubChecked(one); !errors.Is(err, ErrDecimalOverflow) {
+		t.Errorf("SubChecked of large numbers returned %v, want %v", err, ErrDecimalOverflow)
+	}
+	if got, err := max.SubChecked(max); err != nil || !got.IsZero() {
+		t.Errorf("SubChecked returned %v, %v, want 0", got, err)
+	}
+
+	if got := max.Cmp(small); got != 1 {
+		t.Errorf("%v Cmp %v returned %v, want 1", max, small, got)
+	}
+	if got := small.Cmp(max.Neg()); got != 1 {
+		t.Errorf("%v Cmp %v returned %v, want 1", small, max.Neg(), got)
+	}
+	if got, want := max.StringFixed(4), "999999999999999999.0000"; got != want {
+		t.Errorf("StringFixed returned %v, want %v", got, want)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != ErrDecimalOverflow {
+				t.Errorf("Add panicked with %v, want %v", r, ErrDecimalOverflow)
+			}
+		}()
+		max.Add(small)
+	}()
+}
+
+func TestMoney_MinorUnits(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  int64
+	}{
+		{MustParseMoney("usd", "9.99"), 999},
+		{MustParseMoney("EUR", "10"), 1000},
+		{MustParseMoney("JPY", "1200"), 1200},
+		{NewMoney("GBP", 1050), 1050},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.money.MinorUnits()
+		if err != nil {
+			t.Errorf("%v.MinorUnits returned error: %v", tt.money, err)
+		}
+		if got != tt.want {
+			t.Errorf("%v.MinorUnits returned %v, want %v", tt.money, got, tt.want)
+		}
+	}
+
+	if _, err := MustParseMoney("JPY", "10.5").MinorUnits(); err == nil {
+		t.Errorf("MinorUnits returned no error for fractional yens")
+	}
+	if got, want := NewMoney("GBP", 1050).String(), "GBP 10.5"; got != want {
+		t.Errorf("Money.String returned %v, want %v", got, want)
+	}
+}
+
+func TestDecimal_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`9.99`, "9.99"},
+		{`"9.99"`, "9.99"},
+		{`10`, "10"},
+		{`1e-2`, "0.01"},
+		{`null`, "0"},
+	}
+	for _, tt := range tests {
+		var d Decimal
+		if err := json.Unmarshal([]byte(tt.in), &d); err != nil {
+			t.Errorf("Unmarshal(%s) returned error: %v", tt.in, err)
+			continue
+		}
+		if got := d.String(); got != tt.want {
+			t.Errorf("Unmarshal(%s) returned %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	var d Decimal
+	if err := json.Unmarshal([]byte(`"abc"`), &d); err == nil {
+		t.Errorf("Unmarshal returned no error for an invalid decimal")
+	}
+}
+
+func TestMoneyByCurrency_UnmarshalJSON(t *testing.T) {
+	var prices MoneyByCurrency
+	if err := json.Unmarshal([]byte(`{"usd": "10.00", "EUR": 9}`), &prices); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	want := MoneyByCurrency{"USD": MustParseMoney("USD", "10"), "EUR": MustParseMoney("EUR", "9")}
+	if !reflect.DeepEqual(prices, want) {
+		t.Errorf("Unmarshal returned %+v, want %+v", prices, want)
+	}
+
+	if err := json.Unmarshal([]byte(`[]`), &prices); err != nil || len(prices) != 0 {
+		t.Errorf("Unmarshal([]) returned %+v, %v, want an empty map", prices, err)
+	}
+}
+
+func TestMoney_EncodeValues(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{MustParseMoney("USD", "10.1"), "10.10"},
+		{MustParseMoney("JPY", "1200"), "1200"},
+		{MustParseMoney("USD", "0.125"), "0.125"},
+		{Money{Amount: MustParseDecimal("10.1")}, "10.1"},
+	}
+	for _, tt := range tests {
+		v := url.Values{}
+		tt.money.EncodeValues("amount", &v)
+		if got := v.Get("amount"); got != tt.want {
+			t.Errorf("EncodeValues(%v) set %q, want %q", tt.money, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package paddle
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -14,19 +15,29 @@ type OneOffChargesService service
 
 // OneOffCharge represents a Paddle one-off charge.
 type OneOffCharge struct {
-	InvoiceID      *int     `json:"invoice_id,omitempty"`
-	SubscriptionID *int     `json:"subscription_id,omitempty"`
-	Amount         *float64 `json:"amount,omitempty"`
-	Currency       *string  `json:"currency,omitempty"`
-	PaymentDate    *string  `json:"payment_date,omitempty"`
-	ReceiptUrl     *string  `json:"receipt_url,omitempty"`
-	OrderID        *string  `json:"order_id,omitempty"`
-	Status         *string  `json:"status,omitempty"`
+	InvoiceID      *int    `json:"invoice_id,omitempty"`
+	SubscriptionID *int    `json:"subscription_id,omitempty"`
+	Amount         *Money  `json:"amount,omitempty"`
+	Currency       *string `json:"currency,omitempty"`
+	PaymentDate    *string `json:"payment_date,omitempty"`
+	ReceiptUrl     *string `json:"receipt_url,omitempty"`
+	OrderID        *string `json:"order_id,omitempty"`
+	Status         *string `json:"status,omitempty"`
+}
+
+// UnmarshalJSON decodes a one-off charge and sets the currency of its amount.
+func (c *OneOffCharge) UnmarshalJSON(data []byte) error {
+	type oneOffCharge OneOffCharge
+	if err := json.Unmarshal(data, (*oneOffCharge)(c)); err != nil {
+		return err
+	}
+	setCurrency(c.Currency, c.Amount)
+	return nil
 }
 
 type OneOffChargeCreate struct {
-	Amount     float64 `url:"amount,omitempty"`
-	ChargeName string  `url:"charge_name,omitempty"`
+	Amount     Money  `url:"amount,omitempty"`
+	ChargeName string `url:"charge_name,omitempty"`
 }
 
 type OneOffChargeResponse struct {
@@ -34,10 +45,11 @@ type OneOffChargeResponse struct {
 	Response *OneOffCharge `json:"response"`
 }
 
-// Make an immediate one-off charge on top of an existing user subscription
+// Make an immediate one-off charge on top of an existing user subscription.
+// amount is charged in the currency of the subscription.
 //
 // Paddle API docs: https://developer.paddle.com/api-reference/subscription-api/one-off-charges/createcharge
-func (s *OneOffChargesService) Create(ctx context.Context, subscriptionID int, amount float64, chargeName string) (*OneOffCharge, *http.Response, error) {
+func (s *OneOffChargesService) Create(ctx context.Context, subscriptionID int, amount Money, chargeName string) (*OneOffCharge, *http.Response, error) {
 	u := fmt.Sprintf("2.0/subscription/%d/charge", subscriptionID)
 
 	OneOffChargeCreate := &OneOffChargeCreate{
@@ -51,7 +63,7 @@ func (s *OneOffChargesService) Create(ctx context.Context, subscriptionID int, a
 	}
 
 	oneOffChargeResponse := new(OneOffChargeResponse)
-	response, err := s.client.Do(ctx, req, oneOffChargeResponse)
+	response, err := s.client.Do(withOperation(ctx, "OneOffCharges.Create"), req, oneOffChargeResponse)
 	if err != nil {
 		return nil, response, err
 	}
@@ -14,16 +14,17 @@ func TestOneOffChargesService_Create(t *testing.T) {
 
 	mux.HandleFunc("/2.0/subscription/1/charge", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "POST")
-		testFormValues(t, r, values{"amount": "10", "charge_name": "xyz"})
-		fmt.Fprint(w, `{"success":true, "response": {"invoice_id":1, "subscription_id":1}}`)
+		testFormValues(t, r, values{"amount": "10.99", "charge_name": "xyz"})
+		fmt.Fprint(w, `{"success":true, "response": {"invoice_id":1, "subscription_id":1, "amount": 10.99, "currency": "USD"}}`)
 	})
 
-	oneOffCharge, _, err := client.OneOffCharges.Create(context.Background(), 1, 10, "xyz")
+	oneOffCharge, _, err := client.OneOffCharges.Create(context.Background(), 1, NewMoney("USD", 1099), "xyz")
 	if err != nil {
 		t.Errorf("OneOffCharges.Create returned error: %v", err)
 	}
 
-	want := &OneOffCharge{InvoiceID: Int(1), SubscriptionID: Int(1)}
+	amount := NewMoney("USD", 1099)
+	want := &OneOffCharge{InvoiceID: Int(1), SubscriptionID: Int(1), Amount: &amount, Currency: String("USD")}
 	if !reflect.DeepEqual(oneOffCharge, want) {
 		t.Errorf("OneOffCharges.Create returned %+v, want %+v", oneOffCharge, want)
 	}
@@ -2,8 +2,11 @@ package paddle
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 // OrderDetailsService handles communication with the order_details related
@@ -12,6 +15,20 @@ import (
 // Paddle API docs: https://developer.paddle.com/api-reference/checkout-api/order-details/
 type OrderDetailsService service
 
+// States of an order, as reported by OrderDetails.State.
+const (
+	OrderStateProcessing = "processing"
+	OrderStateProcessed  = "processed"
+	OrderStateIncomplete = "incomplete"
+	OrderStateError      = "error"
+)
+
+const (
+	defaultWaitTimeout   = time.Minute
+	defaultWaitBaseDelay = time.Second
+	defaultWaitMaxDelay  = 10 * time.Second
+)
+
 // OrderDetails represents a Paddle order details.
 type OrderDetails struct {
 	State    *string   `json:"state,omitempty"`
@@ -28,8 +45,8 @@ type Checkout struct {
 
 type Order struct {
 	OrderID                    *int            `json:"order_id,omitempty"`
-	Total                      *string         `json:"total,omitempty"`
-	TotalTax                   *string         `json:"total_tax,omitempty"`
+	Total                      *Money          `json:"total,omitempty"`
+	TotalTax                   *Money          `json:"total_tax,omitempty"`
 	Currency                   *string         `json:"currency,omitempty"`
 	FormattedTotal             *string         `json:"formatted_total,omitempty"`
 	FormattedTax               *string         `json:"formatted_tax,omitempty"`
@@ -46,6 +63,16 @@ type Order struct {
 	Customer                   *Customer       `json:"customer,omitempty"`
 }
 
+// UnmarshalJSON decodes an order and sets the currency of its amounts.
+func (o *Order) UnmarshalJSON(data []byte) error {
+	type order Order
+	if err := json.Unmarshal(data, (*order)(o)); err != nil {
+		return err
+	}
+	setCurrency(o.Currency, o.Total, o.TotalTax)
+	return nil
+}
+
 type OrderCompleted struct {
 	Date         *string `json:"date,omitempty"`
 	TimeZone     *string `json:"time_zone,omitempty"`
@@ -76,16 +103,118 @@ type OrderDetailsResponse struct {
 // Paddle API docs: https://developer.paddle.com/api-reference/checkout-api/order-details/getorder
 func (s *OrderDetailsService) Get(ctx context.Context, checkoutID string) (*OrderDetails, *http.Response, error) {
 	u := fmt.Sprintf("1.0/order?checkout_id=%v", checkoutID)
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.newCheckoutRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	orderResponse := new(OrderDetailsResponse)
-	response, err := s.client.Do(ctx, req, orderResponse)
+	response, err := s.client.Do(withOperation(ctx, "OrderDetails.Get"), req, orderResponse)
 	if err != nil {
 		return nil, response, err
 	}
 
 	return orderResponse.Response, response, nil
 }
+
+// WaitOptions specifies the optional parameters to the
+// OrderDetailsService.WaitForCompletion method.
+type WaitOptions struct {
+	// Timeout bounds the whole wait, one minute if zero. The deadline of
+	// the context applies as well.
+	Timeout time.Duration
+
+	// Delay before the second poll, one second if zero. It doubles after
+	// each poll.
+	BaseDelay time.Duration
+
+	// Upper bound of the delay between two polls, ten seconds if zero.
+	MaxDelay time.Duration
+}
+
+// OrderTimeoutError is returned by OrderDetailsService.WaitForCompletion
+// when the order is still processing once the timeout has elapsed.
+type OrderTimeoutError struct {
+	CheckoutID string
+	Timeout    time.Duration
+	Details    *OrderDetails // Last details received, nil if none.
+}
+
+func (e *OrderTimeoutError) Error() string {
+	return fmt.Sprintf("paddle: order of checkout %s still processing after %v", e.CheckoutID, e.Timeout)
+}
+
+// OrderFailedError is returned by OrderDetailsService.WaitForCompletion
+// when the order ends up in a state other than processed, such as
+// OrderStateError or OrderStateIncomplete.
+type OrderFailedError struct {
+	CheckoutID string
+	State      string
+	Details    *OrderDetails
+}
+
+func (e *OrderFailedError) Error() string {
+	return fmt.Sprintf("paddle: order of checkout %s failed in state %q", e.CheckoutID, e.State)
+}
+
+// WaitForCompletion polls the details of the order of the checkout
+// checkoutID until Paddle has processed it, and returns the final details
+// along with their lockers and license codes. The delay between two polls
+// grows exponentially.
+//
+// An *OrderFailedError is returned if the order ends up in any other state,
+// and an *OrderTimeoutError if it is still processing after the timeout.
+// Errors returned by the API and the cancellation of ctx stop the wait.
+func (s *OrderDetailsService) WaitForCompletion(ctx context.Context, checkoutID string, options *WaitOptions) (*OrderDetails, *http.Response, error) {
+	opts := WaitOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultWaitTimeout
+	}
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = defaultWaitBaseDelay
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = defaultWaitMaxDelay
+	}
+	backoff := &RetryPolicy{BaseDelay: opts.BaseDelay, MaxDelay: opts.MaxDelay}
+
+	waitCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	var details *OrderDetails
+
+	// stopped returns the error ending the wait when err interrupted it.
+	stopped := func(err error) error {
+		if ctx.Err() == nil && waitCtx.Err() != nil && errors.Is(err, context.DeadlineExceeded) {
+			return &OrderTimeoutError{CheckoutID: checkoutID, Timeout: opts.Timeout, Details: details}
+		}
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		current, response, err := s.Get(waitCtx, checkoutID)
+		if err != nil {
+			return details, response, stopped(err)
+		}
+		details = current
+
+		state := ""
+		if details != nil && details.State != nil {
+			state = *details.State
+		}
+		switch state {
+		case OrderStateProcessed:
+			return details, response, nil
+		case OrderStateProcessing:
+		default:
+			return details, response, &OrderFailedError{CheckoutID: checkoutID, State: state, Details: details}
+		}
+
+		if err := sleep(waitCtx, backoff.backoff(attempt, nil)); err != nil {
+			return details, response, stopped(err)
+		}
+	}
+}
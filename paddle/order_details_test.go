@@ -2,10 +2,12 @@ package paddle
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestOrderDetailsService_Get(t *testing.T) {
@@ -18,7 +20,7 @@ func TestOrderDetailsService_Get(t *testing.T) {
 		fmt.Fprint(w, `{"success":true, "response": {
 		"state": "xyz",
 		"checkout":{"checkout_id": "1"},
-		"order": {"order_id": 1, "completed": {"date": "123"}, "customer": {"email": "abc"}},
+		"order": {"order_id": 1, "total": "11.00", "total_tax": "1.83", "currency": "GBP", "completed": {"date": "123"}, "customer": {"email": "abc"}},
 		"Lockers": [{"locker_id": 1}]}}`)
 	})
 
@@ -27,11 +29,15 @@ func TestOrderDetailsService_Get(t *testing.T) {
 		t.Errorf("OrderDetails.Get returned error: %v", err)
 	}
 
+	total, totalTax := MustParseMoney("GBP", "11"), MustParseMoney("GBP", "1.83")
 	want := &OrderDetails{
 		State:    String("xyz"),
 		Checkout: &Checkout{CheckoutID: String("1")},
 		Order: &Order{
 			OrderID:   Int(1),
+			Total:     &total,
+			TotalTax:  &totalTax,
+			Currency:  String("GBP"),
 			Completed: &OrderCompleted{Date: String("123")},
 			Customer:  &Customer{Email: String("abc")},
 		},
@@ -42,3 +48,57 @@ func TestOrderDetailsService_Get(t *testing.T) {
 		t.Errorf("OrderDetails.Get returned %+v, want %+v", order, want)
 	}
 }
+
+func TestOrderDetailsService_WaitForCompletion(t *testing.T) {
+	client, mux, _, teardown := checkoutSetup()
+	defer teardown()
+
+	polls := 0
+	mux.HandleFunc("/1.0/order", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		polls++
+		state := "processing"
+		if polls == 3 {
+			state = "processed"
+		}
+		fmt.Fprintf(w, `{"success":true, "response": {"state": %q, "lockers": [{"locker_id": 1, "license_code": "A1B2"}]}}`, state)
+	})
+
+	details, _, err := client.OrderDetails.WaitForCompletion(context.Background(), "1", &WaitOptions{BaseDelay: time.Millisecond})
+	if err != nil {
+		t.Fatalf("OrderDetails.WaitForCompletion returned error: %v", err)
+	}
+	if polls != 3 || *details.State != OrderStateProcessed || *details.Lockers[0].LicenseCode != "A1B2" {
+		t.Errorf("OrderDetails.WaitForCompletion returned %+v after %d polls", details, polls)
+	}
+}
+
+func TestOrderDetailsService_WaitForCompletion_errors(t *testing.T) {
+	client, mux, _, teardown := checkoutSetup()
+	defer teardown()
+
+	states := map[string]string{"processing": "processing", "failed": "error"}
+	mux.HandleFunc("/1.0/order", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"success":true, "response": {"state": %q}}`, states[r.URL.Query().Get("checkout_id")])
+	})
+
+	opts := &WaitOptions{Timeout: 20 * time.Millisecond, BaseDelay: time.Millisecond}
+	_, _, err := client.OrderDetails.WaitForCompletion(context.Background(), "processing", opts)
+	var timeoutErr *OrderTimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.CheckoutID != "processing" || *timeoutErr.Details.State != OrderStateProcessing {
+		t.Errorf("OrderDetails.WaitForCompletion returned %v, want an *OrderTimeoutError", err)
+	}
+
+	_, _, err = client.OrderDetails.WaitForCompletion(context.Background(), "failed", opts)
+	want := &OrderFailedError{CheckoutID: "failed", State: OrderStateError, Details: &OrderDetails{State: String("error")}}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("OrderDetails.WaitForCompletion returned %v, want %v", err, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	_, _, err = client.OrderDetails.WaitForCompletion(ctx, "processing", &WaitOptions{BaseDelay: time.Millisecond})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("OrderDetails.WaitForCompletion returned %v, want %v", err, context.Canceled)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -35,9 +36,32 @@ type Client struct {
 	// This key should never be used in client side code or shared publicly. This can be found in Developer Tools > Authentication.
 	VendorAuthCode *string
 
+	// Credentials is queried for the vendor credentials on every request. It
+	// takes precedence over VendorID and VendorAuthCode when set.
+	Credentials CredentialsProvider
+
 	// Base URL for API requests. BaseURL should always be specified with a trailing slash.
 	BaseURL *url.URL
 
+	// Base URL for Checkout API requests, which are sent without the vendor
+	// credentials. Checkout API requests are sent to BaseURL if nil.
+	CheckoutBaseURL *url.URL
+
+	// User agent used when communicating with the Paddle API. The default user
+	// agent of the http package is used if empty.
+	UserAgent string
+
+	// RetryPolicy controls how failed requests are retried. Requests are sent only once if nil.
+	RetryPolicy *RetryPolicy
+
+	// RateLimiter is waited on before every request, retries included. Requests are not limited if nil.
+	RateLimiter RateLimiter
+
+	// Cache serves the plans, products and prices from a cache. Every request is sent to Paddle if nil.
+	Cache *Cache
+
+	middlewares []Middleware
+
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
 	// Services used for talking to different parts of the Paddle API.
@@ -54,6 +78,8 @@ type Client struct {
 	Products      *ProductsService
 	RefundPayment *RefundPaymentService
 	PayLink       *PayLinkService
+	Transactions  *TransactionsService
+	Licenses      *LicensesService
 }
 
 type service struct {
@@ -70,12 +96,145 @@ type ListOptions struct {
 	ResultsPerPage int `url:"results_per_page,omitempty"`
 }
 
+// An Option configures a Client created with New.
+type Option func(c *Client) error
+
+// WithSandbox makes the client talk to the sandbox environment.
+func WithSandbox() Option {
+	return func(c *Client) error {
+		c.BaseURL, _ = url.Parse(sandboxBaseURL)
+		c.CheckoutBaseURL, _ = url.Parse(sandboxCheckoutBaseURL)
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("nil http.Client")
+		}
+		c.client = httpClient
+		return nil
+	}
+}
+
+// WithCredentials sets the vendor_id and vendor_auth_code attached to the
+// requests of the Product, Subscription and Alert APIs. Use
+// WithCredentialsProvider for credentials rotated at runtime.
+func WithCredentials(vendorID, vendorAuthCode string) Option {
+	return WithCredentialsProvider(StaticCredentials(Credentials{
+		VendorID:       vendorID,
+		VendorAuthCode: vendorAuthCode,
+	}))
+}
+
+// WithBaseURL sets the base URL of the Product, Subscription and Alert APIs.
+// A trailing slash is added if missing.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) (err error) {
+		c.BaseURL, err = parseBaseURL(baseURL)
+		return err
+	}
+}
+
+// WithCheckoutBaseURL sets the base URL of the Checkout API.
+// A trailing slash is added if missing.
+func WithCheckoutBaseURL(baseURL string) Option {
+	return func(c *Client) (err error) {
+		c.CheckoutBaseURL, err = parseBaseURL(baseURL)
+		return err
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the policy failed requests are retried with.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Client) error {
+		c.RetryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimiter sets the RateLimiter waited on before every request.
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(c *Client) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithCache sets the Cache serving the catalogue endpoints.
+func WithCache(cache *Cache) Option {
+	return func(c *Client) error {
+		c.Cache = cache
+		return nil
+	}
+}
+
+// WithMiddleware registers middlewares, as Client.Use does.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) error {
+		c.Use(middlewares...)
+		return nil
+	}
+}
+
+// parseBaseURL parses a base URL and adds a trailing slash to its path if missing.
+func parseBaseURL(baseURL string) (*url.URL, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u, nil
+}
+
+// New returns a new Paddle API client configured with opts. Every service is
+// available on the returned client: requests to the Checkout API are sent to
+// the CheckoutBaseURL, the other ones to the BaseURL along with the vendor
+// credentials. By default, the client talks to the production environment
+// using http.DefaultClient.
+//
+// Example usage:
+//
+//	client, err := paddle.New(
+//		paddle.WithSandbox(),
+//		paddle.WithCredentials(vendorID, vendorAuthCode),
+//		paddle.WithUserAgent("myapp/1.0"),
+//	)
+func New(opts ...Option) (*Client, error) {
+	baseURL, _ := url.Parse(defaultBaseURL)
+	checkoutURL, _ := url.Parse(checkoutBaseURL)
+	c := newClient(nil, baseURL, checkoutURL)
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
 // NewClient returns a new Paddle API client. It requires a vendor_id
 // and a vendor_auth_code arguments. If a nil httpClient is
 // provided, http.DefaultClient will be used.
 func NewClient(vendorID, vendorAuthCode string, httpClient *http.Client) *Client {
 	baseURL, _ := url.Parse(defaultBaseURL)
-	return getClient(httpClient, baseURL, vendorID, vendorAuthCode)
+	checkoutURL, _ := url.Parse(checkoutBaseURL)
+	c := newClient(httpClient, baseURL, checkoutURL)
+	c.VendorID = String(vendorID)
+	c.VendorAuthCode = String(vendorAuthCode)
+	return c
 }
 
 // NewSandboxClient returns a new Paddle API client for the sandbox environment.
@@ -83,32 +242,10 @@ func NewClient(vendorID, vendorAuthCode string, httpClient *http.Client) *Client
 // provided, http.DefaultClient will be used.
 func NewSandboxClient(vendorID, vendorAuthCode string, httpClient *http.Client) *Client {
 	baseURL, _ := url.Parse(sandboxBaseURL)
-	return getClient(httpClient, baseURL, vendorID, vendorAuthCode)
-}
-
-// getCLient creates and returns a Paddle API client.
-func getClient(httpClient *http.Client, baseURL *url.URL, vendorID, vendorAuthCode string) *Client {
-	if httpClient == nil {
-		httpClient = http.DefaultClient
-	}
-	c := &Client{
-		client:         httpClient,
-		BaseURL:        baseURL,
-		VendorID:       String(vendorID),
-		VendorAuthCode: String(vendorAuthCode),
-	}
-
-	c.common.client = c
-	c.Users = (*UsersService)(&c.common)
-	c.Plans = (*PlansService)(&c.common)
-	c.Modifiers = (*ModifiersService)(&c.common)
-	c.Payments = (*PaymentsService)(&c.common)
-	c.OneOffCharges = (*OneOffChargesService)(&c.common)
-	c.Webhooks = (*WebhooksService)(&c.common)
-	c.Coupons = (*CouponsService)(&c.common)
-	c.Products = (*ProductsService)(&c.common)
-	c.RefundPayment = (*RefundPaymentService)(&c.common)
-	c.PayLink = (*PayLinkService)(&c.common)
+	checkoutURL, _ := url.Parse(sandboxCheckoutBaseURL)
+	c := newClient(httpClient, baseURL, checkoutURL)
+	c.VendorID = String(vendorID)
+	c.VendorAuthCode = String(vendorAuthCode)
 	return c
 }
 
@@ -116,30 +253,43 @@ func getClient(httpClient *http.Client, baseURL *url.URL, vendorID, vendorAuthCo
 // If a nil httpClient is provided, http.DefaultClient will be used.
 func NewCheckoutClient(httpClient *http.Client) *Client {
 	baseURL, _ := url.Parse(checkoutBaseURL)
-	return getCheckoutClient(httpClient, baseURL)
+	return newClient(httpClient, baseURL, nil)
 }
 
 // NewSandboxCheckoutClient returns a new Paddle API client for the sandbox checkout enivronement.
 // If a nil httpClient is provided, http.DefaultClient will be used.
 func NewSandboxCheckoutClient(httpClient *http.Client) *Client {
 	baseURL, _ := url.Parse(sandboxCheckoutBaseURL)
-	return getCheckoutClient(httpClient, baseURL)
+	return newClient(httpClient, baseURL, nil)
 }
 
-// getCheckoutCLient creates and returns a checkout Paddle API client.
-func getCheckoutClient(httpClient *http.Client, baseURL *url.URL) *Client {
+// newClient creates and returns a Paddle API client with every service set.
+func newClient(httpClient *http.Client, baseURL, checkoutURL *url.URL) *Client {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
 	c := &Client{
-		client:  httpClient,
-		BaseURL: baseURL,
+		client:          httpClient,
+		BaseURL:         baseURL,
+		CheckoutBaseURL: checkoutURL,
 	}
 
 	c.common.client = c
+	c.Users = (*UsersService)(&c.common)
+	c.Plans = (*PlansService)(&c.common)
+	c.Modifiers = (*ModifiersService)(&c.common)
+	c.Payments = (*PaymentsService)(&c.common)
+	c.OneOffCharges = (*OneOffChargesService)(&c.common)
+	c.Webhooks = (*WebhooksService)(&c.common)
 	c.OrderDetails = (*OrderDetailsService)(&c.common)
 	c.UserHistory = (*UserHistoryService)(&c.common)
 	c.Prices = (*PricesService)(&c.common)
+	c.Coupons = (*CouponsService)(&c.common)
+	c.Products = (*ProductsService)(&c.common)
+	c.RefundPayment = (*RefundPaymentService)(&c.common)
+	c.PayLink = (*PayLinkService)(&c.common)
+	c.Transactions = (*TransactionsService)(&c.common)
+	c.Licenses = (*LicensesService)(&c.common)
 	return c
 }
 
@@ -149,15 +299,53 @@ func getCheckoutClient(httpClient *http.Client, baseURL *url.URL) *Client {
 // specified, the value pointed to by body is url form encoded and included as the
 // request body.
 func (c *Client) NewRequest(method, urlStr string, options interface{}) (*http.Request, error) {
-	if !strings.HasSuffix(c.BaseURL.Path, "/") {
-		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL)
+	vendorID, vendorAuthCode, err := c.vendorCredentials()
+	if err != nil {
+		return nil, err
+	}
+	return c.newRequest(c.BaseURL, method, urlStr, options, vendorID, vendorAuthCode)
+}
+
+// vendorCredentials returns the vendor credentials supplied by the
+// Credentials provider, or else set in VendorID and VendorAuthCode. A
+// provider supplying an empty vendor ID or auth code is an error, rather
+// than a request sent without credentials.
+func (c *Client) vendorCredentials() (*string, *string, error) {
+	if c.Credentials == nil {
+		return c.VendorID, c.VendorAuthCode, nil
+	}
+
+	creds, err := c.Credentials.Credentials()
+	if err != nil {
+		return nil, nil, err
+	}
+	if creds.VendorID == "" || creds.VendorAuthCode == "" {
+		return nil, nil, errors.New("paddle: credentials provider returned an empty vendor ID or auth code")
+	}
+	return String(creds.VendorID), String(creds.VendorAuthCode), nil
+}
+
+// newCheckoutRequest is like NewRequest but creates a Checkout API request,
+// resolved relative to the CheckoutBaseURL and sent without the vendor
+// credentials.
+func (c *Client) newCheckoutRequest(method, urlStr string, options interface{}) (*http.Request, error) {
+	baseURL := c.CheckoutBaseURL
+	if baseURL == nil {
+		baseURL = c.BaseURL
 	}
-	u, err := c.BaseURL.Parse(urlStr)
+	return c.newRequest(baseURL, method, urlStr, options, nil, nil)
+}
+
+func (c *Client) newRequest(baseURL *url.URL, method, urlStr string, options interface{}, vendorID, vendorAuthCode *string) (*http.Request, error) {
+	if !strings.HasSuffix(baseURL.Path, "/") {
+		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", baseURL)
+	}
+	u, err := baseURL.Parse(urlStr)
 	if err != nil {
 		return nil, err
 	}
 
-	payload, err := newPayload(c.VendorID, c.VendorAuthCode, options)
+	payload, err := newPayload(vendorID, vendorAuthCode, options)
 	if err != nil {
 		return nil, err
 	}
@@ -170,6 +358,9 @@ func (c *Client) NewRequest(method, urlStr string, options interface{}) (*http.R
 	if payload.Size() > 0 {
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
 
 	return req, nil
 }
@@ -177,6 +368,11 @@ func (c *Client) NewRequest(method, urlStr string, options interface{}) (*http.R
 // newPayload encodes opt into ``URL encoded'' form and return a *strings.Reader. opt
 // must be a struct whose fields may contain "url" tags.
 // Client's VendorID and VendorAuthCode will be attached to the payload.
+//
+// Optional fields that may be sent as a zero value are pointers tagged
+// omitempty, so that only nil ones are left out. Booleans are encoded as
+// "true" or "false", or as 1 or 0 when tagged with the int option, as each
+// endpoint expects.
 func newPayload(vendorID, vendorAuthCode *string, opt interface{}) (*strings.Reader, error) {
 	data, err := query.Values(opt)
 	if err != nil {
@@ -200,10 +396,71 @@ func newPayload(vendorID, vendorAuthCode *string, opt interface{}) (*strings.Rea
 //
 // The provided ctx must be non-nil. If it is canceled or times out,
 // ctx.Err() will be returned.
+//
+// The call goes through the middlewares registered with Use. If the Client
+// has a RateLimiter, Do waits on it before sending the request.
+// If the Client has a RetryPolicy, failed attempts are retried according to it.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
 	if ctx == nil {
 		return nil, errors.New("context must be non-nil")
 	}
+
+	handler := func(ctx context.Context, call *Call) (*http.Response, error) {
+		return c.send(ctx, call.Request, v)
+	}
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		handler = c.middlewares[i](handler)
+	}
+
+	return handler(ctx, newCall(ctx, req))
+}
+
+// endpoint returns the path of req relative to the base URL it was resolved
+// against, such as "2.0/subscription/users".
+func (c *Client) endpoint(req *http.Request) string {
+	basePath := ""
+	for _, base := range []*url.URL{c.BaseURL, c.CheckoutBaseURL} {
+		if base != nil && req.URL.Host == base.Host && strings.HasPrefix(req.URL.Path, base.Path) && len(base.Path) > len(basePath) {
+			basePath = base.Path
+		}
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, basePath), "/")
+}
+
+// send sends an API request, waiting on the RateLimiter and retrying
+// according to the RetryPolicy of the Client.
+func (c *Client) send(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
+	endpoint := c.endpoint(req)
+
+	for attempt := 1; ; attempt++ {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx, endpoint); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := c.bareDo(ctx, req, v)
+		if err == nil || !c.RetryPolicy.shouldRetry(ctx, attempt, err) {
+			return resp, err
+		}
+
+		delay := c.RetryPolicy.backoff(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			// Waiting would outlive the caller's deadline, give up now.
+			return resp, err
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return resp, err
+		}
+
+		if req, err = rewindRequest(req); err != nil {
+			return resp, err
+		}
+	}
+}
+
+// bareDo sends a single API request and decodes its response into v.
+func (c *Client) bareDo(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
 	req = req.WithContext(ctx)
 
 	resp, err := c.client.Do(req)
@@ -245,6 +502,7 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*htt
 	return resp, nil
 }
 
+// Error holds the code and the message of a failed API call.
 type Error struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
@@ -254,24 +512,31 @@ type Error struct {
 // a field success set to false. Additionally an error object will be returned,
 // containing a code referencing the error, and a message in a human-readable format.
 type ErrorResponse struct {
-	response *http.Response // HTTP response that caused this error
+	Response *http.Response `json:"-"` // HTTP response that caused this error
 
 	Success    bool  `json:"success"`
 	ErrorField Error `json:"error"`
 }
 
-// Check wether or not the API response contains an error
+// Check wether or not the API response contains an error. API errors are
+// returned as an *ErrorResponse.
 func checkResponse(r *http.Response, data []byte) error {
-	errorResponse := &ErrorResponse{response: r}
-	if data != nil {
+	errorResponse := &ErrorResponse{Response: r}
+	if len(data) > 0 {
 		if err := json.Unmarshal(data, errorResponse); err != nil {
-			return err
+			if r.StatusCode >= 200 && r.StatusCode <= 299 {
+				return err
+			}
+			// Non JSON error pages, usually sent by a proxy in front of the API.
+			errorResponse.ErrorField.Message = http.StatusText(r.StatusCode)
+			return errorResponse
 		}
 	}
 	if !errorResponse.Success {
-		return fmt.Errorf("Error: %v, %s",
-			errorResponse.ErrorField.Code,
-			errorResponse.ErrorField.Message)
+		if errorResponse.ErrorField.Message == "" {
+			errorResponse.ErrorField.Message = http.StatusText(r.StatusCode)
+		}
+		return errorResponse
 	}
 	return nil
 }
@@ -1,6 +1,8 @@
 package paddle
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -46,10 +48,8 @@ func checkoutSetup() (client *Client, mux *http.ServeMux, serverURL string, tear
 	server := httptest.NewServer(mux)
 
 	// client is the Paddle client being tested and is
-	// configured to use test server.
-	client = NewCheckoutClient(nil)
-	url, _ := url.Parse(server.URL + "/")
-	client.BaseURL = url
+	// configured to use test server for both APIs.
+	client, _ = New(WithBaseURL(server.URL), WithCheckoutBaseURL(server.URL))
 
 	return client, mux, server.URL, server.Close
 }
@@ -89,3 +89,109 @@ func testMethod(t *testing.T, r *http.Request, want string) {
 		t.Errorf("Request method: %v, want %v", got, want)
 	}
 }
+
+func TestNew(t *testing.T) {
+	c, err := New()
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if got, want := c.BaseURL.String(), defaultBaseURL; got != want {
+		t.Errorf("New BaseURL is %v, want %v", got, want)
+	}
+	if got, want := c.CheckoutBaseURL.String(), checkoutBaseURL; got != want {
+		t.Errorf("New CheckoutBaseURL is %v, want %v", got, want)
+	}
+	if c.Users == nil || c.Prices == nil || c.OrderDetails == nil || c.PayLink == nil {
+		t.Errorf("New did not set every service")
+	}
+
+	c, _ = New(WithSandbox(), WithCredentials("1", "2"), WithUserAgent("test"))
+	if got, want := c.BaseURL.String(), sandboxBaseURL; got != want {
+		t.Errorf("New BaseURL is %v, want %v", got, want)
+	}
+	if got, want := c.CheckoutBaseURL.String(), sandboxCheckoutBaseURL; got != want {
+		t.Errorf("New CheckoutBaseURL is %v, want %v", got, want)
+	}
+	if id, code, _ := c.vendorCredentials(); *id != "1" || *code != "2" || c.UserAgent != "test" {
+		t.Errorf("New did not apply the options: %+v", c)
+	}
+
+	if _, err := New(WithBaseURL(":")); err == nil {
+		t.Errorf("New returned no error for an invalid base URL")
+	}
+}
+
+func TestNew_routing(t *testing.T) {
+	vendorMux, checkoutMux := http.NewServeMux(), http.NewServeMux()
+	vendorServer, checkoutServer := httptest.NewServer(vendorMux), httptest.NewServer(checkoutMux)
+	defer vendorServer.Close()
+	defer checkoutServer.Close()
+
+	vendorMux.HandleFunc("/api/2.0/subscription/plans", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{})
+		if got := r.Header.Get("User-Agent"); got != "test" {
+			t.Errorf("User-Agent is %q, want %q", got, "test")
+		}
+		fmt.Fprint(w, `{"success":true, "response": []}`)
+	})
+	checkoutMux.HandleFunc("/api/1.0/order", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get(vendorIdAttribute) != "" || r.Form.Get(vendorAuthCodeAttribute) != "" {
+			t.Errorf("Checkout request sent the vendor credentials")
+		}
+		fmt.Fprint(w, `{"success":true, "response": {"state": "processed"}}`)
+	})
+
+	client, err := New(
+		WithCredentials(vendorId, vendorAuthCode),
+		WithBaseURL(vendorServer.URL+"/api"),
+		WithCheckoutBaseURL(checkoutServer.URL+"/api/"),
+		WithUserAgent("test"),
+	)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	if _, _, err := client.Plans.List(context.Background(), nil); err != nil {
+		t.Errorf("Plans.List returned error: %v", err)
+	}
+	if _, _, err := client.OrderDetails.Get(context.Background(), "1"); err != nil {
+		t.Errorf("OrderDetails.Get returned error: %v", err)
+	}
+}
+
+func TestNewPayload_optionalFields(t *testing.T) {
+	zero := MustParseDecimal("0")
+	price := MustParseMoney("USD", "0")
+	tests := []struct {
+		opt  interface{}
+		want string
+	}{
+		{&UserUpdate{SubscriptionID: 1}, "subscription_id=1"},
+		{
+			&UserUpdate{SubscriptionID: 1, Prorate: Bool(false), KeepModifiers: Bool(false), Pause: Bool(false), RecurringPrice: &price},
+			"keep_modifiers=false&pause=false&prorate=false&recurring_price=0.00&subscription_id=1",
+		},
+		{&PaymentsOptions{}, ""},
+		{&PaymentsOptions{IsPaid: Bool(false), IsOneOffCharge: Bool(false)}, "is_one_off_charge=false&is_paid=0"},
+		{&PaymentsOptions{IsPaid: Bool(true)}, "is_paid=1"},
+		{&CouponUpdateOptions{CouponCode: "a"}, "coupon_code=a"},
+		{
+			&CouponUpdateOptions{CouponCode: "a", AllowedUses: Int(0), DiscountAmount: &zero, Recurring: Bool(false)},
+			"allowed_uses=0&coupon_code=a&discount_amount=0&recurring=0",
+		},
+		{&ModifierCreate{SubscriptionID: 1, ModifierAmount: price, ModifierRecurring: Bool(false)}, "modifier_amount=0.00&modifier_recurring=false&subscription_id=1"},
+		{&PayLinkCreate{ProductID: 1, Discountable: Bool(false), MarketingConsent: Bool(true)}, "discountable=0&marketing_consent=1&product_id=1"},
+	}
+	for _, tt := range tests {
+		payload, err := newPayload(nil, nil, tt.opt)
+		if err != nil {
+			t.Fatalf("newPayload(%+v) returned error: %v", tt.opt, err)
+		}
+		got := make([]byte, payload.Len())
+		payload.Read(got)
+		if string(got) != tt.want {
+			t.Errorf("newPayload(%+v) = %q, want %q", tt.opt, got, tt.want)
+		}
+	}
+}
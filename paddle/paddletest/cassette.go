@@ -0,0 +1,287 @@
+package paddletest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrNoInteraction is returned by a replaying Recorder when no recorded
+// interaction matches a request.
+var ErrNoInteraction = errors.New("paddletest: no recorded interaction matches the request")
+
+// Placeholders of the redacted values.
+const (
+	redactedValue    = "REDACTED"
+	redactedEmail    = "redacted@example.com"
+	redactedLastFour = "0000"
+)
+
+// redactedFields are the form fields whose values are redacted.
+var redactedFields = []string{"vendor_id", "vendor_auth_code"}
+
+var (
+	emailPattern    = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	lastFourPattern = regexp.MustCompile(`("(?:last_four_digits|card_last_four)"\s*:\s*)"\d{4}"`)
+)
+
+// Mode tells whether a Recorder records or replays.
+type Mode int
+
+const (
+	// ModeReplay answers the requests with the interactions of the
+	// cassette, without sending them.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends the requests and records the interactions, which
+	// replace the cassette on Stop.
+	ModeRecord
+)
+
+// Cassette holds the recorded interactions, in the order they happened.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a request along with the response it got.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded request. Form holds the decoded form body
+// and query parameters.
+type RecordedRequest struct {
+	Method string     `json:"method"`
+	Path   string     `json:"path"`
+	Form   url.Values `json:"form,omitempty"`
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Recorder is an http.RoundTripper recording the interactions with Paddle to
+// a cassette file, or replaying them from it, so that integration tests run
+// deterministically and offline.
+//
+// Requests are matched on their method, path and decoded form values, in
+// any order, so that the host they are sent to does not matter. Identical
+// requests are answered with their recorded responses in turn.
+//
+// The vendor credentials, emails and card last four digits are redacted
+// before the cassette is saved, and from the requests being replayed.
+//
+// Example usage:
+//
+//	rec, err := paddletest.NewRecorder("testdata/users.json", paddletest.ModeReplay, nil)
+//	defer rec.Stop()
+//	client := paddle.NewSandboxClient(vendorID, vendorAuthCode, &http.Client{Transport: rec})
+type Recorder struct {
+	// Redact, if set, is called on every interaction after the default
+	// redactions, to redact other values.
+	Redact func(*Interaction)
+
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool // Interactions already replayed.
+}
+
+// NewRecorder returns a Recorder for the cassette at path. In ModeReplay,
+// the cassette is loaded from path. In ModeRecord, requests are sent with
+// transport, or http.DefaultTransport if nil.
+func NewRecorder(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{path: path, mode: mode, transport: transport, cassette: &Cassette{}}
+
+	if mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("paddletest: parsing cassette %s: %v", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Cassette returns the cassette being recorded or replayed.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette
+}
+
+// Stop saves the cassette to its path when recording. It does nothing when
+// replaying.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+// RoundTrip records or replays req.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, body, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+	resp, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &Interaction{
+		Request: *recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       string(respBody),
+		},
+	}
+	r.redact(interaction)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay answers req with the first unused interaction matching recorded.
+func (r *Recorder) replay(req *http.Request, recorded *RecordedRequest) (*http.Response, error) {
+	r.redact(&Interaction{Request: *recorded})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !interaction.Request.matches(recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		recordedResp := interaction.Response
+		header := recordedResp.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        strconv.Itoa(recordedResp.StatusCode) + " " + http.StatusText(recordedResp.StatusCode),
+			StatusCode:    recordedResp.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(recordedResp.Body)),
+			ContentLength: int64(len(recordedResp.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s %v", ErrNoInteraction, recorded.Method, recorded.Path, recorded.Form)
+}
+
+// matches reports whether r and other have the same method, path and form
+// values, regardless of the order of the fields.
+func (r *RecordedRequest) matches(other *RecordedRequest) bool {
+	if r.Method != other.Method || r.Path != other.Path || len(r.Form) != len(other.Form) {
+		return false
+	}
+	for k, v := range r.Form {
+		if !reflect.DeepEqual(v, other.Form[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordRequest returns the RecordedRequest of req, along with its body,
+// which has been consumed.
+func recordRequest(req *http.Request) (*RecordedRequest, []byte, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, nil, err
+		}
+		req.Body.Close()
+	}
+
+	form := req.URL.Query()
+	if len(body) > 0 {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, nil, fmt.Errorf("paddletest: decoding the form of %s %s: %v", req.Method, req.URL.Path, err)
+		}
+		for k, v := range values {
+			form[k] = append(form[k], v...)
+		}
+	}
+	if len(form) == 0 {
+		form = nil
+	}
+	return &RecordedRequest{Method: req.Method, Path: req.URL.Path, Form: form}, body, nil
+}
+
+// redact redacts the vendor credentials, emails and card last four digits
+// of interaction, then calls the Redact hook.
+func (r *Recorder) redact(interaction *Interaction) {
+	for _, name := range redactedFields {
+		if _, ok := interaction.Request.Form[name]; ok {
+			interaction.Request.Form.Set(name, redactedValue)
+		}
+	}
+	for _, values := range interaction.Request.Form {
+		for i, v := range values {
+			values[i] = emailPattern.ReplaceAllString(v, redactedEmail)
+		}
+	}
+
+	body := emailPattern.ReplaceAllString(interaction.Response.Body, redactedEmail)
+	interaction.Response.Body = lastFourPattern.ReplaceAllString(body, `${1}"`+redactedLastFour+`"`)
+
+	if r.Redact != nil {
+		r.Redact(interaction)
+	}
+}
@@ -0,0 +1,113 @@
+package paddletest
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Fakerr/go-paddle/paddle"
+)
+
+func TestRecorder_recordAndReplay(t *testing.T) {
+	srv := NewServer()
+	planID := srv.AddPlan("Pro", "month", 1, paddle.MustParseMoney("USD", "9.99"))
+	if _, err := srv.Subscribe(planID, "jane@example.com", 1); err != nil {
+		t.Fatalf("Subscribe returned error: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+
+	rec, err := NewRecorder(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	client, err := srv.Client(paddle.WithHTTPClient(&http.Client{Transport: rec}))
+	if err != nil {
+		t.Fatalf("Client returned error: %v", err)
+	}
+	recorded, _, err := client.Users.List(ctx, &paddle.UsersOptions{State: "active"})
+	if err != nil {
+		t.Fatalf("Users.List returned error: %v", err)
+	}
+	if _, _, err := client.UserHistory.Get(ctx, "jane@example.com", nil); err != nil {
+		t.Fatalf("UserHistory.Get returned error: %v", err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	srv.Close()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	for _, secret := range []string{DefaultVendorAuthCode, `"` + DefaultVendorID + `"`, "jane@example.com", "4242"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	// Replay with other credentials, against a host that does not answer.
+	rec, err = NewRecorder(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	client, err = paddle.New(
+		paddle.WithBaseURL("http://paddle.invalid/"),
+		paddle.WithCheckoutBaseURL("http://paddle.invalid/"),
+		paddle.WithCredentials("1", "other"),
+		paddle.WithHTTPClient(&http.Client{Transport: rec}),
+	)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	replayed, _, err := client.Users.List(ctx, &paddle.UsersOptions{State: "active"})
+	if err != nil {
+		t.Fatalf("Users.List returned error: %v", err)
+	}
+	if len(replayed) != 1 || *replayed[0].SubscriptionID != *recorded[0].SubscriptionID || *replayed[0].UserEmail != redactedEmail {
+		t.Errorf("replayed Users.List returned %+v", replayed)
+	}
+	if _, _, err := client.UserHistory.Get(ctx, "john@example.com", nil); err != nil {
+		t.Errorf("UserHistory.Get of another email returned error: %v", err)
+	}
+
+	if _, _, err := client.Users.List(ctx, &paddle.UsersOptions{State: "active"}); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("Users.List replayed twice returned %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestRecordedRequest_matches(t *testing.T) {
+	a, _, err := recordRequest(newFormRequest(t, "subscription_id=1&vendor_id=2&quantity=3"))
+	if err != nil {
+		t.Fatalf("recordRequest returned error: %v", err)
+	}
+	b, _, err := recordRequest(newFormRequest(t, "quantity=3&subscription_id=1&vendor_id=2"))
+	if err != nil {
+		t.Fatalf("recordRequest returned error: %v", err)
+	}
+	if !a.matches(b) {
+		t.Errorf("requests with reordered fields do not match")
+	}
+
+	c, _, err := recordRequest(newFormRequest(t, "quantity=4&subscription_id=1&vendor_id=2"))
+	if err != nil {
+		t.Fatalf("recordRequest returned error: %v", err)
+	}
+	if a.matches(c) {
+		t.Errorf("requests with different values match")
+	}
+}
+
+func newFormRequest(t *testing.T, body string) *http.Request {
+	t.Helper()
+	r, err := http.NewRequest("POST", "https://vendors.paddle.com/api/2.0/subscription/users/update", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	return r
+}
@@ -0,0 +1,192 @@
+package paddletest
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+
+	"github.com/Fakerr/go-paddle/paddle"
+)
+
+// getPrices serves Prices.Get. Prices are quoted without tax, in the main
+// currency of the product or plan. The first coupon redeemable on each
+// product is applied.
+func (s *Server) getPrices(form url.Values) (interface{}, *apiError) {
+	if form.Get("product_ids") == "" {
+		return nil, errMissingArgument("product_ids")
+	}
+	ids, apiErr := s.parseProductIDs(form, "product_ids")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	country := strings.ToUpper(form.Get("customer_country"))
+	if country == "" {
+		country = "US"
+	}
+	var codes []string
+	if v := form.Get("coupons"); v != "" {
+		codes = strings.Split(v, ",")
+	}
+
+	prices := &paddle.Prices{CustomerCountry: paddle.String(country)}
+	for _, id := range ids {
+		var (
+			title string
+			list  paddle.Money
+		)
+		if p, ok := s.products[id]; ok {
+			title, list = p.name, p.price
+		} else {
+			p := s.plans[id]
+			title, list = p.name, p.initialPrice[p.mainCurrency]
+		}
+
+		product := &paddle.Product{
+			ProductID:                  paddle.Int(id),
+			ProductTitle:               paddle.String(title),
+			Currency:                   paddle.String(list.Currency),
+			VendorSetPricesIncludedTax: paddle.Bool(false),
+			ListPrice:                  netPrice(list),
+			Price:                      netPrice(list),
+		}
+		for _, code := range codes {
+			c, ok := s.coupons[strings.TrimSpace(code)]
+			if !ok || !c.redeemable(id, s.now()) || c.discountType == "flat" && c.currency != list.Currency {
+				continue
+			}
+			discount := c.discount(list)
+			price := paddle.Money{Currency: list.Currency, Amount: list.Amount.Sub(discount.Amount)}
+			if price.Amount.Sign() < 0 {
+				price.Amount, discount = paddle.Decimal{}, list
+			}
+			product.Price = netPrice(price)
+			product.AppliedCoupon = &paddle.AppliedCoupon{Code: paddle.String(c.code), Discount: &discount}
+			break
+		}
+		prices.Products = append(prices.Products, product)
+	}
+	return prices, nil
+}
+
+// netPrice returns the Price of an untaxed amount.
+func netPrice(amount paddle.Money) *paddle.Price {
+	gross, net, tax := amount, amount, paddle.Money{Currency: amount.Currency}
+	return &paddle.Price{Gross: &gross, Net: &net, Tax: &tax}
+}
+
+// discount returns the discount c grants on price.
+func (c *coupon) discount(price paddle.Money) paddle.Money {
+	if c.discountType == "flat" {
+		return paddle.Money{Currency: price.Currency, Amount: c.amount}
+	}
+	units, err := price.MinorUnits()
+	if err != nil {
+		return paddle.Money{Currency: price.Currency}
+	}
+	return paddle.NewMoney(price.Currency, int64(math.Round(float64(units)*c.amount.Float64()/100)))
+}
+
+// SetOrderState sets the state OrderDetails.Get reports for the order of
+// the checkout checkoutID, such as paddle.OrderStateProcessing to test code
+// waiting for the order to complete.
+func (s *Server) SetOrderState(checkoutID, state string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, o := range s.orders {
+		if o.checkoutID == checkoutID {
+			o.state = state
+			return nil
+		}
+	}
+	return fmt.Errorf("paddletest: no order for checkout %s", checkoutID)
+}
+
+// getOrder serves OrderDetails.Get.
+func (s *Server) getOrder(form url.Values) (interface{}, *apiError) {
+	checkoutID := form.Get("checkout_id")
+	if checkoutID == "" {
+		return nil, errMissingArgument("checkout_id")
+	}
+	var o *order
+	for _, candidate := range s.orders {
+		if candidate.checkoutID == checkoutID {
+			o = candidate
+		}
+	}
+	if o == nil {
+		return nil, errorf(paddle.ErrCodePurchaseNotFound, "Unable to find requested purchase")
+	}
+
+	total, tax := o.total, paddle.Money{Currency: o.total.Currency}
+	details := &paddle.OrderDetails{
+		State: paddle.String(paddle.OrderStateProcessed),
+		Checkout: &paddle.Checkout{
+			CheckoutID: paddle.String(o.checkoutID),
+			Title:      paddle.String(o.title),
+		},
+		Order: &paddle.Order{
+			OrderID:        paddle.Int(o.id),
+			Total:          &total,
+			TotalTax:       &tax,
+			Currency:       paddle.String(total.Currency),
+			FormattedTotal: paddle.String(total.Currency + " " + formatMoney(total)),
+			FormattedTax:   paddle.String(tax.Currency + " " + formatMoney(tax)),
+			ReceiptUrl:     paddle.String(s.receiptURL(o)),
+			HasLocker:      paddle.Bool(len(o.lockers) > 0),
+			IsSubscription: paddle.Bool(o.subscriptionID != 0),
+			ProductID:      paddle.Int(o.productID),
+			Quantity:       paddle.Int(o.quantity),
+			Completed: &paddle.OrderCompleted{
+				Date:         paddle.String(o.completedAt.Format("2006-01-02 15:04:05.000000")),
+				TimeZone:     paddle.String("UTC"),
+				TimeZoneType: paddle.Int(3),
+			},
+			Customer: &paddle.Customer{
+				Email:            paddle.String(o.email),
+				MarketingConsent: paddle.Bool(false),
+			},
+		},
+		Lockers: append([]*paddle.Locker{}, o.lockers...),
+	}
+	if o.state != "" {
+		details.State = paddle.String(o.state)
+	}
+	if o.subscriptionID != 0 {
+		details.Order.SubscriptionID = paddle.Int(o.subscriptionID)
+		details.Order.SubscriptionOrderID = paddle.String(orderID(o))
+	}
+	return details, nil
+}
+
+// getUserHistory serves UserHistory.Get. The email must have placed an
+// order, for the given product if any.
+func (s *Server) getUserHistory(form url.Values) (interface{}, *apiError) {
+	email := form.Get("email")
+	if email == "" {
+		return nil, errMissingArgument("email")
+	}
+	productID, byProduct, apiErr := formInt(form, "product_id")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if vendorID := form.Get("vendor_id"); vendorID != "" && vendorID != s.VendorID {
+		return nil, errorf(paddle.ErrCodePermissionDenied, "You don't have permission to access this resource")
+	}
+
+	found := false
+	for _, o := range s.orders {
+		if strings.EqualFold(o.email, email) && (!byProduct || o.productID == productID) {
+			found = true
+		}
+	}
+	if !found {
+		return nil, errorf(paddle.ErrCodePurchaseNotFound, "Unable to find requested purchase")
+	}
+
+	return &paddle.UserHistory{
+		Message:  paddle.String("We've sent details of your past transactions, licenses and downloads to you via email."),
+		Callback: paddle.String(""),
+	}, nil
+}
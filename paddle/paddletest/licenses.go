@@ -0,0 +1,83 @@
+package paddletest
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Fakerr/go-paddle/paddle"
+)
+
+// License is a license code generated by a Server.
+type License struct {
+	Code        string
+	ProductID   int
+	AllowedUses int
+	ExpiresAt   time.Time // Zero if the license never expires.
+}
+
+// Licenses returns the license codes generated so far, oldest first.
+func (s *Server) Licenses() []License {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]License(nil), s.licenses...)
+}
+
+// DeliverLicense puts the license code in a locker of the order of the
+// checkout checkoutID, as Paddle does with the codes returned by a license
+// fulfillment webhook. The locker is returned by OrderDetails.Get.
+func (s *Server) DeliverLicense(checkoutID, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, o := range s.orders {
+		if o.checkoutID == checkoutID {
+			o.lockers = append(o.lockers, &paddle.Locker{
+				LockerID:     paddle.Int(s.nextID()),
+				ProductID:    paddle.Int(o.productID),
+				ProductName:  paddle.String(o.title),
+				LicenseCode:  paddle.String(code),
+				Instructions: paddle.String(""),
+				Download:     paddle.String(""),
+			})
+			return nil
+		}
+	}
+	return fmt.Errorf("paddletest: no order for checkout %s", checkoutID)
+}
+
+// generateLicense serves Licenses.Generate, for one-time products.
+func (s *Server) generateLicense(form url.Values) (interface{}, *apiError) {
+	productID, apiErr := requiredInt(form, "product_id")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if _, ok := s.products[productID]; !ok {
+		return nil, errorf(paddle.ErrCodeProductNotFound, "Unable to find requested product")
+	}
+	allowedUses, apiErr := requiredInt(form, "allowed_uses")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if allowedUses < 1 {
+		return nil, errInvalidArgument("allowed_uses")
+	}
+
+	l := License{
+		Code:        strings.ToUpper(fmt.Sprintf("%s-%s-%s", randomHex(2), randomHex(2), randomHex(2))),
+		ProductID:   productID,
+		AllowedUses: allowedUses,
+	}
+	license := &paddle.License{LicenseCode: paddle.String(l.Code)}
+	if v := form.Get("expires_at"); v != "" {
+		expires, err := time.ParseInLocation(dateLayout, v, time.UTC)
+		if err != nil || expires.Before(s.now().Truncate(24*time.Hour)) {
+			return nil, errorf(paddle.ErrCodeInvalidExpiration, "Provided expiration time is incorrect")
+		}
+		l.ExpiresAt = expires
+		license.ExpiresAt = paddle.String(v)
+	}
+	s.licenses = append(s.licenses, l)
+	return license, nil
+}
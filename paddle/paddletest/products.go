@@ -0,0 +1,680 @@
+package paddletest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Fakerr/go-paddle/paddle"
+)
+
+type product struct {
+	id          int
+	name        string
+	description string
+	price       paddle.Money
+}
+
+type order struct {
+	id             int
+	checkoutID     string
+	productID      int // ID of the product or of the plan.
+	subscriptionID int
+	title          string
+	email          string
+	quantity       int
+	total          paddle.Money
+	refunded       paddle.Decimal
+	completedAt    time.Time
+	lockers        []*paddle.Locker
+	state          string // State reported by OrderDetails.Get, processed if empty.
+}
+
+type coupon struct {
+	code         string
+	description  string
+	couponType   string // product or checkout.
+	discountType string // flat or percentage.
+	amount       paddle.Decimal
+	currency     string
+	productIDs   []int
+	allowedUses  int
+	timesUsed    int
+	recurring    bool
+	expires      time.Time
+	group        string
+}
+
+// Refund is a refund requested from a Server.
+type Refund struct {
+	ID      int
+	OrderID int
+	Amount  paddle.Money
+	Reason  string
+}
+
+// PayLink is a pay link generated by a Server.
+type PayLink struct {
+	URL  string
+	Form url.Values // Arguments the pay link was generated with.
+}
+
+// Refunds returns the refunds requested so far, oldest first.
+func (s *Server) Refunds() []Refund {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Refund(nil), s.refunds...)
+}
+
+// PayLinks returns the pay links generated so far, oldest first.
+func (s *Server) PayLinks() []PayLink {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	links := make([]PayLink, len(s.payLinks))
+	for i, link := range s.payLinks {
+		links[i] = PayLink{URL: link.URL, Form: copyValues(link.Form)}
+	}
+	return links
+}
+
+// AddProduct adds a one-time product sold at price and returns its ID.
+func (s *Server) AddProduct(name string, price paddle.Money) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := &product{id: s.nextID(), name: name, price: price}
+	s.products[p.id] = p
+	return p.id
+}
+
+// Purchase makes email buy quantity units of the one-time product with the
+// given ID, as a checkout would, and returns the checkout ID of the order.
+// The payment_succeeded alert is fired.
+func (s *Server) Purchase(productID int, email string, quantity int) (string, error) {
+	s.mu.Lock()
+	checkoutID, err := s.purchase(productID, email, quantity)
+	outbox := s.takeOutbox()
+	s.mu.Unlock()
+
+	s.deliver(outbox)
+	return checkoutID, err
+}
+
+func (s *Server) purchase(productID int, email string, quantity int) (string, error) {
+	p, ok := s.products[productID]
+	if !ok {
+		return "", fmt.Errorf("paddletest: no product %d", productID)
+	}
+	if quantity < 1 {
+		return "", fmt.Errorf("paddletest: invalid quantity %d", quantity)
+	}
+	total, err := times(p.price, quantity)
+	if err != nil {
+		return "", fmt.Errorf("paddletest: quantity %d: %w", quantity, err)
+	}
+	o := s.newOrder(productID, email, quantity, total)
+	gross := formatMoney(o.total)
+
+	fields := url.Values{}
+	fields.Set("order_id", orderID(o))
+	fields.Set("checkout_id", o.checkoutID)
+	fields.Set("product_id", strconv.Itoa(p.id))
+	fields.Set("product_name", p.name)
+	fields.Set("quantity", strconv.Itoa(quantity))
+	fields.Set("email", email)
+	fields.Set("marketing_consent", "0")
+	fields.Set("currency", o.total.Currency)
+	fields.Set("sale_gross", gross)
+	fields.Set("payment_tax", "0.00")
+	fields.Set("fee", "0.00")
+	fields.Set("earnings", gross)
+	fields.Set("balance_currency", o.total.Currency)
+	fields.Set("balance_gross", gross)
+	fields.Set("balance_tax", "0.00")
+	fields.Set("balance_fee", "0.00")
+	fields.Set("balance_earnings", gross)
+	fields.Set("payment_method", "card")
+	fields.Set("country", "US")
+	fields.Set("customer_name", email)
+	fields.Set("receipt_url", s.receiptURL(o))
+	fields.Set("used_price_override", "0")
+	s.fire("payment_succeeded", fields)
+	return o.checkoutID, nil
+}
+
+// newOrder records a completed order of the product or plan with the
+// given ID.
+func (s *Server) newOrder(productID int, email string, quantity int, total paddle.Money) *order {
+	o := &order{
+		id:          s.nextID(),
+		productID:   productID,
+		email:       email,
+		quantity:    quantity,
+		total:       total,
+		completedAt: s.now(),
+	}
+	if p, ok := s.products[productID]; ok {
+		o.title = p.name
+	} else if p, ok := s.plans[productID]; ok {
+		o.title = p.name
+	}
+	o.checkoutID = fmt.Sprintf("%d-chk%s", o.id, randomHex(10))
+	s.orders[o.id] = o
+	return o
+}
+
+// orderID formats the ID of o, the way Paddle sends order IDs. It is empty
+// if o is nil.
+func orderID(o *order) string {
+	if o == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d-%d", o.completedAt.Year(), o.id)
+}
+
+func (s *Server) receiptURL(o *order) string {
+	if o == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/receipt/%d/%s", s.URL, o.id, o.checkoutID)
+}
+
+// findOrder returns the order with the given order ID.
+func (s *Server) findOrder(id string) (*order, bool) {
+	for _, o := range s.orders {
+		if orderID(o) == id || strconv.Itoa(o.id) == id {
+			return o, true
+		}
+	}
+	return nil, false
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// listProducts serves Products.List.
+func (s *Server) listProducts(form url.Values) (interface{}, *apiError) {
+	ids := make([]int, 0, len(s.products))
+	for id := range s.products {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	products := make([]*paddle.OneTimeProduct, len(ids))
+	for i, id := range ids {
+		p := s.products[id]
+		products[i] = &paddle.OneTimeProduct{
+			ID:          paddle.Int(p.id),
+			Name:        paddle.String(p.name),
+			Description: paddle.String(p.description),
+			BasePrice:   paddle.Float64(p.price.Amount.Float64()),
+			Currency:    paddle.String(p.price.Currency),
+			Screenshots: &[]map[string]interface{}{},
+		}
+	}
+	return &paddle.OneTimeProducts{
+		Total:    paddle.Int(len(products)),
+		Count:    paddle.Int(len(products)),
+		Products: products,
+	}, nil
+}
+
+// productExists reports whether a product or a plan has the given ID.
+func (s *Server) productExists(id int) bool {
+	_, isProduct := s.products[id]
+	_, isPlan := s.plans[id]
+	return isProduct || isPlan
+}
+
+// forProduct reports whether c is valid for the product or plan with the
+// given ID.
+func (c *coupon) forProduct(productID int) bool {
+	if c.couponType == "checkout" {
+		return true
+	}
+	for _, id := range c.productIDs {
+		if id == productID {
+			return true
+		}
+	}
+	return false
+}
+
+// redeemable reports whether c can be redeemed now on the product or plan
+// with the given ID.
+func (c *coupon) redeemable(productID int, now time.Time) bool {
+	if !c.expires.IsZero() && now.After(c.expires.Add(24*time.Hour)) {
+		return false
+	}
+	if c.allowedUses > 0 && c.timesUsed >= c.allowedUses {
+		return false
+	}
+	return c.forProduct(productID)
+}
+
+// parseProductIDs parses a comma separated list of product or plan IDs,
+// which must exist.
+func (s *Server) parseProductIDs(form url.Values, name string) ([]int, *apiError) {
+	var ids []int
+	for _, field := range strings.Split(form.Get(name), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		id, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, errInvalidArgument(name)
+		}
+		if !s.productExists(id) {
+			return nil, errorf(paddle.ErrCodeProductNotFound, "Unable to find requested product")
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// listCoupons serves Coupons.List.
+func (s *Server) listCoupons(form url.Values) (interface{}, *apiError) {
+	productID, apiErr := requiredInt(form, "product_id")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if !s.productExists(productID) {
+		return nil, errorf(paddle.ErrCodeProductNotFound, "Unable to find requested product")
+	}
+
+	codes := make([]string, 0, len(s.coupons))
+	for code := range s.coupons {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	coupons := []*paddle.Coupon{}
+	for _, code := range codes {
+		c := s.coupons[code]
+		if !c.forProduct(productID) {
+			continue
+		}
+		amount := c.amount
+		pc := &paddle.Coupon{
+			Coupon:         paddle.String(c.code),
+			Description:    paddle.String(c.description),
+			DiscountType:   paddle.String(c.discountType),
+			DiscountAmount: &amount,
+			AllowedUses:    paddle.Int(c.allowedUses),
+			TimesUsed:      paddle.Int(c.timesUsed),
+			IsRecurring:    paddle.Bool(c.recurring),
+		}
+		if c.currency != "" {
+			pc.DiscountCurrency = paddle.String(c.currency)
+		}
+		if !c.expires.IsZero() {
+			pc.Expires = paddle.String(c.expires.Format(timeLayout))
+		}
+		coupons = append(coupons, pc)
+	}
+	return coupons, nil
+}
+
+// couponSettings validates the settings shared by the creation and the
+// update of coupons, and applies them to c.
+func (s *Server) couponSettings(c *coupon, form url.Values) *apiError {
+	if form.Get("discount_amount") != "" {
+		amount, err := paddle.ParseDecimal(form.Get("discount_amount"))
+		if err != nil || amount.Sign() <= 0 {
+			return errInvalidArgument("discount_amount")
+		}
+		c.amount = amount
+	}
+	if c.discountType == "percentage" && c.amount.Cmp(paddle.NewDecimal(100, 0)) > 0 {
+		return errInvalidArgument("discount_amount")
+	}
+	if currency := strings.ToUpper(form.Get("currency")); currency != "" {
+		if apiErr := checkCurrency(currency); apiErr != nil {
+			return apiErr
+		}
+		c.currency = currency
+	}
+	if c.discountType == "flat" && c.currency == "" {
+		return errMissingArgument("currency")
+	}
+	if form.Get("product_ids") != "" {
+		ids, apiErr := s.parseProductIDs(form, "product_ids")
+		if apiErr != nil {
+			return apiErr
+		}
+		c.productIDs = ids
+	}
+	if allowedUses, ok, apiErr := formInt(form, "allowed_uses"); apiErr != nil {
+		return apiErr
+	} else if ok {
+		if allowedUses < 0 {
+			return errInvalidArgument("allowed_uses")
+		}
+		c.allowedUses = allowedUses
+	}
+	if recurring, ok, apiErr := formBool(form, "recurring"); apiErr != nil {
+		return apiErr
+	} else if ok {
+		c.recurring = recurring
+	}
+	if v := form.Get("expires"); v != "" {
+		expires, err := time.ParseInLocation(dateLayout, v, time.UTC)
+		if err != nil || expires.Before(s.now().Truncate(24*time.Hour)) {
+			return errorf(paddle.ErrCodeInvalidExpiration, "Provided expiration time is incorrect")
+		}
+		c.expires = expires
+	}
+	return nil
+}
+
+// createCoupon serves Coupons.Create.
+func (s *Server) createCoupon(form url.Values) (interface{}, *apiError) {
+	c := coupon{
+		couponType:   form.Get("coupon_type"),
+		discountType: form.Get("discount_type"),
+		description:  form.Get("description"),
+		group:        form.Get("group"),
+	}
+	switch c.couponType {
+	case "":
+		return nil, errMissingArgument("coupon_type")
+	case "product", "checkout":
+	default:
+		return nil, errInvalidArgument("coupon_type")
+	}
+	switch c.discountType {
+	case "":
+		return nil, errMissingArgument("discount_type")
+	case "flat", "percentage":
+	default:
+		return nil, errInvalidArgument("discount_type")
+	}
+	if form.Get("discount_amount") == "" {
+		return nil, errMissingArgument("discount_amount")
+	}
+	if apiErr := s.couponSettings(&c, form); apiErr != nil {
+		return nil, apiErr
+	}
+	if c.couponType == "product" && len(c.productIDs) == 0 {
+		return nil, errMissingArgument("product_ids")
+	}
+
+	var codes []string
+	if code := form.Get("coupon_code"); code != "" {
+		codes = []string{code}
+	} else {
+		n, ok, apiErr := formInt(form, "num_coupons")
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if !ok {
+			n = 1
+		}
+		if n < 1 || n > 5000 {
+			return nil, errInvalidArgument("num_coupons")
+		}
+		for i := 0; i < n; i++ {
+			codes = append(codes, form.Get("coupon_prefix")+strings.ToUpper(randomHex(4)))
+		}
+	}
+	for _, code := range codes {
+		if _, ok := s.coupons[code]; ok {
+			return nil, errorf(paddle.ErrCodeBadMethodCall, "Coupon code %s is already in use", code)
+		}
+	}
+
+	for _, code := range codes {
+		created := c
+		created.code = code
+		s.coupons[code] = &created
+	}
+	return &paddle.CouponCodes{CouponCode: codes}, nil
+}
+
+// updateCoupon serves Coupons.Update, updating either the coupon with the
+// given code or every coupon of the given group.
+func (s *Server) updateCoupon(form url.Values) (interface{}, *apiError) {
+	code, group := form.Get("coupon_code"), form.Get("group")
+	if code == "" && group == "" {
+		return nil, errMissingArgument("coupon_code")
+	}
+
+	var selected []*coupon
+	for _, c := range s.coupons {
+		if code != "" && c.code == code || code == "" && c.group == group {
+			selected = append(selected, c)
+		}
+	}
+	newCode := form.Get("new_coupon_code")
+	if newCode != "" {
+		if code == "" {
+			return nil, errInvalidArgument("new_coupon_code")
+		}
+		if _, ok := s.coupons[newCode]; ok {
+			return nil, errorf(paddle.ErrCodeBadMethodCall, "Coupon code %s is already in use", newCode)
+		}
+	}
+
+	// Validate the settings against copies first, so that no coupon is
+	// updated if one of them fails.
+	updated := make([]coupon, len(selected))
+	for i, c := range selected {
+		updated[i] = *c
+		if apiErr := s.couponSettings(&updated[i], form); apiErr != nil {
+			return nil, apiErr
+		}
+		if g := form.Get("new_group"); g != "" {
+			updated[i].group = g
+		}
+	}
+	for i, c := range selected {
+		*c = updated[i]
+		if newCode != "" {
+			delete(s.coupons, c.code)
+			c.code = newCode
+			s.coupons[newCode] = c
+		}
+	}
+
+	return map[string]int{"updated": len(selected)}, nil
+}
+
+// deleteCoupon serves Coupons.Delete.
+func (s *Server) deleteCoupon(form url.Values) (interface{}, *apiError) {
+	code := form.Get("coupon_code")
+	if code == "" {
+		return nil, errMissingArgument("coupon_code")
+	}
+	c, ok := s.coupons[code]
+	if ok && form.Get("product_id") != "" {
+		productID, apiErr := requiredInt(form, "product_id")
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		ok = c.forProduct(productID)
+	}
+	if !ok {
+		return nil, errorf(paddle.ErrCodeBadMethodCall, "Unable to find requested coupon")
+	}
+	delete(s.coupons, code)
+	return nil, nil
+}
+
+// refundPayment serves RefundPayment.Refund. The whole remaining amount of
+// the order is refunded unless an amount is given.
+func (s *Server) refundPayment(form url.Values) (interface{}, *apiError) {
+	id := form.Get("order_id")
+	if id == "" {
+		return nil, errMissingArgument("order_id")
+	}
+	o, ok := s.findOrder(id)
+	if !ok {
+		return nil, errorf(paddle.ErrCodePurchaseNotFound, "Unable to find requested purchase")
+	}
+
+	remaining := paddle.Money{Currency: o.total.Currency, Amount: o.total.Amount.Sub(o.refunded)}
+	amount, ok, apiErr := formMoney(form, "amount", o.total.Currency)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if !ok {
+		amount = remaining
+	}
+	if amount.Amount.Sign() <= 0 {
+		return nil, errorf(paddle.ErrCodePriceTooLow, "Price is too low")
+	}
+	if amount.Amount.Cmp(remaining.Amount) > 0 {
+		return nil, errorf(paddle.ErrCodeBadMethodCall, "The amount exceeds the refundable amount of %s", formatMoney(remaining))
+	}
+
+	refundType := "partial"
+	if amount.Amount.Cmp(o.total.Amount) == 0 {
+		refundType = "full"
+	}
+	o.refunded = o.refunded.Add(amount.Amount)
+	r := Refund{ID: s.nextID(), OrderID: o.id, Amount: amount, Reason: form.Get("reason")}
+	s.refunds = append(s.refunds, r)
+
+	gross := formatMoney(amount)
+	fields := url.Values{}
+	fields.Set("order_id", orderID(o))
+	fields.Set("checkout_id", o.checkoutID)
+	fields.Set("email", o.email)
+	fields.Set("marketing_consent", "0")
+	fields.Set("quantity", strconv.Itoa(o.quantity))
+	fields.Set("currency", amount.Currency)
+	fields.Set("amount", gross)
+	fields.Set("gross_refund", gross)
+	fields.Set("tax_refund", "0.00")
+	fields.Set("fee_refund", "0.00")
+	fields.Set("earnings_decrease", gross)
+	fields.Set("balance_currency", amount.Currency)
+	fields.Set("balance_gross_refund", gross)
+	fields.Set("balance_tax_refund", "0.00")
+	fields.Set("balance_fee_refund", "0.00")
+	fields.Set("balance_earnings_decrease", gross)
+	fields.Set("refund_type", refundType)
+	fields.Set("refund_reason", r.Reason)
+
+	if sub, ok := s.subscriptions[o.subscriptionID]; ok {
+		for k, v := range s.subscriptionFields(sub) {
+			if _, set := fields[k]; !set {
+				fields[k] = v
+			}
+		}
+		for _, pay := range s.payments {
+			if pay.orderID == o.id {
+				fields.Set("subscription_payment_id", strconv.Itoa(pay.id))
+			}
+		}
+		fields.Set("initial_payment", boolField(o.checkoutID == sub.checkoutID))
+		fields.Set("instalments", "1")
+		s.fire("subscription_payment_refunded", fields)
+	} else {
+		s.fire("payment_refunded", fields)
+	}
+
+	return map[string]int{"refund_request_id": r.ID}, nil
+}
+
+// generatePayLink serves PayLink.Create. Custom pay links, without a
+// product_id, need a title and a webhook_url.
+func (s *Server) generatePayLink(form url.Values) (interface{}, *apiError) {
+	productID, hasProduct, apiErr := formInt(form, "product_id")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if hasProduct {
+		if !s.productExists(productID) {
+			return nil, errorf(paddle.ErrCodeProductNotFound, "Unable to find requested product")
+		}
+	} else {
+		if form.Get("title") == "" {
+			return nil, errMissingArgument("title")
+		}
+		if form.Get("webhook_url") == "" {
+			return nil, errMissingArgument("webhook_url")
+		}
+	}
+
+	for _, name := range []string{"prices", "recurring_prices"} {
+		if form.Get(name) == "" {
+			continue
+		}
+		if name == "recurring_prices" {
+			if _, isPlan := s.plans[productID]; !isPlan {
+				return nil, errInvalidArgument(name)
+			}
+		}
+		for _, price := range strings.Split(form.Get(name), ",") {
+			kv := strings.SplitN(price, ":", 2)
+			if len(kv) != 2 {
+				return nil, errInvalidArgument(name)
+			}
+			if apiErr := checkCurrency(kv[0]); apiErr != nil {
+				return nil, apiErr
+			}
+			amount, err := paddle.ParseDecimal(kv[1])
+			if err != nil {
+				return nil, errInvalidArgument(name)
+			}
+			if amount.Sign() < 0 {
+				return nil, errorf(paddle.ErrCodePriceTooLow, "Price is too low")
+			}
+		}
+	}
+
+	if v := form.Get("expires"); v != "" {
+		expires, err := time.ParseInLocation(dateLayout, v, time.UTC)
+		if err != nil || expires.Before(s.now().Truncate(24*time.Hour)) {
+			return nil, errorf(paddle.ErrCodeInvalidExpiration, "Provided expiration time is incorrect")
+		}
+	}
+	if v := form.Get("affiliates"); v != "" {
+		for _, affiliate := range strings.Split(v, ",") {
+			kv := strings.SplitN(affiliate, ":", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return nil, errorf(paddle.ErrCodeInvalidAffiliate, "Invalid or duplicated affiliate")
+			}
+			commission, err := paddle.ParseDecimal(kv[1])
+			if err != nil || commission.Sign() <= 0 || commission.Cmp(paddle.NewDecimal(1, 0)) > 0 {
+				return nil, errorf(paddle.ErrCodeInvalidCommission, "Invalid or missing affiliate commission")
+			}
+		}
+	}
+	for _, name := range []string{"quantity", "trial_days", "recurring_affiliate_limit"} {
+		if n, _, apiErr := formInt(form, name); apiErr != nil {
+			return nil, apiErr
+		} else if n < 0 {
+			return nil, errInvalidArgument(name)
+		}
+	}
+	if v := form.Get("customer_country"); v != "" && len(v) != 2 {
+		return nil, errInvalidArgument("customer_country")
+	}
+	if form.Get("vat_number") != "" {
+		for _, name := range []string{"vat_company_name", "vat_street", "vat_city", "vat_country", "vat_postcode"} {
+			if form.Get(name) == "" {
+				return nil, errMissingArgument(name)
+			}
+		}
+	}
+
+	link := PayLink{URL: fmt.Sprintf("%s/checkout/custom/%s", s.URL, randomHex(16)), Form: copyValues(form)}
+	link.Form.Del("vendor_id")
+	link.Form.Del("vendor_auth_code")
+	s.payLinks = append(s.payLinks, link)
+	return map[string]string{"url": link.URL}, nil
+}
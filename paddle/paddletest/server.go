@@ -0,0 +1,422 @@
+// Package paddletest provides a stateful, in-memory fake of the classic
+// Paddle vendor and checkout APIs, for testing code built on the paddle
+// package without reaching Paddle.
+//
+// Example usage:
+//
+//	srv := paddletest.NewServer()
+//	defer srv.Close()
+//
+//	planID := srv.AddPlan("Pro", "month", 1, paddle.MustParseMoney("USD", "9.99"))
+//	subscriptionID, err := srv.Subscribe(planID, "jane@example.com", 1)
+//
+//	client, err := srv.Client()
+//	users, _, err := client.Users.List(ctx, nil)
+package paddletest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Fakerr/go-paddle/paddle"
+)
+
+// Default vendor credentials of a Server.
+const (
+	DefaultVendorID       = "12345"
+	DefaultVendorAuthCode = "paddletest-auth-code"
+)
+
+// Layouts of the dates sent by Paddle.
+const (
+	timeLayout = "2006-01-02 15:04:05"
+	dateLayout = "2006-01-02"
+)
+
+// Server is a fake Paddle API listening on a local address. It serves the
+// vendor endpoints, authenticated with VendorID and VendorAuthCode, and the
+// checkout endpoints on the same URL.
+//
+// Like Paddle, failed calls are answered with a 200 status code and a JSON
+// body whose success field is false, holding the Paddle error code.
+//
+// When WebhookURL is set, the alerts Paddle would send when the state
+// changes are signed with WebhookKey and posted to it before the request
+// changing the state is answered.
+type Server struct {
+	// URL of the server, of the form http://ipaddr:port with no trailing slash.
+	URL string
+
+	// Vendor credentials the vendor endpoints expect.
+	VendorID       string
+	VendorAuthCode string
+
+	// WebhookURL receives the alerts. Alerts are only recorded in the alert
+	// history if empty. It must be set before the state changes.
+	WebhookURL string
+
+	// WebhookClient sends the alerts. http.DefaultClient is used if nil.
+	WebhookClient *http.Client
+
+	// Now returns the current time. time.Now is used if nil.
+	Now func() time.Time
+
+	server *httptest.Server
+
+	keyOnce sync.Once
+	key     *rsa.PrivateKey
+	keyErr  error
+
+	mu            sync.Mutex
+	lastID        int
+	plans         map[int]*plan
+	products      map[int]*product
+	subscriptions map[int]*subscription
+	modifiers     map[int]*modifier
+	payments      map[int]*payment
+	orders        map[int]*order
+	coupons       map[string]*coupon
+	userIDs       map[string]int // User IDs, keyed on email.
+	refunds       []Refund
+	payLinks      []PayLink
+	licenses      []License
+	alerts        []*Alert
+	outbox        []*Alert // Alerts waiting to be delivered.
+}
+
+// NewServer starts and returns a new Server with an empty state. The caller
+// should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		VendorID:       DefaultVendorID,
+		VendorAuthCode: DefaultVendorAuthCode,
+		plans:          map[int]*plan{},
+		products:       map[int]*product{},
+		subscriptions:  map[int]*subscription{},
+		modifiers:      map[int]*modifier{},
+		payments:       map[int]*payment{},
+		orders:         map[int]*order{},
+		coupons:        map[string]*coupon{},
+		userIDs:        map[string]int{},
+	}
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a paddle.Client sending every request to s with its vendor
+// credentials. opts are applied after the options setting these.
+func (s *Server) Client(opts ...paddle.Option) (*paddle.Client, error) {
+	return paddle.New(append([]paddle.Option{
+		paddle.WithCredentials(s.VendorID, s.VendorAuthCode),
+		paddle.WithBaseURL(s.URL),
+		paddle.WithCheckoutBaseURL(s.URL),
+	}, opts...)...)
+}
+
+// WebhookKey returns the private key the alerts are signed with, generated
+// on first use.
+func (s *Server) WebhookKey() (*rsa.PrivateKey, error) {
+	s.keyOnce.Do(func() {
+		s.key, s.keyErr = rsa.GenerateKey(rand.Reader, 2048)
+	})
+	return s.key, s.keyErr
+}
+
+// WebhookPublicKey returns the PEM encoded public key verifying the alerts,
+// to be given to paddle.NewWebhookHandler.
+func (s *Server) WebhookPublicKey() ([]byte, error) {
+	key, err := s.WebhookKey()
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// apiError is a failed API call, answered with the Paddle error code and
+// message.
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func errorf(code int, format string, a ...interface{}) *apiError {
+	return &apiError{Code: code, Message: fmt.Sprintf(format, a...)}
+}
+
+// Errors shared by several endpoints, with the messages Paddle sends.
+func errMissingArgument(name string) *apiError {
+	return errorf(paddle.ErrCodeMissingArguments, "One or more required arguments are missing: %s", name)
+}
+
+func errInvalidArgument(name string) *apiError {
+	return errorf(paddle.ErrCodeBadMethodCall, "Invalid value for argument: %s", name)
+}
+
+// endpoint handles a call to the API. It is called with the lock of the
+// server held and returns the value of the response field of the answer.
+type endpoint func(s *Server, form url.Values) (interface{}, *apiError)
+
+// vendorEndpoints are the endpoints of the vendors API, called with POST
+// and authenticated with the vendor credentials.
+var vendorEndpoints = map[string]endpoint{
+	"2.0/subscription/plans":               (*Server).listPlans,
+	"2.0/subscription/plans_create":        (*Server).createPlan,
+	"2.0/subscription/users":               (*Server).listUsers,
+	"2.0/subscription/users/update":        (*Server).updateUser,
+	"2.0/subscription/users_cancel":        (*Server).cancelUser,
+	"2.0/subscription/modifiers":           (*Server).listModifiers,
+	"2.0/subscription/modifiers/create":    (*Server).createModifier,
+	"2.0/subscription/modifiers/delete":    (*Server).deleteModifier,
+	"2.0/subscription/payments":            (*Server).listPayments,
+	"2.0/subscription/payments_reschedule": (*Server).reschedulePayment,
+	"2.0/product/get_products":             (*Server).listProducts,
+	"2.0/product/list_coupons":             (*Server).listCoupons,
+	"2.1/product/create_coupon":            (*Server).createCoupon,
+	"2.1/product/update_coupon":            (*Server).updateCoupon,
+	"2.0/product/delete_coupon":            (*Server).deleteCoupon,
+	"2.0/product/generate_pay_link":        (*Server).generatePayLink,
+	"2.0/product/generate_license":         (*Server).generateLicense,
+	"2.0/payment/refund":                   (*Server).refundPayment,
+	"2.0/alert/webhooks":                   (*Server).listAlerts,
+}
+
+// checkoutEndpoints are the endpoints of the checkout API, called with GET
+// and not authenticated.
+var checkoutEndpoints = map[string]endpoint{
+	"2.0/prices":       (*Server).getPrices,
+	"1.0/order":        (*Server).getOrder,
+	"2.0/user/history": (*Server).getUserHistory,
+}
+
+// chargePath matches the path of the one-off charges endpoint.
+var chargePath = regexp.MustCompile(`^2\.0/subscription/([^/]+)/charge$`)
+
+// transactionsPath matches the path of the transactions endpoint.
+var transactionsPath = regexp.MustCompile(`^2\.0/(user|subscription|order|checkout|product)/([^/]+)/transactions$`)
+
+// ServeHTTP serves the API.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var fn endpoint
+	vendor := false
+	if r.Method == http.MethodGet {
+		fn = checkoutEndpoints[path]
+	} else if r.Method == http.MethodPost {
+		fn, vendor = vendorEndpoints[path], true
+		if m := chargePath.FindStringSubmatch(path); m != nil {
+			r.Form.Set("subscription_id", m[1])
+			fn = (*Server).createOneOffCharge
+		}
+		if m := transactionsPath.FindStringSubmatch(path); m != nil {
+			fn = func(s *Server, form url.Values) (interface{}, *apiError) {
+				return s.listTransactions(m[1], m[2], form)
+			}
+		}
+	}
+	if fn == nil {
+		writeJSON(w, nil, errorf(paddle.ErrCodeBadMethodCall, "Bad method call"))
+		return
+	}
+
+	s.mu.Lock()
+	var (
+		response interface{}
+		apiErr   *apiError
+	)
+	if vendor && (r.Form.Get("vendor_id") != s.VendorID || r.Form.Get("vendor_auth_code") != s.VendorAuthCode) {
+		apiErr = errorf(paddle.ErrCodePermissionDenied, "You don't have permission to access this resource")
+	} else {
+		response, apiErr = fn(s, r.Form)
+	}
+	outbox := s.takeOutbox()
+	s.mu.Unlock()
+
+	s.deliver(outbox)
+	writeJSON(w, response, apiErr)
+}
+
+// writeJSON writes the answer of an API call.
+func writeJSON(w http.ResponseWriter, response interface{}, apiErr *apiError) {
+	body := map[string]interface{}{"success": apiErr == nil}
+	if apiErr != nil {
+		body["error"] = apiErr
+	} else if response != nil {
+		body["response"] = response
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+// now returns the current time in UTC.
+func (s *Server) now() time.Time {
+	if s.Now != nil {
+		return s.Now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+// nextID returns a new ID, unique across every kind of object.
+func (s *Server) nextID() int {
+	s.lastID++
+	return s.lastID
+}
+
+// formInt returns the integer argument name of form, and whether it is set.
+func formInt(form url.Values, name string) (int, bool, *apiError) {
+	v := form.Get(name)
+	if v == "" {
+		return 0, false, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false, errInvalidArgument(name)
+	}
+	return n, true, nil
+}
+
+// requiredInt is like formInt but fails if the argument is not set.
+func requiredInt(form url.Values, name string) (int, *apiError) {
+	n, ok, apiErr := formInt(form, name)
+	if apiErr == nil && !ok {
+		apiErr = errMissingArgument(name)
+	}
+	return n, apiErr
+}
+
+// formBool returns the boolean argument name of form, sent as true, false,
+// 1 or 0, and whether it is set.
+func formBool(form url.Values, name string) (bool, bool, *apiError) {
+	switch strings.ToLower(form.Get(name)) {
+	case "":
+		return false, false, nil
+	case "true", "1":
+		return true, true, nil
+	case "false", "0":
+		return false, true, nil
+	}
+	return false, false, errInvalidArgument(name)
+}
+
+// formMoney returns the amount argument name of form, in currency.
+func formMoney(form url.Values, name, currency string) (paddle.Money, bool, *apiError) {
+	v := form.Get(name)
+	if v == "" {
+		return paddle.Money{}, false, nil
+	}
+	m, err := paddle.ParseMoney(currency, v)
+	if err != nil {
+		return paddle.Money{}, false, errInvalidArgument(name)
+	}
+	return m, true, nil
+}
+
+// formDate returns the date argument name of form, and whether it is set.
+func formDate(form url.Values, name string) (time.Time, bool, *apiError) {
+	v := form.Get(name)
+	if v == "" {
+		return time.Time{}, false, nil
+	}
+	t, err := time.ParseInLocation(dateLayout, v, time.UTC)
+	if err != nil {
+		return time.Time{}, false, errorf(paddle.ErrCodeInvalidDate, "Provided date is not valid: %s", name)
+	}
+	return t, true, nil
+}
+
+// paginate returns the items of the page requested in form, defaulting to
+// the first page of perPage items.
+func paginate(form url.Values, n, perPage int) (start, end int, apiErr *apiError) {
+	page, ok, apiErr := formInt(form, "page")
+	if apiErr != nil {
+		return 0, 0, apiErr
+	}
+	if !ok {
+		page = 1
+	}
+	if size, ok, apiErr := formInt(form, "results_per_page"); apiErr != nil {
+		return 0, 0, apiErr
+	} else if ok {
+		if size < 1 || size > 200 {
+			return 0, 0, errInvalidArgument("results_per_page")
+		}
+		perPage = size
+	}
+	if page < 1 {
+		return 0, 0, errInvalidArgument("page")
+	}
+
+	start = (page - 1) * perPage
+	if start > n {
+		start = n
+	}
+	end = start + perPage
+	if end > n {
+		end = n
+	}
+	return start, end, nil
+}
+
+// supportedCurrencies are the currencies prices can be set in.
+var supportedCurrencies = map[string]bool{
+	"USD": true, "EUR": true, "GBP": true, "ARS": true, "AUD": true, "BRL": true,
+	"CAD": true, "CHF": true, "CNY": true, "CZK": true, "DKK": true, "HKD": true,
+	"HUF": true, "ILS": true, "INR": true, "JPY": true, "KRW": true, "MXN": true,
+	"NOK": true, "NZD": true, "PLN": true, "RUB": true, "SEK": true, "SGD": true,
+	"THB": true, "TRY": true, "TWD": true, "UAH": true, "ZAR": true,
+}
+
+func checkCurrency(currency string) *apiError {
+	if !supportedCurrencies[strings.ToUpper(currency)] {
+		return errorf(paddle.ErrCodeInvalidCurrency, "Provided currency is not valid: %q", currency)
+	}
+	return nil
+}
+
+// formatMoney formats m the way Paddle does, padded to the minor unit of
+// its currency.
+func formatMoney(m paddle.Money) string {
+	v := url.Values{}
+	m.EncodeValues("amount", &v)
+	return v.Get("amount")
+}
+
+// times returns m multiplied by n, or paddle.ErrDecimalOverflow if the
+// product is too large.
+func times(m paddle.Money, n int) (paddle.Money, error) {
+	total := paddle.Money{Currency: m.Currency}
+	for i := 0; i < n; i++ {
+		var err error
+		if total.Amount, err = total.Amount.AddChecked(m.Amount); err != nil {
+			return paddle.Money{}, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,572 @@
+package paddletest
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Fakerr/go-paddle/paddle"
+)
+
+// setup returns a Server and a client talking to it.
+func setup(t *testing.T) (*Server, *paddle.Client) {
+	t.Helper()
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.Client()
+	if err != nil {
+		t.Fatalf("Client returned error: %v", err)
+	}
+	return srv, client
+}
+
+// receiveAlerts points the webhooks of srv to a paddle.WebhookHandler and
+// returns a function listing the names of the alerts it received.
+func receiveAlerts(t *testing.T, srv *Server) func() []string {
+	t.Helper()
+	publicKey, err := srv.WebhookPublicKey()
+	if err != nil {
+		t.Fatalf("WebhookPublicKey returned error: %v", err)
+	}
+
+	var (
+		mu    sync.Mutex
+		names []string
+	)
+	h := paddle.NewWebhookHandler(publicKey)
+	h.Fallback(func(ctx context.Context, alertName string, alert interface{}) error {
+		mu.Lock()
+		defer mu.Unlock()
+		names = append(names, alertName)
+		return nil
+	})
+	receiver := httptest.NewServer(h)
+	t.Cleanup(receiver.Close)
+	srv.WebhookURL = receiver.URL
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), names...)
+	}
+}
+
+func TestServer_subscriptionLifecycle(t *testing.T) {
+	srv, client := setup(t)
+	received := receiveAlerts(t, srv)
+	ctx := context.Background()
+
+	planID := srv.AddPlan("Pro", "month", 1, paddle.MustParseMoney("USD", "9.99"))
+	subscriptionID, err := srv.Subscribe(planID, "jane@example.com", 1)
+	if err != nil {
+		t.Fatalf("Subscribe returned error: %v", err)
+	}
+
+	users, _, err := client.Users.List(ctx, &paddle.UsersOptions{PlanID: strconv.Itoa(planID)})
+	if err != nil {
+		t.Fatalf("Users.List returned error: %v", err)
+	}
+	if len(users) != 1 || *users[0].SubscriptionID != subscriptionID || *users[0].State != "active" {
+		t.Fatalf("Users.List returned %+v", users)
+	}
+	if got, want := *users[0].LastPayment.Amount, paddle.MustParseMoney("USD", "9.99"); got.Amount.Cmp(want.Amount) != 0 || got.Currency != "USD" {
+		t.Errorf("LastPayment.Amount = %v, want %v", got, want)
+	}
+
+	user, _, err := client.Users.Update(ctx, subscriptionID, 2, nil)
+	if err != nil {
+		t.Fatalf("Users.Update returned error: %v", err)
+	}
+	if got := user.NextPayment.Amount.Amount.String(); got != "19.98" {
+		t.Errorf("NextPayment.Amount = %v, want 19.98", got)
+	}
+
+	modifier, _, err := client.Modifiers.Create(ctx, subscriptionID, paddle.MustParseMoney("USD", "5"), &paddle.ModifierCreateOptions{ModifierDescription: "Support"})
+	if err != nil {
+		t.Fatalf("Modifiers.Create returned error: %v", err)
+	}
+	modifiers, _, err := client.Modifiers.List(ctx, &paddle.ModifiersOptions{SubscriptionID: subscriptionID})
+	if err != nil {
+		t.Fatalf("Modifiers.List returned error: %v", err)
+	}
+	if len(modifiers) != 1 || *modifiers[0].ModifierID != *modifier.ModifierID || !*modifiers[0].IsRecurring {
+		t.Errorf("Modifiers.List returned %+v", modifiers)
+	}
+
+	if _, _, err := client.OneOffCharges.Create(ctx, subscriptionID, paddle.MustParseMoney("USD", "20"), "Setup"); err != nil {
+		t.Fatalf("OneOffCharges.Create returned error: %v", err)
+	}
+
+	payments, _, err := client.Payments.List(ctx, &paddle.PaymentsOptions{SubscriptionID: subscriptionID})
+	if err != nil {
+		t.Fatalf("Payments.List returned error: %v", err)
+	}
+	if len(payments) != 3 {
+		t.Fatalf("Payments.List returned %d payments, want 3", len(payments))
+	}
+	next := payments[2]
+	if *next.IsPaid != 0 || next.Amount.Amount.String() != "24.98" {
+		t.Errorf("scheduled payment = %+v, want unpaid 24.98", next)
+	}
+
+	if _, _, err := client.Users.Cancel(ctx, subscriptionID); err != nil {
+		t.Fatalf("Users.Cancel returned error: %v", err)
+	}
+	users, _, err = client.Users.List(ctx, &paddle.UsersOptions{State: "deleted"})
+	if err != nil {
+		t.Fatalf("Users.List returned error: %v", err)
+	}
+	if len(users) != 1 || users[0].NextPayment != nil {
+		t.Errorf("Users.List returned %+v, want the cancelled subscription without next payment", users)
+	}
+
+	want := []string{
+		"subscription_created",
+		"subscription_payment_succeeded",
+		"subscription_updated",
+		"subscription_payment_succeeded",
+		"subscription_cancelled",
+	}
+	if got := received(); !reflect.DeepEqual(got, want) {
+		t.Errorf("received alerts %v, want %v", got, want)
+	}
+	for _, a := range srv.Alerts() {
+		if a.Status != AlertSuccess || a.Attempts != 1 {
+			t.Errorf("alert %v has status %v after %d attempts", a.Name, a.Status, a.Attempts)
+		}
+	}
+
+	history, _, err := client.Webhooks.Get(ctx, &paddle.WebhookEventOptions{AlertsPerPage: "2"})
+	if err != nil {
+		t.Fatalf("Webhooks.Get returned error: %v", err)
+	}
+	if *history.TotalAlerts != 5 || *history.TotalPages != 3 || *history.Data[0].AlertName != "subscription_cancelled" {
+		t.Errorf("Webhooks.Get returned %+v", history)
+	}
+}
+
+func TestServer_pauseAndResume(t *testing.T) {
+	srv, client := setup(t)
+	ctx := context.Background()
+
+	planID := srv.AddPlan("Pro", "year", 1, paddle.MustParseMoney("EUR", "99"))
+	subscriptionID, err := srv.Subscribe(planID, "jane@example.com", 1)
+	if err != nil {
+		t.Fatalf("Subscribe returned error: %v", err)
+	}
+
+	if _, _, err := client.Users.Update(ctx, subscriptionID, 0, &paddle.UserUpdateOptions{Pause: paddle.Bool(true)}); err != nil {
+		t.Fatalf("Users.Update returned error: %v", err)
+	}
+	users, _, err := client.Users.List(ctx, &paddle.UsersOptions{State: "paused"})
+	if err != nil {
+		t.Fatalf("Users.List returned error: %v", err)
+	}
+	if len(users) != 1 || users[0].PausedAt == nil || users[0].NextPayment != nil {
+		t.Fatalf("Users.List returned %+v", users)
+	}
+
+	_, _, err = client.Users.Update(ctx, subscriptionID, 3, nil)
+	if !paddle.IsValidationError(err) {
+		t.Errorf("Users.Update of a paused subscription returned %v, want a validation error", err)
+	}
+	if err := srv.Renew(subscriptionID); err == nil {
+		t.Error("Renew of a paused subscription returned no error")
+	}
+
+	var transitionErr *paddle.TransitionError
+	if _, _, err := client.Users.ChangeQuantity(ctx, subscriptionID, 3); !errors.As(err, &transitionErr) {
+		t.Errorf("Users.ChangeQuantity of a paused subscription returned %v, want a *TransitionError", err)
+	}
+	if _, _, err := client.Users.Resume(ctx, subscriptionID); err != nil {
+		t.Fatalf("Users.Resume returned error: %v", err)
+	}
+	if _, _, err := client.Users.ChangeQuantity(ctx, subscriptionID, 3); err != nil {
+		t.Errorf("Users.ChangeQuantity returned error: %v", err)
+	}
+	if _, _, err := client.Users.Resume(ctx, subscriptionID); !errors.As(err, &transitionErr) {
+		t.Errorf("Users.Resume of an active subscription returned %v, want a *TransitionError", err)
+	}
+}
+
+func TestServer_errors(t *testing.T) {
+	srv, client := setup(t)
+	ctx := context.Background()
+
+	_, _, err := client.Users.Cancel(ctx, 42)
+	if !errors.Is(err, paddle.ErrNotFound) || paddle.ErrorCode(err) != paddle.ErrCodeSubscriptionNotFound {
+		t.Errorf("Users.Cancel returned %v, want error %d", err, paddle.ErrCodeSubscriptionNotFound)
+	}
+
+	_, _, err = client.Modifiers.Delete(ctx, 42)
+	if paddle.ErrorCode(err) != paddle.ErrCodeModifierNotFound {
+		t.Errorf("Modifiers.Delete returned %v, want error %d", err, paddle.ErrCodeModifierNotFound)
+	}
+
+	_, _, err = client.Plans.Create(ctx, "Pro", "month", 1, nil)
+	if paddle.ErrorCode(err) != paddle.ErrCodeMissingArguments {
+		t.Errorf("Plans.Create without price returned %v, want error %d", err, paddle.ErrCodeMissingArguments)
+	}
+
+	_, _, err = client.Plans.Create(ctx, "Pro", "month", 1, &paddle.PlanCreateOptions{MainCurrencyCode: "JPY", RecurringPriceUsd: &paddle.Money{Amount: paddle.MustParseDecimal("10")}})
+	if paddle.ErrorCode(err) != paddle.ErrCodeInvalidCurrency {
+		t.Errorf("Plans.Create in JPY returned %v, want error %d", err, paddle.ErrCodeInvalidCurrency)
+	}
+
+	subscriptionID, err := srv.Subscribe(srv.AddPlan("Pro", "month", 1, paddle.MustParseMoney("USD", "9.99")), "jane@example.com", 1)
+	if err != nil {
+		t.Fatalf("Subscribe returned error: %v", err)
+	}
+	_, _, err = client.Users.Update(ctx, subscriptionID, 2, &paddle.UserUpdateOptions{RecurringPrice: &paddle.Money{Currency: "USD", Amount: paddle.MustParseDecimal("999999999999999999")}})
+	if !errors.Is(err, paddle.ErrValidation) {
+		t.Errorf("Users.Update with an overflowing price returned %v, want a validation error", err)
+	}
+
+	_, _, err = client.Payments.Update(ctx, 42, "2030-01-01")
+	if paddle.ErrorCode(err) != paddle.ErrCodePaymentNotFound {
+		t.Errorf("Payments.Update returned %v, want error %d", err, paddle.ErrCodePaymentNotFound)
+	}
+
+	unauthorized, err := srv.Client(paddle.WithCredentials(srv.VendorID, "wrong"))
+	if err != nil {
+		t.Fatalf("Client returned error: %v", err)
+	}
+	if _, _, err := unauthorized.Plans.List(ctx, nil); !paddle.IsAuthError(err) {
+		t.Errorf("Plans.List with a wrong auth code returned %v, want an auth error", err)
+	}
+}
+
+func TestServer_plansAndPayments(t *testing.T) {
+	srv, client := setup(t)
+	ctx := context.Background()
+
+	created, _, err := client.Plans.Create(ctx, "Team", "week", 2, &paddle.PlanCreateOptions{
+		MainCurrencyCode:  "GBP",
+		RecurringPriceGbp: &paddle.Money{Currency: "GBP", Amount: paddle.MustParseDecimal("15")},
+		PlanTrialDays:     14,
+	})
+	if err != nil {
+		t.Fatalf("Plans.Create returned error: %v", err)
+	}
+	plans, _, err := client.Plans.List(ctx, &paddle.PlansOptions{PlanID: *created.ProductID})
+	if err != nil {
+		t.Fatalf("Plans.List returned error: %v", err)
+	}
+	if len(plans) != 1 || plans[0].RecurringPrice["GBP"].Amount.String() != "15" || *plans[0].TrialDays != 14 {
+		t.Fatalf("Plans.List returned %+v", plans)
+	}
+
+	subscriptionID, err := srv.Subscribe(*created.ProductID, "team@example.com", 1)
+	if err != nil {
+		t.Fatalf("Subscribe returned error: %v", err)
+	}
+	payments, _, err := client.Payments.List(ctx, &paddle.PaymentsOptions{SubscriptionID: subscriptionID})
+	if err != nil {
+		t.Fatalf("Payments.List returned error: %v", err)
+	}
+	if len(payments) != 1 || *payments[0].IsPaid != 0 {
+		t.Fatalf("Payments.List during the trial returned %+v", payments)
+	}
+
+	if _, _, err := client.Payments.Update(ctx, *payments[0].ID, "2000-01-01"); paddle.ErrorCode(err) != paddle.ErrCodeInvalidDate {
+		t.Errorf("Payments.Update in the past returned %v, want error %d", err, paddle.ErrCodeInvalidDate)
+	}
+	if _, _, err := client.Payments.Update(ctx, *payments[0].ID, "2099-01-01"); err != nil {
+		t.Fatalf("Payments.Update returned error: %v", err)
+	}
+
+	if err := srv.Renew(subscriptionID); err != nil {
+		t.Fatalf("Renew returned error: %v", err)
+	}
+	paid, _, err := client.Payments.List(ctx, &paddle.PaymentsOptions{SubscriptionID: subscriptionID, IsPaid: paddle.Bool(true)})
+	if err != nil {
+		t.Fatalf("Payments.List returned error: %v", err)
+	}
+	if len(paid) != 1 || *paid[0].Currency != "GBP" {
+		t.Errorf("Payments.List of paid payments returned %+v", paid)
+	}
+}
+
+func TestServer_couponsAndPrices(t *testing.T) {
+	srv, client := setup(t)
+	ctx := context.Background()
+
+	productID := srv.AddProduct("E-book", paddle.MustParseMoney("USD", "20"))
+	codes, _, err := client.Coupons.Create(ctx, "product", "percentage", paddle.MustParseDecimal("10"), &paddle.CouponCreateOptions{
+		CouponCode: "LAUNCH",
+		ProductIds: strconv.Itoa(productID),
+	})
+	if err != nil {
+		t.Fatalf("Coupons.Create returned error: %v", err)
+	}
+	if !reflect.DeepEqual(codes.CouponCode, []string{"LAUNCH"}) {
+		t.Errorf("Coupons.Create returned %v", codes.CouponCode)
+	}
+	if _, _, err := client.Coupons.Create(ctx, "product", "flat", paddle.MustParseDecimal("5"), &paddle.CouponCreateOptions{ProductIds: strconv.Itoa(productID)}); paddle.ErrorCode(err) != paddle.ErrCodeMissingArguments {
+		t.Errorf("Coupons.Create of a flat coupon without currency returned %v", err)
+	}
+
+	prices, _, err := client.Prices.Get(ctx, strconv.Itoa(productID), &paddle.PricesOptions{Coupons: "LAUNCH"})
+	if err != nil {
+		t.Fatalf("Prices.Get returned error: %v", err)
+	}
+	product := prices.Products[0]
+	if product.Price.Gross.Amount.String() != "18" || product.ListPrice.Gross.Amount.String() != "20" || *product.AppliedCoupon.Code != "LAUNCH" {
+		t.Errorf("Prices.Get returned %+v", product)
+	}
+
+	discount := paddle.MustParseDecimal("50")
+	updated, _, err := client.Coupons.Update(ctx, &paddle.CouponUpdateOptions{CouponCode: "LAUNCH", DiscountAmount: &discount})
+	if err != nil || *updated != 1 {
+		t.Fatalf("Coupons.Update returned %v, %v", updated, err)
+	}
+	coupons, _, err := client.Coupons.List(ctx, productID)
+	if err != nil {
+		t.Fatalf("Coupons.List returned error: %v", err)
+	}
+	if len(coupons) != 1 || coupons[0].DiscountAmount.String() != "50" {
+		t.Errorf("Coupons.List returned %+v", coupons)
+	}
+
+	if _, _, err := client.Coupons.Delete(ctx, "LAUNCH", nil); err != nil {
+		t.Fatalf("Coupons.Delete returned error: %v", err)
+	}
+	if _, _, err := client.Coupons.Delete(ctx, "LAUNCH", nil); err == nil {
+		t.Error("Coupons.Delete of a deleted coupon returned no error")
+	}
+}
+
+func TestServer_ordersAndRefunds(t *testing.T) {
+	srv, client := setup(t)
+	received := receiveAlerts(t, srv)
+	ctx := context.Background()
+
+	productID := srv.AddProduct("E-book", paddle.MustParseMoney("USD", "20"))
+	checkoutID, err := srv.Purchase(productID, "jane@example.com", 2)
+	if err != nil {
+		t.Fatalf("Purchase returned error: %v", err)
+	}
+
+	details, _, err := client.OrderDetails.Get(ctx, checkoutID)
+	if err != nil {
+		t.Fatalf("OrderDetails.Get returned error: %v", err)
+	}
+	if *details.State != "processed" || details.Order.Total.String() != "USD 40" {
+		t.Fatalf("OrderDetails.Get returned %+v", details.Order)
+	}
+	orderID := orderID(srv.orders[*details.Order.OrderID])
+
+	if _, _, err := client.RefundPayment.Refund(ctx, orderID, &paddle.RefundPaymentOptions{Amount: paddle.MustParseMoney("USD", "15")}); err != nil {
+		t.Fatalf("RefundPayment.Refund returned error: %v", err)
+	}
+	if _, _, err := client.RefundPayment.Refund(ctx, orderID, &paddle.RefundPaymentOptions{Amount: paddle.MustParseMoney("USD", "30")}); !paddle.IsValidationError(err) {
+		t.Errorf("RefundPayment.Refund above the order total returned %v, want a validation error", err)
+	}
+	if refunds := srv.Refunds(); len(refunds) != 1 || refunds[0].Amount.String() != "USD 15" {
+		t.Errorf("Refunds returned %+v", refunds)
+	}
+
+	if _, _, err := client.UserHistory.Get(ctx, "jane@example.com", nil); err != nil {
+		t.Errorf("UserHistory.Get returned error: %v", err)
+	}
+	if _, _, err := client.UserHistory.Get(ctx, "john@example.com", nil); !errors.Is(err, paddle.ErrNotFound) {
+		t.Errorf("UserHistory.Get of an unknown email returned %v", err)
+	}
+
+	if got, want := received(), []string{"payment_succeeded", "payment_refunded"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("received alerts %v, want %v", got, want)
+	}
+}
+
+func TestServer_transactions(t *testing.T) {
+	srv, client := setup(t)
+	ctx := context.Background()
+
+	planID := srv.AddPlan("Pro", "month", 1, paddle.MustParseMoney("USD", "9.99"))
+	subscriptionID, err := srv.Subscribe(planID, "jane@example.com", 1)
+	if err != nil {
+		t.Fatalf("Subscribe returned error: %v", err)
+	}
+	if _, _, err := client.OneOffCharges.Create(ctx, subscriptionID, paddle.MustParseMoney("USD", "5"), "Setup"); err != nil {
+		t.Fatalf("OneOffCharges.Create returned error: %v", err)
+	}
+	productID := srv.AddProduct("E-book", paddle.MustParseMoney("USD", "20"))
+	checkoutID, err := srv.Purchase(productID, "jane@example.com", 1)
+	if err != nil {
+		t.Fatalf("Purchase returned error: %v", err)
+	}
+	if _, err := srv.Purchase(productID, "john@example.com", 1); err != nil {
+		t.Fatalf("Purchase returned error: %v", err)
+	}
+
+	users, _, err := client.Users.List(ctx, nil)
+	if err != nil || len(users) != 1 {
+		t.Fatalf("Users.List returned %+v, %v", users, err)
+	}
+	transactions, _, err := client.Transactions.ListByUser(ctx, *users[0].UserID, nil)
+	if err != nil {
+		t.Fatalf("Transactions.ListByUser returned error: %v", err)
+	}
+	if len(transactions) != 3 {
+		t.Fatalf("Transactions.ListByUser returned %d transactions, want 3", len(transactions))
+	}
+	purchase, charge, signup := transactions[0], transactions[1], transactions[2]
+	if *purchase.CheckoutID != checkoutID || *purchase.IsSubscription || purchase.Subscription != nil {
+		t.Errorf("purchase transaction = %+v", purchase)
+	}
+	if !*charge.IsOneOff || charge.Amount.String() != "USD 5" {
+		t.Errorf("one-off charge transaction = %+v", charge)
+	}
+	if *signup.Subscription.SubscriptionID != subscriptionID || *signup.Status != "completed" || *signup.User.Email != "jane@example.com" {
+		t.Errorf("signup transaction = %+v", signup)
+	}
+
+	if _, _, err := client.RefundPayment.Refund(ctx, *purchase.OrderID, nil); err != nil {
+		t.Fatalf("RefundPayment.Refund returned error: %v", err)
+	}
+	byOrder, _, err := client.Transactions.ListByOrder(ctx, *purchase.OrderID, nil)
+	if err != nil || len(byOrder) != 1 || *byOrder[0].Status != "refunded" {
+		t.Errorf("Transactions.ListByOrder returned %+v, %v", byOrder, err)
+	}
+
+	bySubscription, err := client.Transactions.Iter(ctx, paddle.TransactionEntitySubscription, strconv.Itoa(subscriptionID), nil).All()
+	if err != nil || len(bySubscription) != 2 {
+		t.Errorf("Transactions.Iter of the subscription returned %d transactions, %v", len(bySubscription), err)
+	}
+	byProduct, _, err := client.Transactions.ListByProduct(ctx, productID, nil)
+	if err != nil || len(byProduct) != 2 {
+		t.Errorf("Transactions.ListByProduct returned %d transactions, %v", len(byProduct), err)
+	}
+}
+
+func TestServer_licenses(t *testing.T) {
+	srv, client := setup(t)
+	ctx := context.Background()
+
+	productID := srv.AddProduct("Desktop app", paddle.MustParseMoney("USD", "49"))
+	checkoutID, err := srv.Purchase(productID, "jane@example.com", 1)
+	if err != nil {
+		t.Fatalf("Purchase returned error: %v", err)
+	}
+
+	license, _, err := client.Licenses.Generate(ctx, productID, 2, &paddle.LicenseGenerateOptions{ExpiresAt: "2999-01-01"})
+	if err != nil {
+		t.Fatalf("Licenses.Generate returned error: %v", err)
+	}
+	if got := srv.Licenses(); len(got) != 1 || got[0].Code != *license.LicenseCode || got[0].AllowedUses != 2 {
+		t.Errorf("Licenses returned %+v", got)
+	}
+	if err := srv.DeliverLicense(checkoutID, *license.LicenseCode); err != nil {
+		t.Fatalf("DeliverLicense returned error: %v", err)
+	}
+
+	licenses, _, err := client.Licenses.ListByCheckout(ctx, checkoutID)
+	if err != nil {
+		t.Fatalf("Licenses.ListByCheckout returned error: %v", err)
+	}
+	if len(licenses) != 1 || licenses[0].LicenseCode != *license.LicenseCode || licenses[0].ProductID != productID || licenses[0].CheckoutID != checkoutID {
+		t.Errorf("Licenses.ListByCheckout returned %+v", licenses)
+	}
+
+	if _, _, err := client.Licenses.Generate(ctx, 42, 1, nil); paddle.ErrorCode(err) != paddle.ErrCodeProductNotFound {
+		t.Errorf("Licenses.Generate of an unknown product returned %v", err)
+	}
+	if _, _, err := client.Licenses.Generate(ctx, productID, 0, nil); !paddle.IsValidationError(err) {
+		t.Errorf("Licenses.Generate with zero allowed uses returned %v, want a validation error", err)
+	}
+	if _, _, err := client.Licenses.Generate(ctx, productID, 1, &paddle.LicenseGenerateOptions{ExpiresAt: "2000-01-01"}); paddle.ErrorCode(err) != paddle.ErrCodeInvalidExpiration {
+		t.Errorf("Licenses.Generate with a past expiry returned %v", err)
+	}
+}
+
+func TestServer_payLinks(t *testing.T) {
+	srv, client := setup(t)
+	ctx := context.Background()
+
+	_, _, err := client.PayLink.Create(ctx, &paddle.PayLinkCreate{Title: "Custom"})
+	if paddle.ErrorCode(err) != paddle.ErrCodeMissingArguments {
+		t.Errorf("PayLink.Create without webhook_url returned %v", err)
+	}
+	_, _, err = client.PayLink.Create(ctx, &paddle.PayLinkCreate{Title: "Custom", WebhookURL: "https://example.com", Expires: "2000-01-01"})
+	if paddle.ErrorCode(err) != paddle.ErrCodeInvalidExpiration {
+		t.Errorf("PayLink.Create with a past expiry returned %v", err)
+	}
+
+	url, _, err := client.PayLink.Create(ctx, &paddle.PayLinkCreate{
+		Title:      "Custom",
+		WebhookURL: "https://example.com",
+		Prices:     "USD:9.99,EUR:8.99",
+	})
+	if err != nil {
+		t.Fatalf("PayLink.Create returned error: %v", err)
+	}
+	links := srv.PayLinks()
+	if len(links) != 1 || links[0].URL != *url || links[0].Form.Get("prices") != "USD:9.99,EUR:8.99" || links[0].Form.Get("vendor_auth_code") != "" {
+		t.Errorf("PayLinks returned %+v", links)
+	}
+
+	planID := srv.AddPlan("Pro", "month", 1, paddle.MustParseMoney("USD", "9.99"))
+	link, err := paddle.NewPayLink().
+		Plan(planID).
+		Price(paddle.MustParseMoney("USD", "1")).
+		RecurringPrice(paddle.MustParseMoney("USD", "9")).
+		Discountable(false).
+		Passthrough(map[string]int{"user_id": 42}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if _, _, err := client.PayLink.Create(ctx, link); err != nil {
+		t.Fatalf("PayLink.Create of a plan returned error: %v", err)
+	}
+	form := srv.PayLinks()[1].Form
+	if form.Get("recurring_prices") != "USD:9.00" || form.Get("discountable") != "0" || form.Get("passthrough") != `{"user_id":42}` {
+		t.Errorf("PayLinks returned form %v", form)
+	}
+}
+
+func TestServer_waitForCompletion(t *testing.T) {
+	srv, client := setup(t)
+	ctx := context.Background()
+
+	productID := srv.AddProduct("E-book", paddle.MustParseMoney("USD", "20"))
+	checkoutID, err := srv.Purchase(productID, "jane@example.com", 1)
+	if err != nil {
+		t.Fatalf("Purchase returned error: %v", err)
+	}
+	if err := srv.SetOrderState(checkoutID, paddle.OrderStateProcessing); err != nil {
+		t.Fatalf("SetOrderState returned error: %v", err)
+	}
+
+	opts := &paddle.WaitOptions{Timeout: 50 * time.Millisecond, BaseDelay: time.Millisecond}
+	var timeoutErr *paddle.OrderTimeoutError
+	if _, _, err := client.OrderDetails.WaitForCompletion(ctx, checkoutID, opts); !errors.As(err, &timeoutErr) || *timeoutErr.Details.State != paddle.OrderStateProcessing {
+		t.Errorf("WaitForCompletion of a processing order returned %v, want an *OrderTimeoutError", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		srv.DeliverLicense(checkoutID, "A1B2-C3D4")
+		srv.SetOrderState(checkoutID, paddle.OrderStateProcessed)
+	}()
+	opts.Timeout = 5 * time.Second
+	details, _, err := client.OrderDetails.WaitForCompletion(ctx, checkoutID, opts)
+	if err != nil {
+		t.Fatalf("WaitForCompletion returned error: %v", err)
+	}
+	if licenses := details.Licenses(); len(licenses) != 1 || licenses[0].LicenseCode != "A1B2-C3D4" {
+		t.Errorf("WaitForCompletion returned licenses %+v", licenses)
+	}
+
+	srv.SetOrderState(checkoutID, paddle.OrderStateError)
+	var failedErr *paddle.OrderFailedError
+	if _, _, err := client.OrderDetails.WaitForCompletion(ctx, checkoutID, opts); !errors.As(err, &failedErr) || failedErr.State != paddle.OrderStateError {
+		t.Errorf("WaitForCompletion of a failed order returned %v, want an *OrderFailedError", err)
+	}
+}
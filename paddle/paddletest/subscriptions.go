@@ -0,0 +1,1038 @@
+package paddletest
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Fakerr/go-paddle/paddle"
+)
+
+// Subscription states.
+const (
+	stateActive   = "active"
+	stateTrialing = "trialing"
+	statePastDue  = "past_due"
+	statePaused   = "paused"
+	stateDeleted  = "deleted"
+)
+
+// planCurrencies are the currencies subscription plans can be priced in.
+var planCurrencies = []string{"USD", "GBP", "EUR"}
+
+type plan struct {
+	id             int
+	name           string
+	billingType    string // day, week, month or year.
+	billingPeriod  int
+	trialDays      int
+	mainCurrency   string
+	initialPrice   paddle.MoneyByCurrency
+	recurringPrice paddle.MoneyByCurrency
+}
+
+type subscription struct {
+	id               int
+	planID           int
+	userID           int
+	email            string
+	marketingConsent bool
+	state            string
+	unitPrice        paddle.Money
+	quantity         int
+	passthrough      string
+	checkoutID       string
+	signupDate       time.Time
+	nextBillDate     time.Time
+	pausedAt         time.Time
+	pausedFrom       time.Time
+}
+
+type modifier struct {
+	id             int
+	subscriptionID int
+	amount         paddle.Money
+	recurring      bool
+	description    string
+}
+
+type payment struct {
+	id             int
+	subscriptionID int
+	amount         paddle.Money
+	payoutDate     time.Time
+	paid           bool
+	oneOff         bool
+	orderID        int
+}
+
+// addPeriod returns t moved forward by n billing periods of type billingType.
+func addPeriod(t time.Time, billingType string, n int) time.Time {
+	switch billingType {
+	case "day":
+		return t.AddDate(0, 0, n)
+	case "week":
+		return t.AddDate(0, 0, 7*n)
+	case "year":
+		return t.AddDate(n, 0, 0)
+	}
+	return t.AddDate(0, n, 0)
+}
+
+// AddPlan adds a subscription plan billed every billingPeriod days, weeks,
+// months or years, as given by billingType, and returns its ID. The
+// currency of the first price is the main currency of the plan.
+func (s *Server) AddPlan(name, billingType string, billingPeriod int, prices ...paddle.Money) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := &plan{
+		id:             s.nextID(),
+		name:           name,
+		billingType:    billingType,
+		billingPeriod:  billingPeriod,
+		initialPrice:   paddle.MoneyByCurrency{},
+		recurringPrice: paddle.MoneyByCurrency{},
+	}
+	for i, price := range prices {
+		if i == 0 {
+			p.mainCurrency = price.Currency
+		}
+		p.initialPrice[price.Currency] = price
+		p.recurringPrice[price.Currency] = price
+	}
+	s.plans[p.id] = p
+	return p.id
+}
+
+// Subscribe subscribes email to the plan with the given ID, as a checkout
+// would, and returns the ID of the subscription. The first payment is made
+// unless the plan has a trial period. The subscription_created alert, and
+// subscription_payment_succeeded for the first payment, are fired.
+func (s *Server) Subscribe(planID int, email string, quantity int) (int, error) {
+	s.mu.Lock()
+	sub, err := s.subscribe(planID, email, quantity)
+	outbox := s.takeOutbox()
+	s.mu.Unlock()
+
+	s.deliver(outbox)
+	if err != nil {
+		return 0, err
+	}
+	return sub.id, nil
+}
+
+func (s *Server) subscribe(planID int, email string, quantity int) (*subscription, error) {
+	p, ok := s.plans[planID]
+	if !ok {
+		return nil, fmt.Errorf("paddletest: no plan %d", planID)
+	}
+	if quantity < 1 {
+		return nil, fmt.Errorf("paddletest: invalid quantity %d", quantity)
+	}
+	total, err := times(p.initialPrice[p.mainCurrency], quantity)
+	if err == nil {
+		_, err = recurringTotal(p.recurringPrice[p.mainCurrency], quantity, nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("paddletest: quantity %d: %w", quantity, err)
+	}
+
+	now := s.now()
+	sub := &subscription{
+		id:         s.nextID(),
+		planID:     planID,
+		userID:     s.userID(email),
+		email:      email,
+		state:      stateActive,
+		unitPrice:  p.recurringPrice[p.mainCurrency],
+		quantity:   quantity,
+		signupDate: now,
+	}
+	o := s.newOrder(planID, email, quantity, total)
+	o.subscriptionID = sub.id
+	sub.checkoutID = o.checkoutID
+	s.subscriptions[sub.id] = sub
+
+	if p.trialDays > 0 {
+		sub.state = stateTrialing
+		sub.nextBillDate = now.AddDate(0, 0, p.trialDays)
+	} else {
+		sub.nextBillDate = addPeriod(now, p.billingType, p.billingPeriod)
+	}
+	s.schedule(sub)
+
+	s.fire("subscription_created", s.subscriptionFields(sub))
+	if p.trialDays == 0 {
+		pay := &payment{
+			id:             s.nextID(),
+			subscriptionID: sub.id,
+			amount:         o.total,
+			payoutDate:     now,
+			paid:           true,
+			orderID:        o.id,
+		}
+		s.payments[pay.id] = pay
+		s.firePaymentSucceeded(sub, pay, true)
+	}
+	return sub, nil
+}
+
+// Renew bills the next payment of the subscription with the given ID, as
+// Paddle does on its next bill date, and moves the next bill date one
+// billing period forward. One-time modifiers are consumed. The
+// subscription_payment_succeeded alert is fired.
+func (s *Server) Renew(subscriptionID int) error {
+	s.mu.Lock()
+	err := s.renew(subscriptionID)
+	outbox := s.takeOutbox()
+	s.mu.Unlock()
+
+	s.deliver(outbox)
+	return err
+}
+
+func (s *Server) renew(subscriptionID int) error {
+	sub, ok := s.subscriptions[subscriptionID]
+	if !ok || sub.state == stateDeleted || sub.state == statePaused {
+		return fmt.Errorf("paddletest: no active subscription %d", subscriptionID)
+	}
+	pay := s.scheduledPayment(sub.id)
+	if pay == nil {
+		return fmt.Errorf("paddletest: no payment scheduled for subscription %d", subscriptionID)
+	}
+
+	p := s.plans[sub.planID]
+	o := s.newOrder(sub.planID, sub.email, sub.quantity, pay.amount)
+	o.subscriptionID = sub.id
+	pay.paid, pay.orderID, pay.payoutDate = true, o.id, s.now()
+
+	for id, m := range s.modifiers {
+		if m.subscriptionID == sub.id && !m.recurring {
+			delete(s.modifiers, id)
+		}
+	}
+	sub.state = stateActive
+	sub.nextBillDate = addPeriod(sub.nextBillDate, p.billingType, p.billingPeriod)
+	s.schedule(sub)
+
+	s.firePaymentSucceeded(sub, pay, false)
+	return nil
+}
+
+// userID returns the ID of the user with the given email, creating it if
+// needed.
+func (s *Server) userID(email string) int {
+	id, ok := s.userIDs[strings.ToLower(email)]
+	if !ok {
+		id = s.nextID()
+		s.userIDs[strings.ToLower(email)] = id
+	}
+	return id
+}
+
+// recurringAmount returns the amount of the next payment of sub. The
+// endpoints changing the quantity, price or modifiers of a subscription
+// check that this amount does not overflow, so it panics if it does.
+func (s *Server) recurringAmount(sub *subscription) paddle.Money {
+	amount, err := recurringTotal(sub.unitPrice, sub.quantity, s.subscriptionModifiers(sub.id))
+	if err != nil {
+		panic(fmt.Sprintf("paddletest: amount of subscription %d: %v", sub.id, err))
+	}
+	return amount
+}
+
+// recurringTotal returns quantity times unitPrice plus the amounts of
+// modifiers, or paddle.ErrDecimalOverflow if the total is too large.
+func recurringTotal(unitPrice paddle.Money, quantity int, modifiers []*modifier) (paddle.Money, error) {
+	amount, err := times(unitPrice, quantity)
+	if err != nil {
+		return paddle.Money{}, err
+	}
+	for _, m := range modifiers {
+		if amount.Amount, err = amount.Amount.AddChecked(m.amount.Amount); err != nil {
+			return paddle.Money{}, err
+		}
+	}
+	return amount, nil
+}
+
+// subscriptionModifiers returns the modifiers of the subscription with the
+// given ID, sorted by ID.
+func (s *Server) subscriptionModifiers(subscriptionID int) []*modifier {
+	var modifiers []*modifier
+	for _, m := range s.sortedModifiers() {
+		if m.subscriptionID == subscriptionID {
+			modifiers = append(modifiers, m)
+		}
+	}
+	return modifiers
+}
+
+// scheduledPayment returns the next unpaid payment of the subscription
+// with the given ID, or nil.
+func (s *Server) scheduledPayment(subscriptionID int) *payment {
+	for _, pay := range s.payments {
+		if pay.subscriptionID == subscriptionID && !pay.paid && !pay.oneOff {
+			return pay
+		}
+	}
+	return nil
+}
+
+// schedule updates the next payment of sub after a change, removing it if
+// sub is paused or cancelled.
+func (s *Server) schedule(sub *subscription) {
+	pay := s.scheduledPayment(sub.id)
+	if sub.state == statePaused || sub.state == stateDeleted {
+		if pay != nil {
+			delete(s.payments, pay.id)
+		}
+		return
+	}
+	if pay == nil {
+		pay = &payment{id: s.nextID(), subscriptionID: sub.id}
+		s.payments[pay.id] = pay
+	}
+	pay.amount = s.recurringAmount(sub)
+	pay.payoutDate = sub.nextBillDate
+}
+
+// subscriptionFields returns the alert fields describing sub.
+func (s *Server) subscriptionFields(sub *subscription) url.Values {
+	fields := url.Values{}
+	fields.Set("subscription_id", strconv.Itoa(sub.id))
+	fields.Set("subscription_plan_id", strconv.Itoa(sub.planID))
+	fields.Set("user_id", strconv.Itoa(sub.userID))
+	fields.Set("email", sub.email)
+	fields.Set("marketing_consent", boolField(sub.marketingConsent))
+	fields.Set("checkout_id", sub.checkoutID)
+	fields.Set("currency", sub.unitPrice.Currency)
+	fields.Set("quantity", strconv.Itoa(sub.quantity))
+	fields.Set("unit_price", formatMoney(sub.unitPrice))
+	fields.Set("status", sub.state)
+	fields.Set("passthrough", sub.passthrough)
+	if sub.state != stateDeleted {
+		fields.Set("update_url", s.updateURL(sub))
+		fields.Set("cancel_url", s.cancelURL(sub))
+	}
+	if !sub.nextBillDate.IsZero() && sub.state != statePaused && sub.state != stateDeleted {
+		fields.Set("next_bill_date", sub.nextBillDate.Format(dateLayout))
+	}
+	return fields
+}
+
+// firePaymentSucceeded fires the subscription_payment_succeeded alert of pay.
+func (s *Server) firePaymentSucceeded(sub *subscription, pay *payment, initial bool) {
+	p := s.plans[sub.planID]
+	o := s.orders[pay.orderID]
+	gross := formatMoney(pay.amount)
+
+	fields := s.subscriptionFields(sub)
+	fields.Set("subscription_payment_id", strconv.Itoa(pay.id))
+	fields.Set("order_id", orderID(o))
+	fields.Set("plan_name", p.name)
+	fields.Set("initial_payment", boolField(initial))
+	fields.Set("instalments", "1")
+	fields.Set("payment_method", "card")
+	fields.Set("country", "US")
+	fields.Set("customer_name", sub.email)
+	fields.Set("receipt_url", s.receiptURL(o))
+	fields.Set("sale_gross", gross)
+	fields.Set("payment_tax", "0.00")
+	fields.Set("fee", "0.00")
+	fields.Set("earnings", gross)
+	fields.Set("balance_currency", pay.amount.Currency)
+	fields.Set("balance_gross", gross)
+	fields.Set("balance_tax", "0.00")
+	fields.Set("balance_fee", "0.00")
+	fields.Set("balance_earnings", gross)
+	if next := s.scheduledPayment(sub.id); next != nil {
+		fields.Set("next_payment_amount", formatMoney(next.amount))
+	}
+	s.fire("subscription_payment_succeeded", fields)
+}
+
+func (s *Server) updateURL(sub *subscription) string {
+	return fmt.Sprintf("%s/subscription/%d/update", s.URL, sub.id)
+}
+
+func (s *Server) cancelURL(sub *subscription) string {
+	return fmt.Sprintf("%s/subscription/%d/cancel", s.URL, sub.id)
+}
+
+// flag returns 1 if b is true and 0 otherwise.
+func flag(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// boolField formats b as an alert field.
+func boolField(b bool) string {
+	return strconv.Itoa(flag(b))
+}
+
+// listPlans serves Plans.List.
+func (s *Server) listPlans(form url.Values) (interface{}, *apiError) {
+	planID, filter, apiErr := formInt(form, "plan")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	ids := make([]int, 0, len(s.plans))
+	for id := range s.plans {
+		if !filter || id == planID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	plans := make([]*paddle.Plan, len(ids))
+	for i, id := range ids {
+		p := s.plans[id]
+		plans[i] = &paddle.Plan{
+			ID:             paddle.Int(p.id),
+			Name:           paddle.String(p.name),
+			BillingType:    paddle.String(p.billingType),
+			BillingPeriod:  paddle.Int(p.billingPeriod),
+			TrialDays:      paddle.Int(p.trialDays),
+			InitialPrice:   p.initialPrice,
+			RecurringPrice: p.recurringPrice,
+		}
+	}
+	return plans, nil
+}
+
+// createPlan serves Plans.Create.
+func (s *Server) createPlan(form url.Values) (interface{}, *apiError) {
+	name := form.Get("plan_name")
+	if name == "" {
+		return nil, errMissingArgument("plan_name")
+	}
+	billingType := form.Get("plan_type")
+	switch billingType {
+	case "":
+		return nil, errMissingArgument("plan_type")
+	case "day", "week", "month", "year":
+	default:
+		return nil, errInvalidArgument("plan_type")
+	}
+	length, apiErr := requiredInt(form, "plan_length")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if length < 1 {
+		return nil, errInvalidArgument("plan_length")
+	}
+	trialDays, _, apiErr := formInt(form, "plan_trial_days")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if trialDays < 0 {
+		return nil, errInvalidArgument("plan_trial_days")
+	}
+
+	mainCurrency := strings.ToUpper(form.Get("main_currency_code"))
+	if mainCurrency == "" {
+		mainCurrency = "USD"
+	}
+	p := &plan{
+		name:           name,
+		billingType:    billingType,
+		billingPeriod:  length,
+		trialDays:      trialDays,
+		mainCurrency:   mainCurrency,
+		initialPrice:   paddle.MoneyByCurrency{},
+		recurringPrice: paddle.MoneyByCurrency{},
+	}
+	valid := false
+	for _, currency := range planCurrencies {
+		if currency == mainCurrency {
+			valid = true
+		}
+		field := "recurring_price_" + strings.ToLower(currency)
+		price, ok, apiErr := formMoney(form, field, currency)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if !ok {
+			continue
+		}
+		if price.Amount.Sign() < 0 {
+			return nil, errorf(paddle.ErrCodePriceTooLow, "Price is too low: %s", field)
+		}
+		p.recurringPrice[currency] = price
+		p.initialPrice[currency] = price
+		if trialDays > 0 {
+			p.initialPrice[currency] = paddle.Money{Currency: currency}
+		}
+	}
+	if !valid {
+		return nil, errorf(paddle.ErrCodeInvalidCurrency, "Provided currency is not valid: %q", mainCurrency)
+	}
+	if _, ok := p.recurringPrice[mainCurrency]; !ok {
+		return nil, errMissingArgument("recurring_price_" + strings.ToLower(mainCurrency))
+	}
+
+	p.id = s.nextID()
+	s.plans[p.id] = p
+	return &paddle.Product{ProductID: paddle.Int(p.id)}, nil
+}
+
+// findSubscription returns the subscription whose ID is the argument
+// subscription_id of form, failing if it does not exist or is cancelled.
+func (s *Server) findSubscription(form url.Values) (*subscription, *apiError) {
+	id, apiErr := requiredInt(form, "subscription_id")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	sub, ok := s.subscriptions[id]
+	if !ok || sub.state == stateDeleted {
+		return nil, errorf(paddle.ErrCodeSubscriptionNotFound, "Unable to find requested subscription")
+	}
+	return sub, nil
+}
+
+// userResponse describes sub the way Users.List does.
+func (s *Server) userResponse(sub *subscription) *paddle.User {
+	u := &paddle.User{
+		SubscriptionID:   paddle.Int(sub.id),
+		PlanID:           paddle.Int(sub.planID),
+		UserID:           paddle.Int(sub.userID),
+		UserEmail:        paddle.String(sub.email),
+		MarketingConsent: paddle.Bool(sub.marketingConsent),
+		State:            paddle.String(sub.state),
+		SignupDate:       paddle.String(sub.signupDate.Format(timeLayout)),
+		PaymentInformation: &paddle.PaymentInformation{
+			PaymentMethod:  paddle.String("card"),
+			CardType:       paddle.String("visa"),
+			LastFourDigits: paddle.String("4242"),
+			ExpiryDate:     paddle.String("12/2030"),
+		},
+	}
+	if sub.state != stateDeleted {
+		u.UpdateURL = paddle.String(s.updateURL(sub))
+		u.CancelURL = paddle.String(s.cancelURL(sub))
+	}
+	if sub.state == statePaused {
+		u.PausedAt = paddle.String(sub.pausedAt.Format(timeLayout))
+		u.PausedFrom = paddle.String(sub.pausedFrom.Format(timeLayout))
+	}
+
+	var last *payment
+	for _, pay := range s.payments {
+		if pay.subscriptionID == sub.id && pay.paid && !pay.oneOff &&
+			(last == nil || pay.payoutDate.After(last.payoutDate) || pay.payoutDate.Equal(last.payoutDate) && pay.id > last.id) {
+			last = pay
+		}
+	}
+	if last != nil {
+		u.LastPayment = userPayment(last)
+	}
+	if next := s.scheduledPayment(sub.id); next != nil {
+		u.NextPayment = userPayment(next)
+	}
+	return u
+}
+
+func userPayment(pay *payment) *paddle.UserPayment {
+	amount := pay.amount
+	return &paddle.UserPayment{
+		Amount:   &amount,
+		Currency: paddle.String(pay.amount.Currency),
+		Date:     paddle.String(pay.payoutDate.Format(dateLayout)),
+	}
+}
+
+// listUsers serves Users.List. Cancelled subscriptions are only listed
+// when filtering on the deleted state.
+func (s *Server) listUsers(form url.Values) (interface{}, *apiError) {
+	subscriptionID, bySubscription, apiErr := formInt(form, "subscription_id")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	planID, byPlan, apiErr := formInt(form, "plan_id")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	state := form.Get("state")
+	switch state {
+	case "", stateActive, stateTrialing, statePastDue, statePaused, stateDeleted:
+	default:
+		return nil, errInvalidArgument("state")
+	}
+
+	ids := make([]int, 0, len(s.subscriptions))
+	for id, sub := range s.subscriptions {
+		if bySubscription && id != subscriptionID || byPlan && sub.planID != planID {
+			continue
+		}
+		if state == "" && sub.state == stateDeleted || state != "" && sub.state != state {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	start, end, apiErr := paginate(form, len(ids), 200)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	users := make([]*paddle.User, 0, end-start)
+	for _, id := range ids[start:end] {
+		users = append(users, s.userResponse(s.subscriptions[id]))
+	}
+	return users, nil
+}
+
+// updateUser serves Users.Update. Paused subscriptions can only be resumed.
+func (s *Server) updateUser(form url.Values) (interface{}, *apiError) {
+	sub, apiErr := s.findSubscription(form)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	quantity, setQuantity, apiErr := formInt(form, "quantity")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if setQuantity && quantity < 1 {
+		return nil, errInvalidArgument("quantity")
+	}
+	planID, setPlan, apiErr := formInt(form, "plan_id")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	pause, setPause, apiErr := formBool(form, "pause")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	billImmediately, _, apiErr := formBool(form, "bill_immediately")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	keepModifiers, _, apiErr := formBool(form, "keep_modifiers")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	currency := strings.ToUpper(form.Get("currency"))
+	price, setPrice, apiErr := formMoney(form, "recurring_price", currency)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if setPrice {
+		if currency == "" {
+			return nil, errMissingArgument("currency")
+		}
+		if currency != sub.unitPrice.Currency {
+			return nil, errorf(paddle.ErrCodeInvalidCurrency, "Provided currency is not valid: %q", currency)
+		}
+		if price.Amount.Sign() <= 0 {
+			return nil, errorf(paddle.ErrCodePriceTooLow, "Price is too low")
+		}
+	}
+
+	changes := setQuantity || setPlan || setPrice || billImmediately || form.Get("passthrough") != ""
+	if sub.state == statePaused && (changes || setPause && pause) {
+		return nil, errorf(paddle.ErrCodeBadMethodCall, "Paused subscriptions can only be resumed")
+	}
+	if setPause && !pause && sub.state != statePaused {
+		return nil, errorf(paddle.ErrCodeBadMethodCall, "The subscription is not paused")
+	}
+
+	var newPlan *plan
+	if setPlan {
+		var ok bool
+		if newPlan, ok = s.plans[planID]; !ok {
+			return nil, errorf(paddle.ErrCodeProductNotFound, "Unable to find requested product")
+		}
+		if _, ok := newPlan.recurringPrice[sub.unitPrice.Currency]; !ok && !setPrice {
+			return nil, errorf(paddle.ErrCodeInvalidCurrency, "The plan has no price in %s", sub.unitPrice.Currency)
+		}
+	}
+
+	unitPrice, modifiers := sub.unitPrice, s.subscriptionModifiers(sub.id)
+	if newPlan != nil && newPlan.id != sub.planID {
+		unitPrice = newPlan.recurringPrice[sub.unitPrice.Currency]
+		if !keepModifiers {
+			modifiers = nil
+		}
+	}
+	if setPrice {
+		unitPrice = price
+	}
+	newQuantity := sub.quantity
+	if setQuantity {
+		newQuantity = quantity
+	}
+	if _, err := recurringTotal(unitPrice, newQuantity, modifiers); err != nil {
+		if setPrice {
+			return nil, errInvalidArgument("recurring_price")
+		}
+		return nil, errInvalidArgument("quantity")
+	}
+
+	old := *sub
+	oldFields := s.subscriptionFields(sub)
+	oldAmount := s.recurringAmount(sub)
+	now := s.now()
+
+	if setQuantity {
+		sub.quantity = quantity
+	}
+	if newPlan != nil && newPlan.id != sub.planID {
+		sub.planID = newPlan.id
+		sub.unitPrice = newPlan.recurringPrice[sub.unitPrice.Currency]
+		if !keepModifiers {
+			for id, m := range s.modifiers {
+				if m.subscriptionID == sub.id {
+					delete(s.modifiers, id)
+				}
+			}
+		}
+	}
+	if setPrice {
+		sub.unitPrice = price
+	}
+	if passthrough := form.Get("passthrough"); passthrough != "" {
+		sub.passthrough = passthrough
+	}
+	if setPause {
+		if pause {
+			sub.state = statePaused
+			sub.pausedAt = now
+			sub.pausedFrom = sub.nextBillDate
+		} else {
+			sub.state = stateActive
+			sub.pausedAt, sub.pausedFrom = time.Time{}, time.Time{}
+			if sub.nextBillDate.Before(now) {
+				sub.nextBillDate = now
+			}
+		}
+	}
+
+	var billed *payment
+	if billImmediately {
+		o := s.newOrder(sub.planID, sub.email, sub.quantity, s.recurringAmount(sub))
+		o.subscriptionID = sub.id
+		billed = &payment{
+			id:             s.nextID(),
+			subscriptionID: sub.id,
+			amount:         o.total,
+			payoutDate:     now,
+			paid:           true,
+			orderID:        o.id,
+		}
+		s.payments[billed.id] = billed
+		p := s.plans[sub.planID]
+		sub.nextBillDate = addPeriod(now, p.billingType, p.billingPeriod)
+	}
+	s.schedule(sub)
+
+	fields := s.subscriptionFields(sub)
+	fields.Set("old_status", old.state)
+	fields.Set("old_subscription_plan_id", strconv.Itoa(old.planID))
+	fields.Set("old_quantity", oldFields.Get("quantity"))
+	fields.Set("new_quantity", fields.Get("quantity"))
+	fields.Set("old_unit_price", oldFields.Get("unit_price"))
+	fields.Set("new_unit_price", fields.Get("unit_price"))
+	fields.Set("old_price", formatMoney(oldAmount))
+	fields.Set("new_price", formatMoney(s.recurringAmount(sub)))
+	fields.Set("old_next_bill_date", old.nextBillDate.Format(dateLayout))
+	if sub.state == statePaused {
+		fields.Set("paused_at", sub.pausedAt.Format(timeLayout))
+		fields.Set("paused_from", sub.pausedFrom.Format(timeLayout))
+		fields.Set("paused_reason", "voluntary")
+	}
+	s.fire("subscription_updated", fields)
+	if billed != nil {
+		s.firePaymentSucceeded(sub, billed, false)
+	}
+
+	u := &paddle.User{
+		SubscriptionID: paddle.Int(sub.id),
+		UserID:         paddle.Int(sub.userID),
+		PlanID:         paddle.Int(sub.planID),
+	}
+	if next := s.scheduledPayment(sub.id); next != nil {
+		u.NextPayment = userPayment(next)
+	}
+	return u, nil
+}
+
+// cancelUser serves Users.Cancel.
+func (s *Server) cancelUser(form url.Values) (interface{}, *apiError) {
+	sub, apiErr := s.findSubscription(form)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	sub.state = stateDeleted
+	s.schedule(sub)
+
+	fields := s.subscriptionFields(sub)
+	fields.Set("cancellation_effective_date", s.now().Format(dateLayout))
+	s.fire("subscription_cancelled", fields)
+	return nil, nil
+}
+
+// sortedModifiers returns the modifiers ordered by ID.
+func (s *Server) sortedModifiers() []*modifier {
+	modifiers := make([]*modifier, 0, len(s.modifiers))
+	for _, m := range s.modifiers {
+		modifiers = append(modifiers, m)
+	}
+	sort.Slice(modifiers, func(i, j int) bool { return modifiers[i].id < modifiers[j].id })
+	return modifiers
+}
+
+// listModifiers serves Modifiers.List.
+func (s *Server) listModifiers(form url.Values) (interface{}, *apiError) {
+	subscriptionID, bySubscription, apiErr := formInt(form, "subscription_id")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	planID, byPlan, apiErr := formInt(form, "plan_id")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	modifiers := []*paddle.Modifier{}
+	for _, m := range s.sortedModifiers() {
+		if bySubscription && m.subscriptionID != subscriptionID ||
+			byPlan && s.subscriptions[m.subscriptionID].planID != planID {
+			continue
+		}
+		amount := m.amount
+		modifiers = append(modifiers, &paddle.Modifier{
+			ModifierID:     paddle.Int(m.id),
+			SubscriptionID: paddle.Int(m.subscriptionID),
+			Amount:         &amount,
+			Currency:       paddle.String(m.amount.Currency),
+			IsRecurring:    paddle.Bool(m.recurring),
+			Description:    paddle.String(m.description),
+		})
+	}
+	return modifiers, nil
+}
+
+// createModifier serves Modifiers.Create. Modifiers are recurring unless
+// modifier_recurring is false, as with Paddle.
+func (s *Server) createModifier(form url.Values) (interface{}, *apiError) {
+	sub, apiErr := s.findSubscription(form)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	amount, ok, apiErr := formMoney(form, "modifier_amount", sub.unitPrice.Currency)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if !ok {
+		return nil, errMissingArgument("modifier_amount")
+	}
+	if amount.Amount.IsZero() {
+		return nil, errInvalidArgument("modifier_amount")
+	}
+	recurring, set, apiErr := formBool(form, "modifier_recurring")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if _, err := recurringTotal(sub.unitPrice, sub.quantity, append(s.subscriptionModifiers(sub.id), &modifier{amount: amount})); err != nil {
+		return nil, errInvalidArgument("modifier_amount")
+	}
+
+	m := &modifier{
+		id:             s.nextID(),
+		subscriptionID: sub.id,
+		amount:         amount,
+		recurring:      recurring || !set,
+		description:    form.Get("modifier_description"),
+	}
+	s.modifiers[m.id] = m
+	s.schedule(sub)
+
+	return &paddle.Modifier{
+		SubscriptionID: paddle.Int(sub.id),
+		ModifierID:     paddle.Int(m.id),
+	}, nil
+}
+
+// deleteModifier serves Modifiers.Delete.
+func (s *Server) deleteModifier(form url.Values) (interface{}, *apiError) {
+	id, apiErr := requiredInt(form, "modifier_id")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	m, ok := s.modifiers[id]
+	if !ok {
+		return nil, errorf(paddle.ErrCodeModifierNotFound, "Unable to find requested modifier")
+	}
+	delete(s.modifiers, id)
+	s.schedule(s.subscriptions[m.subscriptionID])
+	return nil, nil
+}
+
+// listPayments serves Payments.List, ordered by payout date.
+func (s *Server) listPayments(form url.Values) (interface{}, *apiError) {
+	subscriptionID, bySubscription, apiErr := formInt(form, "subscription_id")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	planID, byPlan, apiErr := formInt(form, "plan")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	isPaid, byPaid, apiErr := formBool(form, "is_paid")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	oneOff, byOneOff, apiErr := formBool(form, "is_one_off_charge")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	from, byFrom, apiErr := formDate(form, "from")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	to, byTo, apiErr := formDate(form, "to")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	var selected []*payment
+	for _, pay := range s.payments {
+		day := pay.payoutDate.Truncate(24 * time.Hour)
+		if bySubscription && pay.subscriptionID != subscriptionID ||
+			byPlan && s.subscriptions[pay.subscriptionID].planID != planID ||
+			byPaid && pay.paid != isPaid ||
+			byOneOff && pay.oneOff != oneOff ||
+			byFrom && day.Before(from) ||
+			byTo && day.After(to) {
+			continue
+		}
+		selected = append(selected, pay)
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		if !selected[i].payoutDate.Equal(selected[j].payoutDate) {
+			return selected[i].payoutDate.Before(selected[j].payoutDate)
+		}
+		return selected[i].id < selected[j].id
+	})
+
+	start, end, apiErr := paginate(form, len(selected), len(selected)+1)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	payments := make([]*paddle.Payment, 0, end-start)
+	for _, pay := range selected[start:end] {
+		amount := pay.amount
+		p := &paddle.Payment{
+			ID:             paddle.Int(pay.id),
+			SubscriptionID: paddle.Int(pay.subscriptionID),
+			Amount:         &amount,
+			Currency:       paddle.String(pay.amount.Currency),
+			PayoutDate:     paddle.String(pay.payoutDate.Format(dateLayout)),
+			IsPaid:         paddle.Int(flag(pay.paid)),
+			IsOneOffCharge: paddle.Int(flag(pay.oneOff)),
+		}
+		if pay.paid {
+			p.ReceiptUrl = paddle.String(s.receiptURL(s.orders[pay.orderID]))
+		}
+		payments = append(payments, p)
+	}
+	return payments, nil
+}
+
+// reschedulePayment serves Payments.Update. Only the scheduled payments of
+// active subscriptions can be moved, to a date that is not in the past.
+func (s *Server) reschedulePayment(form url.Values) (interface{}, *apiError) {
+	id, apiErr := requiredInt(form, "payment_id")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	date, ok, apiErr := formDate(form, "date")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if !ok {
+		return nil, errMissingArgument("date")
+	}
+	pay, found := s.payments[id]
+	if !found || pay.paid || pay.oneOff {
+		return nil, errorf(paddle.ErrCodePaymentNotFound, "Unable to find requested payment")
+	}
+	if date.Before(s.now().Truncate(24 * time.Hour)) {
+		return nil, errorf(paddle.ErrCodeInvalidDate, "Provided date is not valid: date")
+	}
+
+	sub := s.subscriptions[pay.subscriptionID]
+	oldNextBillDate := sub.nextBillDate
+	sub.nextBillDate = date
+	s.schedule(sub)
+
+	fields := s.subscriptionFields(sub)
+	fields.Set("old_status", sub.state)
+	fields.Set("old_subscription_plan_id", strconv.Itoa(sub.planID))
+	fields.Set("old_quantity", fields.Get("quantity"))
+	fields.Set("new_quantity", fields.Get("quantity"))
+	fields.Set("old_unit_price", fields.Get("unit_price"))
+	fields.Set("new_unit_price", fields.Get("unit_price"))
+	fields.Set("old_price", formatMoney(pay.amount))
+	fields.Set("new_price", formatMoney(pay.amount))
+	fields.Set("old_next_bill_date", oldNextBillDate.Format(dateLayout))
+	s.fire("subscription_updated", fields)
+	return nil, nil
+}
+
+// createOneOffCharge serves OneOffCharges.Create.
+func (s *Server) createOneOffCharge(form url.Values) (interface{}, *apiError) {
+	sub, apiErr := s.findSubscription(form)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	amount, ok, apiErr := formMoney(form, "amount", sub.unitPrice.Currency)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if !ok {
+		return nil, errMissingArgument("amount")
+	}
+	if amount.Amount.Sign() <= 0 {
+		return nil, errorf(paddle.ErrCodePriceTooLow, "Price is too low")
+	}
+	chargeName := form.Get("charge_name")
+	if chargeName == "" {
+		return nil, errMissingArgument("charge_name")
+	}
+
+	o := s.newOrder(sub.planID, sub.email, 1, amount)
+	o.subscriptionID = sub.id
+	o.title = chargeName
+	pay := &payment{
+		id:             s.nextID(),
+		subscriptionID: sub.id,
+		amount:         amount,
+		payoutDate:     s.now(),
+		paid:           true,
+		oneOff:         true,
+		orderID:        o.id,
+	}
+	s.payments[pay.id] = pay
+	s.firePaymentSucceeded(sub, pay, false)
+
+	return &paddle.OneOffCharge{
+		InvoiceID:      paddle.Int(pay.id),
+		SubscriptionID: paddle.Int(sub.id),
+		Amount:         &amount,
+		Currency:       paddle.String(amount.Currency),
+		PaymentDate:    paddle.String(pay.payoutDate.Format(dateLayout)),
+		ReceiptUrl:     paddle.String(s.receiptURL(o)),
+		OrderID:        paddle.String(orderID(o)),
+		Status:         paddle.String("success"),
+	}, nil
+}
@@ -0,0 +1,103 @@
+package paddletest
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Fakerr/go-paddle/paddle"
+)
+
+// transactionsPerPage is the size of the pages of transactions.
+const transactionsPerPage = 15
+
+// listTransactions serves Transactions.List, listing the orders of the
+// entity with the given id, newest first.
+func (s *Server) listTransactions(entity, id string, form url.Values) (interface{}, *apiError) {
+	var match func(o *order) bool
+	switch entity {
+	case "order":
+		match = func(o *order) bool { return orderID(o) == id || strconv.Itoa(o.id) == id }
+	case "checkout":
+		match = func(o *order) bool { return o.checkoutID == id }
+	default:
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, errInvalidArgument(entity + "_id")
+		}
+		match = map[string]func(o *order) bool{
+			"user":         func(o *order) bool { return s.userIDs[strings.ToLower(o.email)] == n },
+			"subscription": func(o *order) bool { return o.subscriptionID != 0 && o.subscriptionID == n },
+			"product":      func(o *order) bool { return o.productID == n },
+		}[entity]
+	}
+
+	var orders []*order
+	for _, o := range s.orders {
+		if match(o) {
+			orders = append(orders, o)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].id > orders[j].id })
+
+	start, end, apiErr := paginate(form, len(orders), transactionsPerPage)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	transactions := make([]*paddle.Transaction, 0, end-start)
+	for _, o := range orders[start:end] {
+		transactions = append(transactions, s.transaction(o))
+	}
+	return transactions, nil
+}
+
+// transaction returns the transaction of o.
+func (s *Server) transaction(o *order) *paddle.Transaction {
+	total := o.total
+	t := &paddle.Transaction{
+		OrderID:        paddle.String(orderID(o)),
+		CheckoutID:     paddle.String(o.checkoutID),
+		Amount:         &total,
+		Currency:       paddle.String(total.Currency),
+		Status:         paddle.String(orderStatus(o)),
+		CreatedAt:      paddle.String(o.completedAt.Format(timeLayout)),
+		ProductID:      paddle.Int(o.productID),
+		IsSubscription: paddle.Bool(o.subscriptionID != 0),
+		IsOneOff:       paddle.Bool(false),
+		User: &paddle.TransactionUser{
+			UserID:           paddle.Int(s.userID(o.email)),
+			Email:            paddle.String(o.email),
+			MarketingConsent: paddle.Bool(false),
+		},
+		ReceiptURL: paddle.String(s.receiptURL(o)),
+	}
+	for _, pay := range s.payments {
+		if pay.orderID == o.id && pay.oneOff {
+			t.IsOneOff = paddle.Bool(true)
+		}
+	}
+	if sub, ok := s.subscriptions[o.subscriptionID]; ok {
+		t.Subscription = &paddle.TransactionSubscription{
+			SubscriptionID: paddle.Int(sub.id),
+			Status:         paddle.String(sub.state),
+		}
+		t.User.MarketingConsent = paddle.Bool(sub.marketingConsent)
+		if sub.passthrough != "" {
+			t.Passthrough = paddle.String(sub.passthrough)
+		}
+	}
+	return t
+}
+
+// orderStatus returns the status of o: completed, partially_refunded or
+// refunded.
+func orderStatus(o *order) string {
+	switch {
+	case o.refunded.IsZero():
+		return "completed"
+	case o.refunded.Cmp(o.total.Amount) >= 0:
+		return "refunded"
+	}
+	return "partially_refunded"
+}
@@ -0,0 +1,217 @@
+package paddletest
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Fakerr/go-paddle/paddle"
+)
+
+// Delivery statuses of an Alert.
+const (
+	AlertPending = "pending" // Not sent, as no WebhookURL is set.
+	AlertSuccess = "success" // Acknowledged with a 2xx status code.
+	AlertFailed  = "failed"  // Not acknowledged.
+)
+
+// Alert is an alert fired by a Server.
+type Alert struct {
+	ID        int
+	Name      string
+	Fields    url.Values // Fields sent, p_signature excepted.
+	CreatedAt time.Time
+	Status    string
+	Attempts  int
+}
+
+// Alerts returns the alerts fired so far, oldest first.
+func (s *Server) Alerts() []Alert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alerts := make([]Alert, len(s.alerts))
+	for i, a := range s.alerts {
+		alerts[i] = *a
+		alerts[i].Fields = copyValues(a.Fields)
+	}
+	return alerts
+}
+
+// fire records the alert name carrying fields and queues it for delivery.
+// The alert_name, alert_id and event_time fields are set by fire.
+func (s *Server) fire(name string, fields url.Values) {
+	a := &Alert{
+		ID:        s.nextID(),
+		Name:      name,
+		Fields:    fields,
+		CreatedAt: s.now(),
+		Status:    AlertPending,
+	}
+	fields.Set("alert_name", name)
+	fields.Set("alert_id", strconv.Itoa(a.ID))
+	fields.Set("event_time", a.CreatedAt.Format(timeLayout))
+
+	s.alerts = append(s.alerts, a)
+	s.outbox = append(s.outbox, a)
+}
+
+// takeOutbox returns the alerts waiting to be delivered and empties the
+// queue. It is called with the lock held.
+func (s *Server) takeOutbox() []*Alert {
+	outbox := s.outbox
+	s.outbox = nil
+	return outbox
+}
+
+// deliver posts alerts to the WebhookURL, one after the other. It is called
+// without the lock held, so that the receiver can call the server back.
+func (s *Server) deliver(alerts []*Alert) {
+	if s.WebhookURL == "" || len(alerts) == 0 {
+		return
+	}
+	client := s.WebhookClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	for _, a := range alerts {
+		status := AlertFailed
+		if key, err := s.WebhookKey(); err == nil {
+			if r, err := paddle.NewWebhookRequest(s.WebhookURL, a.Fields, key); err == nil {
+				if resp, err := client.Do(r); err == nil {
+					resp.Body.Close()
+					if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+						status = AlertSuccess
+					}
+				}
+			}
+		}
+
+		s.mu.Lock()
+		a.Status = status
+		a.Attempts++
+		s.mu.Unlock()
+	}
+}
+
+// Redeliver sends the alert with the given ID again, as Paddle does when an
+// alert is not acknowledged, and returns its new status.
+func (s *Server) Redeliver(alertID int) (string, bool) {
+	s.mu.Lock()
+	var alert *Alert
+	for _, a := range s.alerts {
+		if a.ID == alertID {
+			alert = a
+		}
+	}
+	s.mu.Unlock()
+	if alert == nil {
+		return "", false
+	}
+
+	s.deliver([]*Alert{alert})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return alert.Status, true
+}
+
+// alertsPerPage is the default page size of the alert history.
+const alertsPerPage = 10
+
+// listAlerts serves the history of the alerts, newest first.
+func (s *Server) listAlerts(form url.Values) (interface{}, *apiError) {
+	perPage, ok, apiErr := formInt(form, "alerts_per_page")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if !ok {
+		perPage = alertsPerPage
+	}
+	if perPage < 1 || perPage > 200 {
+		return nil, errInvalidArgument("alerts_per_page")
+	}
+
+	var alerts []*Alert
+	for i := len(s.alerts) - 1; i >= 0; i-- {
+		a := s.alerts[i]
+		created := a.CreatedAt.Format(timeLayout)
+		if head := form.Get("query_head"); head != "" && created < head {
+			continue
+		}
+		if tail := form.Get("query_tail"); tail != "" && created > tail {
+			continue
+		}
+		alerts = append(alerts, a)
+	}
+
+	page, ok, apiErr := formInt(form, "page")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if !ok {
+		page = 1
+	}
+	if page < 1 {
+		return nil, errInvalidArgument("page")
+	}
+	start, end := (page-1)*perPage, page*perPage
+	if start > len(alerts) {
+		start = len(alerts)
+	}
+	if end > len(alerts) {
+		end = len(alerts)
+	}
+
+	data := make([]*paddle.EventData, 0, end-start)
+	for _, a := range alerts[start:end] {
+		created := a.CreatedAt.Format(timeLayout)
+		fields := &paddle.EventField{}
+		if orderID, err := strconv.Atoi(a.Fields.Get("order_id")); err == nil {
+			fields.OrderID = paddle.Int(orderID)
+		}
+		if amount := a.Fields.Get("sale_gross"); amount != "" {
+			fields.Amount = paddle.String(amount)
+		} else if amount := a.Fields.Get("amount"); amount != "" {
+			fields.Amount = paddle.String(amount)
+		}
+		if currency := a.Fields.Get("currency"); currency != "" {
+			fields.Currency = paddle.String(currency)
+		}
+		if email := a.Fields.Get("email"); email != "" {
+			fields.Email = paddle.String(email)
+		}
+		if consent, err := strconv.Atoi(a.Fields.Get("marketing_consent")); err == nil {
+			fields.MarketingConsent = paddle.Int(consent)
+		}
+		data = append(data, &paddle.EventData{
+			ID:        paddle.Int(a.ID),
+			AlertName: paddle.String(a.Name),
+			Status:    paddle.String(a.Status),
+			CreatedAt: paddle.String(created),
+			UpdatedAt: paddle.String(created),
+			Attempts:  paddle.Int(a.Attempts),
+			Fields:    fields,
+		})
+	}
+
+	totalPages := (len(alerts) + perPage - 1) / perPage
+	return &paddle.WebhookEvent{
+		CurrentPage:   paddle.Int(page),
+		TotalPages:    paddle.Int(totalPages),
+		AlertsPerPage: paddle.Int(perPage),
+		TotalAlerts:   paddle.Int(len(alerts)),
+		QueryHead:     paddle.String(form.Get("query_head")),
+		Data:          data,
+	}, nil
+}
+
+func copyValues(values url.Values) url.Values {
+	c := make(url.Values, len(values))
+	for k, v := range values {
+		c[k] = append([]string(nil), v...)
+	}
+	return c
+}
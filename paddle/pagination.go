@@ -0,0 +1,113 @@
+package paddle
+
+import (
+	"context"
+)
+
+// maxResultsPerPage is the largest page size accepted by the paginated endpoints.
+const maxResultsPerPage = 200
+
+// pageFunc fetches the given page and reports whether more pages follow it.
+type pageFunc func(ctx context.Context, page int) (items []interface{}, more bool, err error)
+
+type pageResult struct {
+	items []interface{}
+	more  bool
+	err   error
+}
+
+// pager walks through the pages returned by fetch, one item at a time.
+// It is shared by the typed iterators of the services.
+type pager struct {
+	ctx      context.Context
+	fetch    pageFunc
+	prefetch bool
+
+	page    int              // Next page to fetch.
+	pending chan *pageResult // Page being prefetched, if any.
+	items   []interface{}
+	index   int
+	more    bool
+	err     error
+}
+
+func newPager(ctx context.Context, firstPage int, fetch pageFunc) *pager {
+	if firstPage < 1 {
+		firstPage = 1
+	}
+	return &pager{ctx: ctx, fetch: fetch, page: firstPage, index: -1, more: true}
+}
+
+// next advances to the next item, fetching the next page when needed.
+func (p *pager) next() bool {
+	if p.err != nil {
+		return false
+	}
+
+	p.index++
+	for p.index >= len(p.items) {
+		if !p.more {
+			return false
+		}
+		result := p.nextPage()
+		if result.err != nil {
+			p.err = result.err
+			return false
+		}
+		p.items, p.more, p.index = result.items, result.more && len(result.items) > 0, 0
+	}
+	return true
+}
+
+// nextPage returns the next page, either prefetched in the background or
+// fetched right away.
+func (p *pager) nextPage() *pageResult {
+	var result *pageResult
+	if p.pending != nil {
+		result = <-p.pending
+		p.pending = nil
+	} else {
+		result = p.fetchPage(p.page)
+	}
+	p.page++
+
+	if p.prefetch && result.err == nil && result.more && len(result.items) > 0 {
+		p.pending = make(chan *pageResult, 1)
+		go func(page int, pending chan<- *pageResult) {
+			pending <- p.fetchPage(page)
+		}(p.page, p.pending)
+	}
+	return result
+}
+
+func (p *pager) fetchPage(page int) *pageResult {
+	items, more, err := p.fetch(p.ctx, page)
+	return &pageResult{items: items, more: more, err: err}
+}
+
+// current returns the item the pager points at.
+func (p *pager) current() interface{} {
+	if p.index < 0 || p.index >= len(p.items) {
+		return nil
+	}
+	return p.items[p.index]
+}
+
+// pages calls fn for each remaining page until fn returns an error.
+func (p *pager) pages(fn func(items []interface{}) error) error {
+	for p.more && p.err == nil {
+		result := p.nextPage()
+		if result.err != nil {
+			p.err = result.err
+			break
+		}
+		p.more = result.more && len(result.items) > 0
+		if len(result.items) == 0 {
+			break
+		}
+		if err := fn(result.items); err != nil {
+			return err
+		}
+	}
+	return p.err
+}
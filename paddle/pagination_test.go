@@ -0,0 +1,81 @@
+package paddle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUsersService_Iter(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/2.0/subscription/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		switch r.FormValue("page") {
+		case "1":
+			testFormValues(t, r, values{"plan_id": "1", "page": "1", "results_per_page": "2"})
+			fmt.Fprint(w, `{"success":true, "response": [{"user_id":1}, {"user_id":2}]}`)
+		case "2":
+			fmt.Fprint(w, `{"success":true, "response": [{"user_id":3}]}`)
+		default:
+			t.Errorf("Unexpected page %v", r.FormValue("page"))
+		}
+	})
+
+	opt := &UsersOptions{PlanID: "1", ListOptions: ListOptions{ResultsPerPage: 2}}
+	users, err := client.Users.Iter(context.Background(), opt).Prefetch().All()
+	if err != nil {
+		t.Errorf("UsersIterator.All returned error: %v", err)
+	}
+
+	want := []*User{{UserID: Int(1)}, {UserID: Int(2)}, {UserID: Int(3)}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("UsersIterator.All returned %+v, want %+v", users, want)
+	}
+	if opt.Page != 0 {
+		t.Errorf("UsersService.Iter modified the options")
+	}
+}
+
+func TestUsersService_IterError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/2.0/subscription/users", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":false, "error": {"code": 107, "message": "You don't have permission to access this resource"}}`)
+	})
+
+	it := client.Users.Iter(context.Background(), nil)
+	if it.Next() {
+		t.Errorf("UsersIterator.Next returned true, want false")
+	}
+	if !IsAuthError(it.Err()) {
+		t.Errorf("UsersIterator.Err returned %v, want auth error", it.Err())
+	}
+}
+
+func TestWebhooksService_Iter(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/2.0/alert/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		page := r.FormValue("page")
+		fmt.Fprintf(w, `{"success":true, "response": {"current_page":%s, "total_pages":2, "data":[{"id": %s}]}}`, page, page)
+	})
+
+	it := client.Webhooks.Iter(context.Background(), nil)
+	var ids []int
+	for it.Next() {
+		ids = append(ids, *it.Event().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("WebhookEventsIterator.Err returned error: %v", err)
+	}
+
+	if want := []int{1, 2}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("WebhookEventsIterator returned %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,131 @@
+package paddle
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// MaxPassthroughLength is the maximum number of characters Paddle accepts in
+// the passthrough of a checkout.
+const MaxPassthroughLength = 1000
+
+var (
+	// ErrPassthroughTooLong is returned when an encoded passthrough is longer
+	// than MaxPassthroughLength characters.
+	ErrPassthroughTooLong = errors.New("paddle: passthrough is too long")
+
+	// ErrNoPassthrough is returned when decoding the passthrough of an alert
+	// which has none.
+	ErrNoPassthrough = errors.New("paddle: no passthrough")
+
+	// ErrPassthroughUnsigned is returned when a PassthroughCodec with a
+	// secret decodes a passthrough which is not signed.
+	ErrPassthroughUnsigned = errors.New("paddle: passthrough is not signed")
+
+	// ErrPassthroughSignature is returned when the signature of a passthrough
+	// does not match its data, for example because the customer changed it.
+	ErrPassthroughSignature = errors.New("paddle: passthrough signature mismatch")
+)
+
+// signedPassthrough is the format of the passthroughs signed by a
+// PassthroughCodec.
+type signedPassthrough struct {
+	Data      json.RawMessage `json:"d"`
+	Signature string          `json:"s"`
+}
+
+// PassthroughCodec encodes Go values into the passthrough of checkouts and
+// subscriptions, and decodes them back from the alerts. Values are encoded to
+// JSON. With a secret, the JSON is signed with HMAC-SHA256 so that a customer
+// changing the passthrough of a custom checkout is detected.
+//
+// Example usage:
+//
+//	codec := paddle.NewPassthroughCodec([]byte(config.PassthroughSecret))
+//	passthrough, err := codec.Encode(Order{UserID: 42})
+//	link, err := paddle.NewPayLink().Product(productID).Passthrough(passthrough).Build()
+//
+//	// In the alert handler:
+//	var order Order
+//	err := alert.DecodePassthrough(codec, &order)
+type PassthroughCodec struct {
+	secret []byte
+}
+
+// NewPassthroughCodec returns a PassthroughCodec signing the passthroughs
+// with secret. A nil or empty secret encodes plain JSON.
+func NewPassthroughCodec(secret []byte) *PassthroughCodec {
+	return &PassthroughCodec{secret: append([]byte(nil), secret...)}
+}
+
+// Encode returns v encoded to JSON, signed if c has a secret. An error
+// wrapping ErrPassthroughTooLong is returned if the result is longer than
+// MaxPassthroughLength characters.
+func (c *PassthroughCodec) Encode(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	if c.signed() {
+		data, err = json.Marshal(&signedPassthrough{Data: data, Signature: c.sign(data)})
+		if err != nil {
+			return "", err
+		}
+	}
+	passthrough := string(data)
+	if err := checkPassthroughLength(passthrough); err != nil {
+		return "", err
+	}
+	return passthrough, nil
+}
+
+// Decode verifies the signature of passthrough if c has a secret, and
+// decodes its JSON into v. ErrPassthroughUnsigned or ErrPassthroughSignature
+// is returned if the signature is missing or does not match.
+func (c *PassthroughCodec) Decode(passthrough string, v interface{}) error {
+	data := []byte(passthrough)
+	if c.signed() {
+		var signed signedPassthrough
+		if err := json.Unmarshal(data, &signed); err != nil || signed.Signature == "" || signed.Data == nil {
+			return ErrPassthroughUnsigned
+		}
+		if !hmac.Equal([]byte(signed.Signature), []byte(c.sign(signed.Data))) {
+			return ErrPassthroughSignature
+		}
+		data = signed.Data
+	}
+	return json.Unmarshal(data, v)
+}
+
+// decodeField decodes the passthrough field of an alert into v.
+func (c *PassthroughCodec) decodeField(passthrough *string, v interface{}) error {
+	if passthrough == nil || *passthrough == "" {
+		return ErrNoPassthrough
+	}
+	return c.Decode(*passthrough, v)
+}
+
+func (c *PassthroughCodec) signed() bool {
+	return c != nil && len(c.secret) > 0
+}
+
+// sign returns the base64 encoded HMAC-SHA256 of data.
+func (c *PassthroughCodec) sign(data []byte) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(data)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// checkPassthroughLength returns an error wrapping ErrPassthroughTooLong if
+// passthrough is longer than MaxPassthroughLength characters.
+func checkPassthroughLength(passthrough string) error {
+	if n := utf8.RuneCountInString(passthrough); n > MaxPassthroughLength {
+		return fmt.Errorf("%w: %d characters, the limit is %d", ErrPassthroughTooLong, n, MaxPassthroughLength)
+	}
+	return nil
+}
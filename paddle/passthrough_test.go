@@ -0,0 +1,126 @@
+package paddle
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testPassthrough struct {
+	UserID int    `json:"user_id"`
+	Plan   string `json:"plan"`
+}
+
+func TestPassthroughCodec_roundTrip(t *testing.T) {
+	for _, secret := range []string{"", "s3cret"} {
+		c := NewPassthroughCodec([]byte(secret))
+		in := testPassthrough{UserID: 42, Plan: "pro"}
+
+		passthrough, err := c.Encode(in)
+		if err != nil {
+			t.Fatalf("Encode returned error: %v", err)
+		}
+		if secret == "" && passthrough != `{"user_id":42,"plan":"pro"}` {
+			t.Errorf("Encode without secret returned %q", passthrough)
+		}
+
+		var out testPassthrough
+		if err := c.Decode(passthrough, &out); err != nil {
+			t.Fatalf("Decode(%q) returned error: %v", passthrough, err)
+		}
+		if !reflect.DeepEqual(out, in) {
+			t.Errorf("Decode returned %+v, want %+v", out, in)
+		}
+	}
+}
+
+func TestPassthroughCodec_Decode_signature(t *testing.T) {
+	c := NewPassthroughCodec([]byte("s3cret"))
+	passthrough, err := c.Encode(testPassthrough{UserID: 42})
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		codec       *PassthroughCodec
+		passthrough string
+		want        error
+	}{
+		{"tampered", c, strings.Replace(passthrough, "42", "43", 1), ErrPassthroughSignature},
+		{"other secret", NewPassthroughCodec([]byte("other")), passthrough, ErrPassthroughSignature},
+		{"unsigned", c, `{"user_id":43}`, ErrPassthroughUnsigned},
+		{"not JSON", c, "user-43", ErrPassthroughUnsigned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out testPassthrough
+			if err := tt.codec.Decode(tt.passthrough, &out); !errors.Is(err, tt.want) {
+				t.Errorf("Decode(%q) returned %v, want %v", tt.passthrough, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPassthroughCodec_Encode_tooLong(t *testing.T) {
+	c := NewPassthroughCodec(nil)
+	if _, err := c.Encode(strings.Repeat("é", MaxPassthroughLength-2)); err != nil {
+		t.Errorf("Encode of %d characters returned error: %v", MaxPassthroughLength, err)
+	}
+	if _, err := c.Encode(strings.Repeat("x", MaxPassthroughLength)); !errors.Is(err, ErrPassthroughTooLong) {
+		t.Errorf("Encode of %d characters returned %v, want %v", MaxPassthroughLength+2, err, ErrPassthroughTooLong)
+	}
+}
+
+func TestAlerts_DecodePassthrough(t *testing.T) {
+	c := NewPassthroughCodec([]byte("s3cret"))
+	passthrough, err := c.Encode(testPassthrough{UserID: 42})
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	type decoder interface {
+		DecodePassthrough(c *PassthroughCodec, v interface{}) error
+	}
+	for _, name := range append(AlertNames(), fulfillmentAlertName) {
+		alert, err := SampleAlert(name)
+		if err != nil {
+			t.Fatalf("SampleAlert(%q) returned error: %v", name, err)
+		}
+		field := reflect.ValueOf(alert).Elem().FieldByName("Passthrough")
+		if !field.IsValid() {
+			continue
+		}
+		d, ok := alert.(decoder)
+		if !ok {
+			t.Errorf("%T has a Passthrough field but no DecodePassthrough method", alert)
+			continue
+		}
+
+		field.Set(reflect.ValueOf(String(passthrough)))
+		var out testPassthrough
+		if err := d.DecodePassthrough(c, &out); err != nil || out.UserID != 42 {
+			t.Errorf("%T.DecodePassthrough returned %+v, %v", alert, out, err)
+		}
+
+		field.Set(reflect.Zero(field.Type()))
+		if err := d.DecodePassthrough(c, &out); !errors.Is(err, ErrNoPassthrough) {
+			t.Errorf("%T.DecodePassthrough without passthrough returned %v, want %v", alert, err, ErrNoPassthrough)
+		}
+	}
+}
+
+func TestPayLinkBuilder_PassthroughCodec(t *testing.T) {
+	c := NewPassthroughCodec([]byte("s3cret"))
+	link, err := NewPayLink().Product(1).PassthroughCodec(c).Passthrough(testPassthrough{UserID: 42}).Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+
+	alert := &PaymentSucceededAlert{Passthrough: String(link.Passthrough)}
+	var out testPassthrough
+	if err := alert.DecodePassthrough(c, &out); err != nil || out.UserID != 42 {
+		t.Errorf("DecodePassthrough returned %+v, %v", out, err)
+	}
+}
@@ -11,6 +11,9 @@ import (
 // Paddle API docs: https://developer.paddle.com/api-reference/product-api/pay-links
 type PayLinkService service
 
+// PayLinkCreate holds the parameters of a pay link, as sent to Paddle. Empty
+// fields are not sent. PayLinkBuilder builds a validated PayLinkCreate from
+// typed values.
 type PayLinkCreate struct {
 	ProductID               int    `url:"product_id,omitempty"`
 	Title                   string `url:"title,omitempty"`
@@ -20,15 +23,15 @@ type PayLinkCreate struct {
 	TrialDays               int    `url:"trial_days,omitempty"`
 	CustomMessage           string `url:"custom_message,omitempty"`
 	CouponCode              string `url:"coupon_code,omitempty"`
-	Discountable            int    `url:"discountable,omitempty"`
+	Discountable            *bool  `url:"discountable,int,omitempty"`
 	ImageURL                string `url:"image_url,omitempty"`
 	ReturnURL               string `url:"return_url,omitempty"`
-	QuantityVariable        int    `url:"quantity_variable,omitempty"`
+	QuantityVariable        *bool  `url:"quantity_variable,int,omitempty"`
 	Quantity                int    `url:"quantity,omitempty"`
 	Expires                 string `url:"expires,omitempty"`
 	Affiliates              string `url:"affiliates,omitempty"`
 	RecurringAffiliateLimit int    `url:"recurring_affiliate_limit,omitempty"`
-	MarketingConsent        int    `url:"marketing_consent,omitempty"`
+	MarketingConsent        *bool  `url:"marketing_consent,int,omitempty"`
 	CustomerEmail           string `url:"customer_email,omitempty"`
 	CustomerCountry         string `url:"customer_country,omitempty"`
 	CustomerPostcode        string `url:"customer_postcode,omitempty"`
@@ -61,7 +64,7 @@ func (s *PayLinkService) Create(ctx context.Context, payLink *PayLinkCreate) (*s
 	}
 
 	payLinkCreateResponse := new(PayLinkCreateResponse)
-	response, err := s.client.Do(ctx, req, payLinkCreateResponse)
+	response, err := s.client.Do(withOperation(ctx, "PayLink.Create"), req, payLinkCreateResponse)
 	if err != nil {
 		return nil, response, err
 	}
@@ -0,0 +1,482 @@
+package paddle
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// payLinkDateLayout is the format of the expiry date of a pay link.
+const payLinkDateLayout = "2006-01-02"
+
+// postcodeCountries lists the countries for which Paddle requires a postcode.
+var postcodeCountries = map[string]bool{
+	"AU": true,
+	"CA": true,
+	"DE": true,
+	"ES": true,
+	"FR": true,
+	"GB": true,
+	"IN": true,
+	"IT": true,
+	"NL": true,
+	"US": true,
+}
+
+// Affiliate is a vendor earning a commission on the sales of a pay link.
+type Affiliate struct {
+	VendorID int
+
+	// Commission is the share of the sale paid to the affiliate, greater
+	// than 0 and at most 1, for example 0.25 for 25%.
+	Commission Decimal
+}
+
+// VATDetails holds the VAT registration of a business customer. Number,
+// CompanyName, Street, City, Country and Postcode must be set together;
+// State is optional.
+type VATDetails struct {
+	Number      string
+	CompanyName string
+	Street      string
+	City        string
+	State       string
+	Country     string // Two letters ISO 3166-1 code, such as "GB".
+	Postcode    string
+}
+
+// PayLinkError is returned by PayLinkBuilder.Build when the pay link breaks
+// the rules of the Paddle API. It lists every problem found, and matches
+// ErrValidation with errors.Is.
+type PayLinkError struct {
+	Problems []string
+}
+
+func (e *PayLinkError) Error() string {
+	return "paddle: invalid pay link: " + strings.Join(e.Problems, "; ")
+}
+
+// Is reports whether target is ErrValidation.
+func (e *PayLinkError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// PayLinkBuilder builds a PayLinkCreate from typed values, and checks it
+// against the rules of the Paddle API before any request is sent. The
+// setters return the builder so that calls can be chained.
+//
+// Example usage:
+//
+//	link, err := paddle.NewPayLink().
+//		Plan(planID).
+//		Price(paddle.MustParseMoney("USD", "4.99"), paddle.MustParseMoney("EUR", "3.99")).
+//		RecurringPrice(paddle.MustParseMoney("USD", "9.99"), paddle.MustParseMoney("EUR", "8.99")).
+//		Expires(time.Now().AddDate(0, 0, 7)).
+//		Build()
+//	if err != nil {
+//		// err is a *PayLinkError listing every problem.
+//	}
+//	url, _, err := client.PayLink.Create(ctx, link)
+type PayLinkBuilder struct {
+	link PayLinkCreate
+
+	plan            bool
+	custom          bool
+	prices          []Money
+	recurringPrices []Money
+	trialDays       *int
+	quantity        *int
+	affiliateLimit  *int
+	expires         time.Time
+	affiliates      []Affiliate
+	passthrough     interface{}
+	codec           *PassthroughCodec
+	vat             VATDetails
+	now             func() time.Time // Overridden by the tests.
+}
+
+// NewPayLink returns an empty PayLinkBuilder. One of Product, Plan or
+// Custom must be called before Build.
+func NewPayLink() *PayLinkBuilder {
+	return &PayLinkBuilder{now: time.Now}
+}
+
+// Product sets the one-time product sold by the pay link.
+func (b *PayLinkBuilder) Product(productID int) *PayLinkBuilder {
+	b.link.ProductID = productID
+	b.plan = false
+	return b
+}
+
+// Plan sets the subscription plan sold by the pay link. Recurring prices,
+// trial days and recurring affiliate limits are only allowed for plans.
+func (b *PayLinkBuilder) Plan(planID int) *PayLinkBuilder {
+	b.link.ProductID = planID
+	b.plan = true
+	return b
+}
+
+// Custom sells a product which is not in the Paddle catalogue. Paddle calls
+// webhookURL to fulfill the orders, and the prices are required.
+func (b *PayLinkBuilder) Custom(title, webhookURL string) *PayLinkBuilder {
+	b.link.Title = title
+	b.link.WebhookURL = webhookURL
+	b.custom = true
+	return b
+}
+
+// Title overrides the name of the product shown on the checkout.
+func (b *PayLinkBuilder) Title(title string) *PayLinkBuilder {
+	b.link.Title = title
+	return b
+}
+
+// Price adds prices of the checkout, one per currency. For a plan, they are
+// the prices of the first payment.
+func (b *PayLinkBuilder) Price(prices ...Money) *PayLinkBuilder {
+	b.prices = append(b.prices, prices...)
+	return b
+}
+
+// RecurringPrice adds recurring prices of a plan, one per currency. Each
+// currency must have a price as well.
+func (b *PayLinkBuilder) RecurringPrice(prices ...Money) *PayLinkBuilder {
+	b.recurringPrices = append(b.recurringPrices, prices...)
+	return b
+}
+
+// TrialDays sets the length of the trial of a plan.
+func (b *PayLinkBuilder) TrialDays(days int) *PayLinkBuilder {
+	b.trialDays = &days
+	return b
+}
+
+// Message sets a message shown below the product title.
+func (b *PayLinkBuilder) Message(message string) *PayLinkBuilder {
+	b.link.CustomMessage = message
+	return b
+}
+
+// Coupon applies the coupon code to the checkout.
+func (b *PayLinkBuilder) Coupon(code string) *PayLinkBuilder {
+	b.link.CouponCode = code
+	return b
+}
+
+// Discountable sets whether coupons can be applied to the checkout.
+func (b *PayLinkBuilder) Discountable(discountable bool) *PayLinkBuilder {
+	b.link.Discountable = Bool(discountable)
+	return b
+}
+
+// ImageURL sets the image shown on the checkout.
+func (b *PayLinkBuilder) ImageURL(imageURL string) *PayLinkBuilder {
+	b.link.ImageURL = imageURL
+	return b
+}
+
+// ReturnURL sets the page the customer is sent to after the checkout.
+func (b *PayLinkBuilder) ReturnURL(returnURL string) *PayLinkBuilder {
+	b.link.ReturnURL = returnURL
+	return b
+}
+
+// Quantity sets the initial quantity, between 1 and 100.
+func (b *PayLinkBuilder) Quantity(quantity int) *PayLinkBuilder {
+	b.quantity = &quantity
+	return b
+}
+
+// QuantityVariable sets whether the customer can change the quantity.
+func (b *PayLinkBuilder) QuantityVariable(variable bool) *PayLinkBuilder {
+	b.link.QuantityVariable = Bool(variable)
+	return b
+}
+
+// Expires sets the day the pay link expires on, the date of t in its own
+// location. It must not be in the past.
+func (b *PayLinkBuilder) Expires(t time.Time) *PayLinkBuilder {
+	b.expires = t
+	return b
+}
+
+// Affiliate adds an affiliate earning commission on the sales.
+func (b *PayLinkBuilder) Affiliate(vendorID int, commission Decimal) *PayLinkBuilder {
+	b.affiliates = append(b.affiliates, Affiliate{VendorID: vendorID, Commission: commission})
+	return b
+}
+
+// RecurringAffiliateLimit sets the number of subscription payments the
+// affiliates earn commission on.
+func (b *PayLinkBuilder) RecurringAffiliateLimit(payments int) *PayLinkBuilder {
+	b.affiliateLimit = &payments
+	return b
+}
+
+// Customer prefills the email, country and postcode of the customer. Empty
+// values are left out.
+func (b *PayLinkBuilder) Customer(email, country, postcode string) *PayLinkBuilder {
+	b.link.CustomerEmail = email
+	b.link.CustomerCountry = strings.ToUpper(country)
+	b.link.CustomerPostcode = postcode
+	return b
+}
+
+// MarketingConsent sets whether the customer agreed to receive marketing
+// emails. The customer email is required.
+func (b *PayLinkBuilder) MarketingConsent(consent bool) *PayLinkBuilder {
+	b.link.MarketingConsent = Bool(consent)
+	return b
+}
+
+// Passthrough sets the data sent back in the alerts of the checkout. It is
+// encoded by the PassthroughCodec set with PassthroughCodec. Without one, a
+// string is sent as is and any other value is encoded to JSON.
+func (b *PayLinkBuilder) Passthrough(v interface{}) *PayLinkBuilder {
+	b.passthrough = v
+	return b
+}
+
+// PassthroughCodec sets the codec encoding the passthrough, for example to
+// sign it.
+func (b *PayLinkBuilder) PassthroughCodec(c *PassthroughCodec) *PayLinkBuilder {
+	b.codec = c
+	return b
+}
+
+// VAT prefills the VAT registration of a business customer.
+func (b *PayLinkBuilder) VAT(vat VATDetails) *PayLinkBuilder {
+	b.vat = vat
+	return b
+}
+
+// Build checks the pay link and returns the parameters to pass to
+// PayLinkService.Create. If the pay link breaks any rule, a *PayLinkError
+// listing all of them is returned.
+func (b *PayLinkBuilder) Build() (*PayLinkCreate, error) {
+	link := b.link
+	var problems []string
+	problemf := func(format string, a ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, a...))
+	}
+
+	switch {
+	case b.custom && link.ProductID != 0:
+		problemf("webhook_url cannot be set with a product_id")
+	case b.custom:
+		if link.Title == "" {
+			problemf("title is required for a custom product")
+		}
+		if !isHTTPURL(link.WebhookURL) {
+			problemf("webhook_url must be an absolute http or https URL")
+		}
+		if len(b.prices) == 0 {
+			problemf("prices are required for a custom product")
+		}
+	case link.ProductID <= 0:
+		problemf("product_id, or title and webhook_url, are required")
+	}
+
+	var err error
+	if link.Prices, err = encodePrices(b.prices); err != nil {
+		problemf("prices: %v", err)
+	}
+	if len(b.recurringPrices) > 0 {
+		if !b.plan {
+			problemf("recurring_prices need a subscription plan product_id")
+		}
+		if link.RecurringPrices, err = encodePrices(b.recurringPrices); err != nil {
+			problemf("recurring_prices: %v", err)
+		}
+		for _, recurring := range b.recurringPrices {
+			if !hasCurrency(b.prices, recurring.Currency) {
+				problemf("recurring_prices: %s has no matching price in prices", strings.ToUpper(recurring.Currency))
+			}
+		}
+	}
+	if b.trialDays != nil {
+		if !b.plan {
+			problemf("trial_days need a subscription plan product_id")
+		} else if *b.trialDays < 0 {
+			problemf("trial_days must not be negative")
+		}
+		link.TrialDays = *b.trialDays
+	}
+
+	if link.CouponCode != "" && link.Discountable != nil && !*link.Discountable {
+		problemf("coupon_code cannot be applied when discountable is false")
+	}
+	if link.ImageURL != "" && !isHTTPURL(link.ImageURL) {
+		problemf("image_url must be an absolute http or https URL")
+	}
+	if link.ReturnURL != "" && !isHTTPURL(link.ReturnURL) {
+		problemf("return_url must be an absolute http or https URL")
+	}
+	if b.quantity != nil {
+		if *b.quantity < 1 || *b.quantity > 100 {
+			problemf("quantity must be between 1 and 100")
+		}
+		link.Quantity = *b.quantity
+	}
+
+	if !b.expires.IsZero() {
+		expires := b.expires.Format(payLinkDateLayout)
+		if expires < b.now().UTC().Format(payLinkDateLayout) {
+			problemf("expires must not be in the past")
+		}
+		link.Expires = expires
+	}
+
+	if link.Affiliates, err = encodeAffiliates(b.affiliates); err != nil {
+		problemf("affiliates: %v", err)
+	}
+	if b.affiliateLimit != nil {
+		if !b.plan {
+			problemf("recurring_affiliate_limit needs a subscription plan product_id")
+		}
+		if len(b.affiliates) == 0 {
+			problemf("recurring_affiliate_limit needs affiliates")
+		}
+		if *b.affiliateLimit < 1 {
+			problemf("recurring_affiliate_limit must be positive")
+		}
+		link.RecurringAffiliateLimit = *b.affiliateLimit
+	}
+
+	if link.CustomerEmail != "" && !strings.Contains(link.CustomerEmail, "@") {
+		problemf("customer_email is not a valid email address")
+	}
+	if link.MarketingConsent != nil && link.CustomerEmail == "" {
+		problemf("marketing_consent needs a customer_email")
+	}
+	if link.CustomerCountry != "" && !isCountryCode(link.CustomerCountry) {
+		problemf("customer_country must be a two letters country code")
+	}
+	if postcodeCountries[link.CustomerCountry] && link.CustomerPostcode == "" {
+		problemf("customer_postcode is required for customers in %s", link.CustomerCountry)
+	}
+	if link.CustomerPostcode != "" && link.CustomerCountry == "" {
+		problemf("customer_postcode needs a customer_country")
+	}
+
+	if link.Passthrough, err = b.encodePassthrough(); errors.Is(err, ErrPassthroughTooLong) {
+		problemf("passthrough must not be longer than %d characters", MaxPassthroughLength)
+	} else if err != nil {
+		problemf("passthrough: %v", err)
+	}
+
+	if b.vat != (VATDetails{}) {
+		vat := b.vat
+		vat.Country = strings.ToUpper(vat.Country)
+		for _, field := range []struct{ name, value string }{
+			{"vat_number", vat.Number},
+			{"vat_company_name", vat.CompanyName},
+			{"vat_street", vat.Street},
+			{"vat_city", vat.City},
+			{"vat_country", vat.Country},
+			{"vat_postcode", vat.Postcode},
+		} {
+			if field.value == "" {
+				problemf("%s must be set with the other vat_* fields", field.name)
+			}
+		}
+		if vat.Country != "" && !isCountryCode(vat.Country) {
+			problemf("vat_country must be a two letters country code")
+		}
+		link.VatNumber = vat.Number
+		link.VatCompanyName = vat.CompanyName
+		link.VatStreet = vat.Street
+		link.VatCity = vat.City
+		link.VatState = vat.State
+		link.VatCountry = vat.Country
+		link.VatPostcode = vat.Postcode
+	}
+
+	if len(problems) > 0 {
+		return nil, &PayLinkError{Problems: problems}
+	}
+	return &link, nil
+}
+
+// encodePrices encodes prices in the format of the API, such as
+// "USD:9.99,EUR:8.99".
+func encodePrices(prices []Money) (string, error) {
+	var encoded []string
+	seen := make(map[string]bool)
+	for _, price := range prices {
+		currency := strings.ToUpper(price.Currency)
+		if len(currency) != 3 || strings.Trim(currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+			return "", fmt.Errorf("invalid currency %q", price.Currency)
+		}
+		if seen[currency] {
+			return "", fmt.Errorf("%s is set twice", currency)
+		}
+		seen[currency] = true
+		if price.Amount.Sign() < 0 {
+			return "", fmt.Errorf("%s amount must not be negative", currency)
+		}
+		if _, err := price.MinorUnits(); err != nil {
+			return "", err
+		}
+		price.Currency = currency
+		encoded = append(encoded, currency+":"+price.formValue())
+	}
+	return strings.Join(encoded, ","), nil
+}
+
+// encodeAffiliates encodes affiliates in the format of the API, such as
+// "12345:0.25,67890:0.1".
+func encodeAffiliates(affiliates []Affiliate) (string, error) {
+	var encoded []string
+	seen := make(map[int]bool)
+	for _, affiliate := range affiliates {
+		if affiliate.VendorID <= 0 {
+			return "", fmt.Errorf("invalid vendor id %d", affiliate.VendorID)
+		}
+		if seen[affiliate.VendorID] {
+			return "", fmt.Errorf("vendor %d is set twice", affiliate.VendorID)
+		}
+		seen[affiliate.VendorID] = true
+		if affiliate.Commission.Sign() <= 0 || affiliate.Commission.Cmp(NewDecimal(1, 0)) > 0 {
+			return "", fmt.Errorf("commission of vendor %d must be greater than 0 and at most 1", affiliate.VendorID)
+		}
+		encoded = append(encoded, strconv.Itoa(affiliate.VendorID)+":"+affiliate.Commission.String())
+	}
+	return strings.Join(encoded, ","), nil
+}
+
+// encodePassthrough encodes the passthrough with the codec of b, or returns
+// it as is if it is a string and b has no codec.
+func (b *PayLinkBuilder) encodePassthrough() (string, error) {
+	if b.passthrough == nil {
+		return "", nil
+	}
+	if s, ok := b.passthrough.(string); ok && b.codec == nil {
+		return s, checkPassthroughLength(s)
+	}
+	return b.codec.Encode(b.passthrough)
+}
+
+// hasCurrency reports whether prices have an amount in currency.
+func hasCurrency(prices []Money, currency string) bool {
+	for _, price := range prices {
+		if strings.EqualFold(price.Currency, currency) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHTTPURL reports whether s is an absolute http or https URL.
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// isCountryCode reports whether s looks like an ISO 3166-1 alpha-2 code.
+func isCountryCode(s string) bool {
+	return len(s) == 2 && strings.Trim(s, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") == ""
+}
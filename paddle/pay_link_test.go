@@ -2,10 +2,13 @@ package paddle
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestPayLinkService_Create(t *testing.T) {
@@ -29,3 +32,155 @@ func TestPayLinkService_Create(t *testing.T) {
 		t.Errorf("PayLink.Create returned %+v, want %+v", *url, want)
 	}
 }
+
+func TestPayLinkBuilder_Build(t *testing.T) {
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := NewPayLink().
+		Plan(1).
+		Price(MustParseMoney("usd", "5"), MustParseMoney("JPY", "500")).
+		RecurringPrice(MustParseMoney("USD", "9.99")).
+		TrialDays(7).
+		Discountable(false).
+		QuantityVariable(false).
+		Expires(now.AddDate(0, 0, 7)).
+		Affiliate(12345, MustParseDecimal("0.25")).
+		RecurringAffiliateLimit(3).
+		Customer("a@example.com", "gb", "SW1A 1AA").
+		MarketingConsent(true).
+		Passthrough(map[string]int{"user_id": 42}).
+		VAT(VATDetails{Number: "GB123", CompanyName: "Acme", Street: "1 Road", City: "London", Country: "gb", Postcode: "SW1A 1AA"})
+	b.now = func() time.Time { return now }
+
+	link, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+
+	want := &PayLinkCreate{
+		ProductID:               1,
+		Prices:                  "USD:5.00,JPY:500",
+		RecurringPrices:         "USD:9.99",
+		TrialDays:               7,
+		Discountable:            Bool(false),
+		QuantityVariable:        Bool(false),
+		Expires:                 "2030-01-08",
+		Affiliates:              "12345:0.25",
+		RecurringAffiliateLimit: 3,
+		MarketingConsent:        Bool(true),
+		CustomerEmail:           "a@example.com",
+		CustomerCountry:         "GB",
+		CustomerPostcode:        "SW1A 1AA",
+		Passthrough:             `{"user_id":42}`,
+		VatNumber:               "GB123",
+		VatCompanyName:          "Acme",
+		VatStreet:               "1 Road",
+		VatCity:                 "London",
+		VatCountry:              "GB",
+		VatPostcode:             "SW1A 1AA",
+	}
+	if !reflect.DeepEqual(link, want) {
+		t.Errorf("Build returned %+v, want %+v", link, want)
+	}
+
+}
+
+func TestPayLinkBuilder_Build_problems(t *testing.T) {
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		builder *PayLinkBuilder
+		want    []string
+	}{
+		{
+			name:    "no product",
+			builder: NewPayLink(),
+			want:    []string{"product_id, or title and webhook_url, are required"},
+		},
+		{
+			name:    "custom product",
+			builder: NewPayLink().Custom("", "ftp://example.com"),
+			want: []string{
+				"title is required for a custom product",
+				"webhook_url must be an absolute http or https URL",
+				"prices are required for a custom product",
+			},
+		},
+		{
+			name: "one-time product",
+			builder: NewPayLink().Product(1).
+				Price(MustParseMoney("USD", "1"), MustParseMoney("USD", "2")).
+				RecurringPrice(MustParseMoney("EUR", "1.001")).
+				TrialDays(7).
+				Quantity(101),
+			want: []string{
+				"prices: USD is set twice",
+				"recurring_prices need a subscription plan product_id",
+				"recurring_prices: 1.001 is more precise than the minor unit of EUR",
+				"recurring_prices: EUR has no matching price in prices",
+				"trial_days need a subscription plan product_id",
+				"quantity must be between 1 and 100",
+			},
+		},
+		{
+			name: "checkout",
+			builder: NewPayLink().Plan(1).
+				Coupon("SALE").
+				Discountable(false).
+				ReturnURL("/thanks").
+				Expires(now.AddDate(0, 0, -1)).
+				Affiliate(12345, MustParseDecimal("1.5")).
+				MarketingConsent(true).
+				Customer("", "US", "").
+				Passthrough(strings.Repeat("x", 1001)),
+			want: []string{
+				"coupon_code cannot be applied when discountable is false",
+				"return_url must be an absolute http or https URL",
+				"expires must not be in the past",
+				"affiliates: commission of vendor 12345 must be greater than 0 and at most 1",
+				"marketing_consent needs a customer_email",
+				"customer_postcode is required for customers in US",
+				"passthrough must not be longer than 1000 characters",
+			},
+		},
+		{
+			name:    "affiliate limit",
+			builder: NewPayLink().Product(1).RecurringAffiliateLimit(0),
+			want: []string{
+				"recurring_affiliate_limit needs a subscription plan product_id",
+				"recurring_affiliate_limit needs affiliates",
+				"recurring_affiliate_limit must be positive",
+			},
+		},
+		{
+			name:    "partial VAT details",
+			builder: NewPayLink().Product(1).VAT(VATDetails{Number: "GB123", Country: "GBR"}),
+			want: []string{
+				"vat_company_name must be set with the other vat_* fields",
+				"vat_street must be set with the other vat_* fields",
+				"vat_city must be set with the other vat_* fields",
+				"vat_postcode must be set with the other vat_* fields",
+				"vat_country must be a two letters country code",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.builder.now = func() time.Time { return now }
+			link, err := tt.builder.Build()
+			if link != nil {
+				t.Errorf("Build returned %+v, want nil", link)
+			}
+			var linkErr *PayLinkError
+			if !errors.As(err, &linkErr) {
+				t.Fatalf("Build returned %v, want a *PayLinkError", err)
+			}
+			if !reflect.DeepEqual(linkErr.Problems, tt.want) {
+				t.Errorf("Build returned problems %q, want %q", linkErr.Problems, tt.want)
+			}
+			if !IsValidationError(err) {
+				t.Errorf("IsValidationError(%v) = false, want true", err)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package paddle
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 )
 
@@ -15,7 +16,7 @@ type PaymentsService service
 type Payment struct {
 	ID             *int    `json:"id,omitempty"`
 	SubscriptionID *int    `json:"subscription_id,omitempty"`
-	Amount         *int    `json:"amount,omitempty"`
+	Amount         *Money  `json:"amount,omitempty"`
 	Currency       *string `json:"currency,omitempty"`
 	PayoutDate     *string `json:"payout_date,omitempty"`
 	IsPaid         *int    `json:"is_paid,omitempty"`
@@ -23,6 +24,16 @@ type Payment struct {
 	IsOneOffCharge *int    `json:"is_one_off_charge,omitempty"`
 }
 
+// UnmarshalJSON decodes a payment and sets the currency of its amount.
+func (p *Payment) UnmarshalJSON(data []byte) error {
+	type payment Payment
+	if err := json.Unmarshal(data, (*payment)(p)); err != nil {
+		return err
+	}
+	setCurrency(p.Currency, p.Amount)
+	return nil
+}
+
 type PaymentsResponse struct {
 	Success  bool       `json:"success"`
 	Response []*Payment `json:"response"`
@@ -35,17 +46,19 @@ type PaymentsOptions struct {
 	SubscriptionID int `url:"subscription_id,omitempty"`
 	// The product/plan ID (single or comma-separated values)
 	Plan int `url:"plan,omitempty"`
-	// Payment is paid (0 = No, 1 = Yes)
-	IsPaid int `url:"is_paid,omitempty"`
+	// Payment is paid (sent as 0 = No, 1 = Yes)
+	IsPaid *bool `url:"is_paid,int,omitempty"`
 	// Payments starting from (date in format YYYY-MM-DD)
 	From string `url:"from,omitempty"`
 	// Payments up to (date in format YYYY-MM-DD)
 	To string `url:"to,omitempty"`
 	// Non-recurring payments created from the
-	IsOneOffCharge bool `url:"is_one_off_charge,omitempty"`
+	IsOneOffCharge *bool `url:"is_one_off_charge,omitempty"`
 }
 
-// List all paid and upcoming (unpaid) payments
+// List all paid and upcoming (unpaid) payments. The endpoint is not
+// paginated: every matching payment is returned at once, use From and To to
+// narrow large listings.
 //
 // Paddle API docs: https://developer.paddle.com/api-reference/subscription-api/payments/listpayments
 func (s *PaymentsService) List(ctx context.Context, options *PaymentsOptions) ([]*Payment, *http.Response, error) {
@@ -56,7 +69,7 @@ func (s *PaymentsService) List(ctx context.Context, options *PaymentsOptions) ([
 	}
 
 	paymentsResponse := new(PaymentsResponse)
-	response, err := s.client.Do(ctx, req, paymentsResponse)
+	response, err := s.client.Do(withOperation(ctx, "Payments.List"), req, paymentsResponse)
 	if err != nil {
 		return nil, response, err
 	}
@@ -90,7 +103,7 @@ func (s *PaymentsService) Update(ctx context.Context, paymentID int, date string
 	}
 
 	paymentUpdateResponse := new(PaymentUpdateResponse)
-	response, err := s.client.Do(ctx, req, paymentUpdateResponse)
+	response, err := s.client.Do(withOperation(ctx, "Payments.Update"), req, paymentUpdateResponse)
 	if err != nil {
 		return false, response, err
 	}
@@ -2,7 +2,9 @@ package paddle
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 )
 
 // PlansService handles communication with the plans related
@@ -13,13 +15,13 @@ type PlansService service
 
 // Plan represents a Paddle plan.
 type Plan struct {
-	ID             *int                   `json:"id,omitempty"`
-	Name           *string                `json:"name,omitempty"`
-	BillingType    *string                `json:"billing_type,omitempty"`
-	BillingPeriod  *int                   `json:"billing_period,omitempty"`
-	TrialDays      *int                   `json:"trial_days,omitempty"`
-	InitialPrice   map[string]interface{} `json:"initial_price,omitempty"`
-	RecurringPrice map[string]interface{} `json:"recurring_price,omitempty"`
+	ID             *int            `json:"id,omitempty"`
+	Name           *string         `json:"name,omitempty"`
+	BillingType    *string         `json:"billing_type,omitempty"`
+	BillingPeriod  *int            `json:"billing_period,omitempty"`
+	TrialDays      *int            `json:"trial_days,omitempty"`
+	InitialPrice   MoneyByCurrency `json:"initial_price,omitempty"`
+	RecurringPrice MoneyByCurrency `json:"recurring_price,omitempty"`
 }
 
 type PlansResponse struct {
@@ -45,7 +47,7 @@ func (s *PlansService) List(ctx context.Context, options *PlansOptions) ([]*Plan
 	}
 
 	plansResponse := new(PlansResponse)
-	response, err := s.client.Do(ctx, req, plansResponse)
+	response, err := s.client.doCached(withOperation(ctx, "Plans.List"), CachePlans, req, plansResponse)
 	if err != nil {
 		return nil, response, err
 	}
@@ -59,17 +61,23 @@ type PlanCreate struct {
 	PlanType          string `url:"plan_type,omitempty"`
 	PlanTrialDays     int    `url:"plan_trial_days,omitempty"`
 	MainCurrencyCode  string `url:"main_currency_code,omitempty"`
-	RecurringPriceUsd string `url:"recurring_price_usd,omitempty"`
-	RecurringPriceGbp string `url:"recurring_price_gbp,omitempty"`
-	RecurringPriceEur string `url:"recurring_price_eur,omitempty"`
+	RecurringPriceUsd *Money `url:"recurring_price_usd,omitempty"`
+	RecurringPriceGbp *Money `url:"recurring_price_gbp,omitempty"`
+	RecurringPriceEur *Money `url:"recurring_price_eur,omitempty"`
 }
 
+// PlanCreateOptions specifies the optional parameters to the
+// PlansService.Create method.
 type PlanCreateOptions struct {
-	PlanTrialDays     int
-	MainCurrencyCode  string
-	RecurringPriceUsd string
-	RecurringPriceGbp string
-	RecurringPriceEur string
+	PlanTrialDays    int
+	MainCurrencyCode string
+
+	// Recurring prices of the plan in each currency, one of which must be
+	// set for the main currency. A price without currency is taken to be in
+	// the currency of its field, any other currency is an error.
+	RecurringPriceUsd *Money
+	RecurringPriceGbp *Money
+	RecurringPriceEur *Money
 }
 
 type PlanCreateResponse struct {
@@ -91,9 +99,16 @@ func (s *PlansService) Create(ctx context.Context, planName, planType string, pl
 	if options != nil {
 		create.PlanTrialDays = options.PlanTrialDays
 		create.MainCurrencyCode = options.MainCurrencyCode
-		create.RecurringPriceUsd = options.RecurringPriceUsd
-		create.RecurringPriceGbp = options.RecurringPriceGbp
-		create.RecurringPriceEur = options.RecurringPriceEur
+		var err error
+		if create.RecurringPriceUsd, err = planPrice(options.RecurringPriceUsd, "USD"); err != nil {
+			return nil, nil, err
+		}
+		if create.RecurringPriceGbp, err = planPrice(options.RecurringPriceGbp, "GBP"); err != nil {
+			return nil, nil, err
+		}
+		if create.RecurringPriceEur, err = planPrice(options.RecurringPriceEur, "EUR"); err != nil {
+			return nil, nil, err
+		}
 	}
 	req, err := s.client.NewRequest("POST", u, create)
 	if err != nil {
@@ -101,10 +116,23 @@ func (s *PlansService) Create(ctx context.Context, planName, planType string, pl
 	}
 
 	planCreateResponse := new(PlanCreateResponse)
-	response, err := s.client.Do(ctx, req, planCreateResponse)
+	response, err := s.client.Do(withOperation(ctx, "Plans.Create"), req, planCreateResponse)
 	if err != nil {
 		return nil, response, err
 	}
 
+	s.client.invalidateCache(ctx, CachePlans, CachePrices)
 	return planCreateResponse.Response, response, nil
 }
+
+// planPrice returns price in currency, so that it is sent padded to the
+// minor unit, or an error if price is in another currency.
+func planPrice(price *Money, currency string) (*Money, error) {
+	if price == nil {
+		return nil, nil
+	}
+	if price.Currency != "" && !strings.EqualFold(price.Currency, currency) {
+		return nil, fmt.Errorf("paddle: recurring price %v given for %s", price, currency)
+	}
+	return &Money{Currency: currency, Amount: price.Amount}, nil
+}
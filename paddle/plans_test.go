@@ -24,7 +24,7 @@ func TestPlansService_List(t *testing.T) {
 		t.Errorf("Plans.List returned error: %v", err)
 	}
 
-	want := []*Plan{{ID: Int(1), InitialPrice: map[string]interface{}{"USD": "79.00"}}}
+	want := []*Plan{{ID: Int(1), InitialPrice: MoneyByCurrency{"USD": MustParseMoney("USD", "79")}}}
 	if !reflect.DeepEqual(plans, want) {
 		t.Errorf("Plans.List returned %+v, want %+v", plans, want)
 	}
@@ -36,11 +36,24 @@ func TestPlansService_Create(t *testing.T) {
 
 	mux.HandleFunc("/2.0/subscription/plans_create", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "POST")
-		testFormValues(t, r, values{"plan_name": "a", "plan_length": "1", "plan_type": "month", "plan_trial_days": "10"})
+		testFormValues(t, r, values{
+			"plan_name":           "a",
+			"plan_length":         "1",
+			"plan_type":           "month",
+			"plan_trial_days":     "10",
+			"main_currency_code":  "USD",
+			"recurring_price_usd": "10.50",
+			"recurring_price_eur": "9.00",
+		})
 		fmt.Fprint(w, `{"success":true, "response": {"product_id":1}}`)
 	})
 
-	opt := &PlanCreateOptions{PlanTrialDays: 10}
+	opt := &PlanCreateOptions{
+		PlanTrialDays:     10,
+		MainCurrencyCode:  "USD",
+		RecurringPriceUsd: &Money{Currency: "USD", Amount: MustParseDecimal("10.5")},
+		RecurringPriceEur: &Money{Amount: MustParseDecimal("9")},
+	}
 	product, _, err := client.Plans.Create(context.Background(), "a", "month", 1, opt)
 	if err != nil {
 		t.Errorf("Plans.Create returned error: %v", err)
@@ -51,3 +64,13 @@ func TestPlansService_Create(t *testing.T) {
 		t.Errorf("Plans.Create returned %+v, want %+v", product, want)
 	}
 }
+
+func TestPlansService_Create_priceCurrency(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	opt := &PlanCreateOptions{MainCurrencyCode: "USD", RecurringPriceUsd: &Money{Currency: "EUR", Amount: MustParseDecimal("10")}}
+	if _, _, err := client.Plans.Create(context.Background(), "a", "month", 1, opt); err == nil {
+		t.Error("Plans.Create with a EUR price for USD returned no error")
+	}
+}
@@ -2,6 +2,7 @@ package paddle
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -28,15 +29,32 @@ type Product struct {
 	AppliedCoupon              *AppliedCoupon `json:"applied_coupon,omitempty"`
 }
 
+// UnmarshalJSON decodes a product and sets the currency of its amounts.
+func (p *Product) UnmarshalJSON(data []byte) error {
+	type product Product
+	if err := json.Unmarshal(data, (*product)(p)); err != nil {
+		return err
+	}
+	for _, price := range []*Price{p.Price, p.ListPrice} {
+		if price != nil {
+			setCurrency(p.Currency, price.Gross, price.Net, price.Tax)
+		}
+	}
+	if p.AppliedCoupon != nil {
+		setCurrency(p.Currency, p.AppliedCoupon.Discount)
+	}
+	return nil
+}
+
 type Price struct {
-	Gross *float64 `json:"gross,omitempty"`
-	Net   *float64 `json:"net,omitempty"`
-	Tax   *float64 `json:"tax,omitempty"`
+	Gross *Money `json:"gross,omitempty"`
+	Net   *Money `json:"net,omitempty"`
+	Tax   *Money `json:"tax,omitempty"`
 }
 
 type AppliedCoupon struct {
-	Code     *string  `json:"code,omitempty"`
-	Discount *float64 `json:"discount,omitempty"`
+	Code     *string `json:"code,omitempty"`
+	Discount *Money  `json:"discount,omitempty"`
 }
 
 type PricesResponse struct {
@@ -68,13 +86,13 @@ func (s *PricesService) Get(ctx context.Context, productIDs string, options *Pri
 		}
 	}
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.newCheckoutRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	pricesResponse := new(PricesResponse)
-	response, err := s.client.Do(ctx, req, pricesResponse)
+	response, err := s.client.doCached(withOperation(ctx, "Prices.Get"), CachePrices, req, pricesResponse)
 	if err != nil {
 		return nil, response, err
 	}
@@ -19,8 +19,9 @@ func TestPricesService_Get(t *testing.T) {
                         "customer_country": "tn",
                         "products": [{
                               "product_id": 1,
-                              "price": {"gross": 10},
-                              "applied_coupon": {"code": "1"}
+                              "currency": "USD",
+                              "price": {"gross": 10.1},
+                              "applied_coupon": {"code": "1", "discount": 0.5}
                          }]}}`)
 	})
 
@@ -30,12 +31,14 @@ func TestPricesService_Get(t *testing.T) {
 		t.Errorf("Prices.Get returned error: %v", err)
 	}
 
+	gross, discount := MustParseMoney("USD", "10.10"), MustParseMoney("USD", "0.50")
 	want := &Prices{
 		CustomerCountry: String("tn"),
 		Products: []*Product{{
 			ProductID:     Int(1),
-			Price:         &Price{Gross: Float64(10)},
-			AppliedCoupon: &AppliedCoupon{Code: String("1")},
+			Currency:      String("USD"),
+			Price:         &Price{Gross: &gross},
+			AppliedCoupon: &AppliedCoupon{Code: String("1"), Discount: &discount},
 		}},
 	}
 	if !reflect.DeepEqual(prices, want) {
@@ -45,7 +45,7 @@ func (s *ProductsService) List(ctx context.Context) (*OneTimeProducts, *http.Res
 	}
 
 	productsResponse := new(ProductsResponse)
-	response, err := s.client.Do(ctx, req, productsResponse)
+	response, err := s.client.doCached(withOperation(ctx, "Products.List"), CacheProducts, req, productsResponse)
 	if err != nil {
 		return nil, response, err
 	}
@@ -0,0 +1,106 @@
+package paddle
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter limits the rate at which a Client sends requests. It is shared
+// by every service of the Client and must be safe for concurrent use.
+type RateLimiter interface {
+	// Wait blocks until a request to the given endpoint may be sent, or
+	// until ctx is done. endpoint is the path of the request relative to
+	// the BaseURL of the Client, for example "2.0/subscription/users".
+	Wait(ctx context.Context, endpoint string) error
+}
+
+// TokenBucket is a RateLimiter implementing the token bucket algorithm.
+// Requests to every endpoint share the same bucket, unless the endpoint has
+// been given its own limit with SetEndpointLimit.
+type TokenBucket struct {
+	mu        sync.Mutex
+	bucket    *bucket
+	overrides map[string]*bucket
+}
+
+// NewTokenBucket returns a TokenBucket allowing rate requests per second
+// with bursts of at most burst requests. A rate lower or equal to 0 disables
+// the limit.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	return &TokenBucket{
+		bucket:    newBucket(rate, burst),
+		overrides: map[string]*bucket{},
+	}
+}
+
+// SetEndpointLimit gives endpoint its own bucket, allowing rate requests per
+// second with bursts of at most burst requests. Requests to this endpoint no
+// longer consume tokens from the shared bucket.
+func (tb *TokenBucket) SetEndpointLimit(endpoint string, rate float64, burst int) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.overrides[endpoint] = newBucket(rate, burst)
+}
+
+// Wait blocks until a token is available for endpoint or until ctx is done.
+func (tb *TokenBucket) Wait(ctx context.Context, endpoint string) error {
+	tb.mu.Lock()
+	b, ok := tb.overrides[endpoint]
+	if !ok {
+		b = tb.bucket
+	}
+	tb.mu.Unlock()
+
+	return b.wait(ctx)
+}
+
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64 // Tokens added per second.
+	burst  float64 // Maximum number of tokens.
+	tokens float64 // Available tokens, negative when reserved ahead.
+	last   time.Time
+}
+
+func newBucket(rate float64, burst int) *bucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait reserves a token and sleeps until it becomes available. The token is
+// given back if ctx is done before that.
+func (b *bucket) wait(ctx context.Context) error {
+	if b.rate <= 0 {
+		return ctx.Err()
+	}
+
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, delay); err != nil {
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
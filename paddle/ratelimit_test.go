@@ -0,0 +1,91 @@
+package paddle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+type endpointRecorder struct {
+	mu        sync.Mutex
+	endpoints []string
+}
+
+func (r *endpointRecorder) Wait(ctx context.Context, endpoint string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.endpoints = append(r.endpoints, endpoint)
+	return nil
+}
+
+func TestDo_rateLimiter(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	limiter := new(endpointRecorder)
+	client.RateLimiter = limiter
+
+	mux.HandleFunc("/2.0/subscription/users_cancel", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true}`)
+	})
+
+	if _, _, err := client.Users.Cancel(context.Background(), 1); err != nil {
+		t.Fatalf("Users.Cancel returned error: %v", err)
+	}
+
+	if got, want := limiter.endpoints, []string{"2.0/subscription/users_cancel"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("RateLimiter.Wait called with %v, want %v", got, want)
+	}
+}
+
+func TestTokenBucket_Wait(t *testing.T) {
+	tb := NewTokenBucket(20, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := tb.Wait(ctx, "2.0/subscription/users"); err != nil {
+			t.Fatalf("Wait returned error: %v", err)
+		}
+	}
+	// The burst is consumed right away, the two remaining tokens take 50ms each.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Wait returned after %v, want at least 100ms", elapsed)
+	}
+}
+
+func TestTokenBucket_WaitCanceled(t *testing.T) {
+	tb := NewTokenBucket(0.1, 1)
+	if err := tb.Wait(context.Background(), "a"); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := tb.Wait(ctx, "a"); err != context.DeadlineExceeded {
+		t.Errorf("Wait returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestTokenBucket_SetEndpointLimit(t *testing.T) {
+	tb := NewTokenBucket(0.1, 1)
+	tb.SetEndpointLimit("2.1/product/create_coupon", 0, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := tb.Wait(ctx, "2.1/product/create_coupon"); err != nil {
+				t.Errorf("Wait returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
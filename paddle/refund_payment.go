@@ -19,15 +19,17 @@ type RefundPaymentResponse struct {
 }
 
 type RefundPayment struct {
-	OrderID string  `url:"order_id,omitempty"`
-	Amount  float64 `url:"amount,omitempty"`
-	Reason  string  `url:"reason,omitempty"`
+	OrderID string `url:"order_id,omitempty"`
+	Amount  Money  `url:"amount,omitempty"`
+	Reason  string `url:"reason,omitempty"`
 }
 
 // RefundPaymentOptions specifies the optional parameters to the
 // RefundPayment.Refund method.
 type RefundPaymentOptions struct {
-	Amount float64
+	// Amount is the partial amount to refund, in the currency of the order.
+	// The order is refunded in full if not set.
+	Amount Money
 	Reason string
 }
 
@@ -50,7 +52,7 @@ func (s *RefundPaymentService) Refund(ctx context.Context, orderID string, optio
 	}
 
 	refundPaymentsResponse := new(RefundPaymentResponse)
-	response, err := s.client.Do(ctx, req, refundPaymentsResponse)
+	response, err := s.client.Do(withOperation(ctx, "RefundPayment.Refund"), req, refundPaymentsResponse)
 	if err != nil {
 		return nil, response, err
 	}
@@ -14,11 +14,11 @@ func TestRefundPaymentService_Refund(t *testing.T) {
 
 	mux.HandleFunc("/2.0/payment/refund", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "POST")
-		testFormValues(t, r, values{"order_id": "1", "amount": "10"})
+		testFormValues(t, r, values{"order_id": "1", "amount": "10.10"})
 		fmt.Fprint(w, `{"success":true, "response": {"refund_request_id": 1}}`)
 	})
 
-	opt := &RefundPaymentOptions{Amount: float64(10)}
+	opt := &RefundPaymentOptions{Amount: MustParseMoney("USD", "10.10")}
 	request_id, _, err := client.RefundPayment.Refund(context.Background(), "1", opt)
 	if err != nil {
 		t.Errorf("RefundPayment.Refund returned error: %v", err)
@@ -0,0 +1,220 @@
+package paddle
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBaseDelay   = 500 * time.Millisecond
+	defaultMaxDelay    = 30 * time.Second
+	defaultJitter      = 0.5
+)
+
+// readOperations lists the operations which only read data, and can be
+// sent again without side effects.
+var readOperations = map[string]bool{
+	"Coupons.List":      true,
+	"Modifiers.List":    true,
+	"OrderDetails.Get":  true,
+	"Payments.List":     true,
+	"Plans.List":        true,
+	"Prices.Get":        true,
+	"Products.List":     true,
+	"Transactions.List": true,
+	"Users.List":        true,
+	"Webhooks.Get":      true,
+}
+
+// RetryPolicy specifies how Client.Do retries failed requests.
+//
+// Only read operations, such as "Users.List", are retried by default: every
+// call to the API is a POST, and a write whose response was lost may have
+// been processed by Paddle already, so sending it again could charge or
+// refund a customer twice. Writes known to be safe to repeat can be listed
+// in RetryableOperations. Requests sent directly with Client.Do, without an
+// operation, are never retried.
+//
+// Transport errors of those operations are retried. API errors are retried
+// when their HTTP status is listed in RetryableStatuses or their Paddle
+// error code is listed in RetryableCodes. Retries never outlive the
+// deadline of the context passed to Client.Do.
+type RetryPolicy struct {
+	// Maximum number of attempts, including the first one.
+	MaxAttempts int
+
+	// Delay before the first retry. It doubles after each attempt.
+	BaseDelay time.Duration
+
+	// Upper bound of the delay between two attempts.
+	MaxDelay time.Duration
+
+	// Fraction of the delay that is randomized, between 0 and 1.
+	Jitter float64
+
+	// HTTP status codes that are worth retrying.
+	RetryableStatuses []int
+
+	// Paddle error codes that are worth retrying.
+	RetryableCodes []int
+
+	// Write operations, such as "Users.Update", retried as well as the
+	// read operations.
+	RetryableOperations []string
+}
+
+// DefaultRetryPolicy returns a RetryPolicy that retries the transport
+// errors, throttled requests, server errors and Paddle internal errors of
+// read operations up to three attempts.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: defaultMaxAttempts,
+		BaseDelay:   defaultBaseDelay,
+		MaxDelay:    defaultMaxDelay,
+		Jitter:      defaultJitter,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableCodes: []int{ErrCodeInternal},
+	}
+}
+
+// shouldRetry reports whether the request should be sent again after the
+// given attempt failed with err.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, attempt int, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil || !p.retryable(OperationFromContext(ctx)) {
+		return false
+	}
+
+	var errorResponse *ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		// Transport errors. The request may or may not have been processed,
+		// for example when the response timed out.
+		var urlError *url.Error
+		return errors.As(err, &urlError)
+	}
+
+	for _, status := range p.RetryableStatuses {
+		if errorResponse.StatusCode() == status {
+			return true
+		}
+	}
+	for _, code := range p.RetryableCodes {
+		if errorResponse.Code() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// retryable reports whether operation can be sent again: it is a read
+// operation or listed in RetryableOperations.
+func (p *RetryPolicy) retryable(operation string) bool {
+	if readOperations[operation] {
+		return true
+	}
+	for _, o := range p.RetryableOperations {
+		if o == operation && operation != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay to wait after the given attempt. A Retry-After
+// header sent along resp takes precedence over the exponential backoff.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if delay, ok := retryAfter(resp); ok {
+		return delay
+	}
+
+	base, max := p.BaseDelay, p.MaxDelay
+	if base <= 0 {
+		base = defaultBaseDelay
+	}
+	if max <= 0 {
+		max = defaultMaxDelay
+	}
+
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+
+	if p.Jitter > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+	return delay
+}
+
+// retryAfter parses the Retry-After header of resp, given either in seconds
+// or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleep waits for the given duration or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rewindRequest returns a copy of req whose body, encoded by NewRequest,
+// can be sent again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("request body cannot be sent again")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	retry := req.Clone(req.Context())
+	retry.Body = body
+	return retry, nil
+}
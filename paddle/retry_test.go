@@ -0,0 +1,147 @@
+package paddle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDo_retry(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts:       3,
+		BaseDelay:         time.Millisecond,
+		RetryableStatuses: []int{http.StatusServiceUnavailable},
+	}
+
+	attempts := 0
+	mux.HandleFunc("/2.0/subscription/users", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		// The form body must be sent again on every attempt.
+		testFormValues(t, r, values{"plan_id": "1"})
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"success":true, "response": [{"user_id":2}]}`)
+	})
+
+	users, _, err := client.Users.List(context.Background(), &UsersOptions{PlanID: "1"})
+	if err != nil {
+		t.Fatalf("Users.List returned error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Users.List sent %d requests, want 3", attempts)
+	}
+
+	want := []*User{{UserID: Int(2)}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("Users.List returned %+v, want %+v", users, want)
+	}
+}
+
+func TestDo_retryNotRetryable(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.RetryPolicy = DefaultRetryPolicy()
+
+	attempts := 0
+	mux.HandleFunc("/2.0/subscription/users", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		fmt.Fprint(w, `{"success":false, "error": {"code": 107, "message": "You don't have permission to access this resource"}}`)
+	})
+
+	_, _, err := client.Users.List(context.Background(), nil)
+	if !IsAuthError(err) {
+		t.Errorf("Users.List returned error %v, want auth error", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Users.List sent %d requests, want 1", attempts)
+	}
+}
+
+func TestDo_retryWrites(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   *RetryPolicy
+		attempts int
+	}{
+		{"default", &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, RetryableStatuses: []int{http.StatusGatewayTimeout}}, 1},
+		{"allowlisted", &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, RetryableStatuses: []int{http.StatusGatewayTimeout}, RetryableOperations: []string{"Coupons.Delete"}}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+			client.RetryPolicy = tt.policy
+
+			attempts := 0
+			mux.HandleFunc("/2.0/product/delete_coupon", func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.WriteHeader(http.StatusGatewayTimeout)
+			})
+
+			if _, _, err := client.Coupons.Delete(context.Background(), "SALE", nil); err == nil {
+				t.Error("Coupons.Delete returned no error")
+			}
+			if attempts != tt.attempts {
+				t.Errorf("Coupons.Delete sent %d requests, want %d", attempts, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestDo_retryDeadline(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.RetryPolicy = DefaultRetryPolicy()
+
+	attempts := 0
+	mux.HandleFunc("/2.0/subscription/users", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, _, err := client.Users.List(ctx, nil)
+	if !IsRateLimited(err) {
+		t.Errorf("Users.List returned error %v, want rate limited", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Users.List sent %d requests, want 1", attempts)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := p.backoff(tt.attempt, nil); got != tt.want {
+			t.Errorf("backoff(%d) returned %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	if got, want := p.backoff(1, resp), 7*time.Second; got != want {
+		t.Errorf("backoff with Retry-After returned %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,225 @@
+package paddle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// TransactionsService handles communication with the transactions related
+// methods of the Paddle API.
+//
+// Paddle API docs: https://developer.paddle.com/api-reference/product-api/transactions/
+type TransactionsService service
+
+// TransactionEntity is the kind of entity whose transactions are listed.
+type TransactionEntity string
+
+// Entities whose transactions can be listed.
+const (
+	TransactionEntityUser         TransactionEntity = "user"
+	TransactionEntitySubscription TransactionEntity = "subscription"
+	TransactionEntityOrder        TransactionEntity = "order"
+	TransactionEntityCheckout     TransactionEntity = "checkout"
+	TransactionEntityProduct      TransactionEntity = "product"
+)
+
+var transactionEntities = map[TransactionEntity]bool{
+	TransactionEntityUser:         true,
+	TransactionEntitySubscription: true,
+	TransactionEntityOrder:        true,
+	TransactionEntityCheckout:     true,
+	TransactionEntityProduct:      true,
+}
+
+// Transaction represents a Paddle transaction, the payment of an order of a
+// one-time product or of a subscription.
+type Transaction struct {
+	OrderID        *string                  `json:"order_id,omitempty"`
+	CheckoutID     *string                  `json:"checkout_id,omitempty"`
+	Amount         *Money                   `json:"amount,omitempty"`
+	Currency       *string                  `json:"currency,omitempty"`
+	Status         *string                  `json:"status,omitempty"`
+	CreatedAt      *string                  `json:"created_at,omitempty"`
+	Passthrough    *string                  `json:"passthrough,omitempty"`
+	ProductID      *int                     `json:"product_id,omitempty"`
+	IsSubscription *bool                    `json:"is_subscription,omitempty"`
+	IsOneOff       *bool                    `json:"is_one_off,omitempty"`
+	Subscription   *TransactionSubscription `json:"subscription,omitempty"`
+	User           *TransactionUser         `json:"user,omitempty"`
+	ReceiptURL     *string                  `json:"receipt_url,omitempty"`
+}
+
+// TransactionSubscription is the subscription a transaction belongs to.
+type TransactionSubscription struct {
+	SubscriptionID *int    `json:"subscription_id,omitempty"`
+	Status         *string `json:"status,omitempty"`
+}
+
+// TransactionUser is the customer of a transaction.
+type TransactionUser struct {
+	UserID           *int    `json:"user_id,omitempty"`
+	Email            *string `json:"email,omitempty"`
+	MarketingConsent *bool   `json:"marketing_consent,omitempty"`
+}
+
+// UnmarshalJSON decodes a transaction and sets the currency of its amount.
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	type transaction Transaction
+	if err := json.Unmarshal(data, (*transaction)(t)); err != nil {
+		return err
+	}
+	setCurrency(t.Currency, t.Amount)
+	return nil
+}
+
+type TransactionsResponse struct {
+	Success  bool           `json:"success"`
+	Response []*Transaction `json:"response"`
+}
+
+// transactionsPerPage is the size of the pages of transactions, which Paddle
+// sets.
+const transactionsPerPage = 15
+
+// TransactionsOptions specifies the optional parameters to the
+// TransactionsService.List method. Paddle returns pages of 15 transactions.
+type TransactionsOptions struct {
+	Page int `url:"page,omitempty"`
+}
+
+// List the transactions of the entity with the given id, such as the
+// transactions of a user with TransactionEntityUser and a user id.
+//
+// Paddle API docs: https://developer.paddle.com/api-reference/product-api/transactions/listtransactions
+func (s *TransactionsService) List(ctx context.Context, entity TransactionEntity, id string, options *TransactionsOptions) ([]*Transaction, *http.Response, error) {
+	if !transactionEntities[entity] {
+		return nil, nil, fmt.Errorf("paddle: unknown transaction entity %q", entity)
+	}
+	if id == "" {
+		return nil, nil, fmt.Errorf("paddle: missing %s id", entity)
+	}
+
+	u := fmt.Sprintf("2.0/%s/%s/transactions", entity, url.PathEscape(id))
+	req, err := s.client.NewRequest("POST", u, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transactionsResponse := new(TransactionsResponse)
+	response, err := s.client.Do(withOperation(ctx, "Transactions.List"), req, transactionsResponse)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return transactionsResponse.Response, response, nil
+}
+
+// ListByUser lists the transactions of a user, such as their full purchase
+// history.
+func (s *TransactionsService) ListByUser(ctx context.Context, userID int, options *TransactionsOptions) ([]*Transaction, *http.Response, error) {
+	return s.List(ctx, TransactionEntityUser, strconv.Itoa(userID), options)
+}
+
+// ListBySubscription lists the transactions of a subscription.
+func (s *TransactionsService) ListBySubscription(ctx context.Context, subscriptionID int, options *TransactionsOptions) ([]*Transaction, *http.Response, error) {
+	return s.List(ctx, TransactionEntitySubscription, strconv.Itoa(subscriptionID), options)
+}
+
+// ListByOrder lists the transactions of an order.
+func (s *TransactionsService) ListByOrder(ctx context.Context, orderID string, options *TransactionsOptions) ([]*Transaction, *http.Response, error) {
+	return s.List(ctx, TransactionEntityOrder, orderID, options)
+}
+
+// ListByCheckout lists the transactions of a checkout.
+func (s *TransactionsService) ListByCheckout(ctx context.Context, checkoutID string, options *TransactionsOptions) ([]*Transaction, *http.Response, error) {
+	return s.List(ctx, TransactionEntityCheckout, checkoutID, options)
+}
+
+// ListByProduct lists the transactions of a product or plan.
+func (s *TransactionsService) ListByProduct(ctx context.Context, productID int, options *TransactionsOptions) ([]*Transaction, *http.Response, error) {
+	return s.List(ctx, TransactionEntityProduct, strconv.Itoa(productID), options)
+}
+
+// TransactionsIterator iterates over the transactions returned by
+// TransactionsService.List, fetching the pages as needed.
+type TransactionsIterator struct {
+	pager *pager
+}
+
+// Iter returns an iterator over all the transactions of the entity with the
+// given id, starting at options.Page.
+//
+// Example usage:
+//
+//	it := client.Transactions.Iter(ctx, paddle.TransactionEntityUser, "29777", nil)
+//	for it.Next() {
+//		transaction := it.Transaction()
+//		...
+//	}
+//	if err := it.Err(); err != nil { ... }
+func (s *TransactionsService) Iter(ctx context.Context, entity TransactionEntity, id string, options *TransactionsOptions) *TransactionsIterator {
+	opts := TransactionsOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	fetch := func(ctx context.Context, page int) ([]interface{}, bool, error) {
+		opts.Page = page
+		transactions, _, err := s.List(ctx, entity, id, &opts)
+		if err != nil {
+			return nil, false, err
+		}
+		items := make([]interface{}, len(transactions))
+		for i, transaction := range transactions {
+			items[i] = transaction
+		}
+		// The endpoint doesn't return a total, a partial page is the last one.
+		return items, len(transactions) == transactionsPerPage, nil
+	}
+	return &TransactionsIterator{pager: newPager(ctx, opts.Page, fetch)}
+}
+
+// Prefetch makes the iterator fetch the next page in the background while the
+// current one is consumed. It must be called before the first call to Next.
+func (it *TransactionsIterator) Prefetch() *TransactionsIterator {
+	it.pager.prefetch = true
+	return it
+}
+
+// Next advances the iterator to the next transaction. It returns false when
+// there are no more transactions or an error occurred.
+func (it *TransactionsIterator) Next() bool { return it.pager.next() }
+
+// Transaction returns the current transaction.
+func (it *TransactionsIterator) Transaction() *Transaction {
+	transaction, _ := it.pager.current().(*Transaction)
+	return transaction
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *TransactionsIterator) Err() error { return it.pager.err }
+
+// All returns all the remaining transactions.
+func (it *TransactionsIterator) All() ([]*Transaction, error) {
+	var transactions []*Transaction
+	for it.Next() {
+		transactions = append(transactions, it.Transaction())
+	}
+	return transactions, it.Err()
+}
+
+// ListPages calls fn with each page of transactions of the entity with the
+// given id, until there are no more pages or fn returns an error.
+func (s *TransactionsService) ListPages(ctx context.Context, entity TransactionEntity, id string, options *TransactionsOptions, fn func(transactions []*Transaction) error) error {
+	return s.Iter(ctx, entity, id, options).pager.pages(func(items []interface{}) error {
+		transactions := make([]*Transaction, len(items))
+		for i, item := range items {
+			transactions[i] = item.(*Transaction)
+		}
+		return fn(transactions)
+	})
+}
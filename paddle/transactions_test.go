@@ -0,0 +1,133 @@
+package paddle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTransactionsService_List(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/2.0/user/29777/transactions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"page": "2"})
+		fmt.Fprint(w, `{"success":true, "response": [{
+			"order_id": "1042907-384786",
+			"checkout_id": "4795118-chre8a3e1ab9f73-0e1ad27cb1",
+			"amount": "5.00",
+			"currency": "usd",
+			"status": "completed",
+			"created_at": "2017-01-01 00:00:00",
+			"passthrough": null,
+			"product_id": 12345,
+			"is_subscription": true,
+			"is_one_off": false,
+			"subscription": {"subscription_id": 123456, "status": "active"},
+			"user": {"user_id": 29777, "email": "example@paddle.com", "marketing_consent": true},
+			"receipt_url": "https://my.paddle.com/receipt/1042907-384786/4795118-chre8a3e1ab9f73-0e1ad27cb1"
+		}]}`)
+	})
+
+	transactions, _, err := client.Transactions.ListByUser(context.Background(), 29777, &TransactionsOptions{Page: 2})
+	if err != nil {
+		t.Errorf("Transactions.ListByUser returned error: %v", err)
+	}
+
+	amount := MustParseMoney("USD", "5.00")
+	want := []*Transaction{{
+		OrderID:        String("1042907-384786"),
+		CheckoutID:     String("4795118-chre8a3e1ab9f73-0e1ad27cb1"),
+		Amount:         &amount,
+		Currency:       String("usd"),
+		Status:         String("completed"),
+		CreatedAt:      String("2017-01-01 00:00:00"),
+		ProductID:      Int(12345),
+		IsSubscription: Bool(true),
+		IsOneOff:       Bool(false),
+		Subscription:   &TransactionSubscription{SubscriptionID: Int(123456), Status: String("active")},
+		User:           &TransactionUser{UserID: Int(29777), Email: String("example@paddle.com"), MarketingConsent: Bool(true)},
+		ReceiptURL:     String("https://my.paddle.com/receipt/1042907-384786/4795118-chre8a3e1ab9f73-0e1ad27cb1"),
+	}}
+	if !reflect.DeepEqual(transactions, want) {
+		t.Errorf("Transactions.ListByUser returned %+v, want %+v", transactions, want)
+	}
+}
+
+func TestTransactionsService_ListEntities(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var paths []string
+	for _, path := range []string{
+		"/2.0/subscription/1/transactions",
+		"/2.0/order/2019-2/transactions",
+		"/2.0/checkout/3-chk/transactions",
+		"/2.0/product/4/transactions",
+	} {
+		path := path
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, path)
+			fmt.Fprint(w, `{"success":true, "response": []}`)
+		})
+	}
+
+	ctx := context.Background()
+	client.Transactions.ListBySubscription(ctx, 1, nil)
+	client.Transactions.ListByOrder(ctx, "2019-2", nil)
+	client.Transactions.ListByCheckout(ctx, "3-chk", nil)
+	client.Transactions.ListByProduct(ctx, 4, nil)
+	if len(paths) != 4 {
+		t.Errorf("Transactions requested %v, want the four entities", paths)
+	}
+
+	if _, _, err := client.Transactions.List(ctx, "invoice", "1", nil); err == nil {
+		t.Errorf("Transactions.List of an unknown entity returned no error")
+	}
+	if _, _, err := client.Transactions.ListByOrder(ctx, "", nil); err == nil {
+		t.Errorf("Transactions.ListByOrder without id returned no error")
+	}
+}
+
+func TestTransactionsService_Iter(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var pages []string
+	mux.HandleFunc("/2.0/product/1/transactions", func(w http.ResponseWriter, r *http.Request) {
+		page := r.FormValue("page")
+		pages = append(pages, page)
+		switch page {
+		case "1":
+			var transactions []string
+			for i := 1; i <= transactionsPerPage; i++ {
+				transactions = append(transactions, fmt.Sprintf(`{"order_id": "%d"}`, i))
+			}
+			fmt.Fprintf(w, `{"success":true, "response": [%s]}`, strings.Join(transactions, ","))
+		case "2":
+			fmt.Fprint(w, `{"success":true, "response": [{"order_id": "16"}]}`)
+		default:
+			t.Errorf("Transactions.Iter requested page %q after a partial page", page)
+			fmt.Fprint(w, `{"success":true, "response": []}`)
+		}
+	})
+
+	transactions, err := client.Transactions.Iter(context.Background(), TransactionEntityProduct, "1", nil).All()
+	if err != nil {
+		t.Fatalf("Transactions.Iter returned error: %v", err)
+	}
+	var got []string
+	for _, transaction := range transactions {
+		got = append(got, *transaction.OrderID)
+	}
+	if len(got) != transactionsPerPage+1 || got[0] != "1" || got[transactionsPerPage] != "16" {
+		t.Errorf("Transactions.Iter returned orders %v, want 1 to 16", got)
+	}
+	if want := []string{"1", "2"}; !reflect.DeepEqual(pages, want) {
+		t.Errorf("Transactions.Iter requested pages %v, want %v", pages, want)
+	}
+}
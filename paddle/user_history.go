@@ -45,13 +45,13 @@ func (s *UserHistoryService) Get(ctx context.Context, email string, options *Use
 		}
 	}
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.newCheckoutRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	userHistoryResponse := new(UserHistoryResponse)
-	response, err := s.client.Do(ctx, req, userHistoryResponse)
+	response, err := s.client.Do(withOperation(ctx, "UserHistory.Get"), req, userHistoryResponse)
 	if err != nil {
 		return nil, response, err
 	}
@@ -2,6 +2,7 @@ package paddle
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 )
 
@@ -30,9 +31,19 @@ type User struct {
 }
 
 type UserPayment struct {
-	Amount   *float64 `json:"amount,omitempty"`
-	Currency *string  `json:"currency,omitempty"`
-	Date     *string  `json:"date,omitempty"`
+	Amount   *Money  `json:"amount,omitempty"`
+	Currency *string `json:"currency,omitempty"`
+	Date     *string `json:"date,omitempty"`
+}
+
+// UnmarshalJSON decodes a user payment and sets the currency of its amount.
+func (p *UserPayment) UnmarshalJSON(data []byte) error {
+	type userPayment UserPayment
+	if err := json.Unmarshal(data, (*userPayment)(p)); err != nil {
+		return err
+	}
+	setCurrency(p.Currency, p.Amount)
+	return nil
 }
 
 type PaymentInformation struct {
@@ -75,7 +86,7 @@ func (s *UsersService) List(ctx context.Context, options *UsersOptions) ([]*User
 	}
 
 	usersResponse := new(UsersResponse)
-	response, err := s.client.Do(ctx, req, usersResponse)
+	response, err := s.client.Do(withOperation(ctx, "Users.List"), req, usersResponse)
 	if err != nil {
 		return nil, response, err
 	}
@@ -83,28 +94,37 @@ func (s *UsersService) List(ctx context.Context, options *UsersOptions) ([]*User
 	return usersResponse.Response, response, nil
 }
 
+// UserUpdate is the form sent to update a subscription. Nil fields are not
+// sent.
 type UserUpdate struct {
 	SubscriptionID  int     `url:"subscription_id,omitempty"`
 	Quantity        int     `url:"quantity,omitempty"`
 	Currency        string  `url:"currency,omitempty"`
-	RecurringPrice  float64 `url:"recurring_price,omitempty"`
-	BillImmediately bool    `url:"bill_immediately,omitempty"`
+	RecurringPrice  *Money  `url:"recurring_price,omitempty"`
+	BillImmediately *bool   `url:"bill_immediately,omitempty"`
 	PlanID          int     `url:"plan_id,omitempty"`
-	Prorate         bool    `url:"prorate,omitempty"`
-	KeepModifiers   bool    `url:"keep_modifiers,omitempty"`
-	Passthrough     string  `url:"passthrough,omitempty"`
-	Pause           bool    `url:"pause,omitempty"`
+	Prorate         *bool   `url:"prorate,omitempty"`
+	KeepModifiers   *bool   `url:"keep_modifiers,omitempty"`
+	Passthrough     *string `url:"passthrough,omitempty"`
+	Pause           *bool   `url:"pause,omitempty"`
 }
 
+// UserUpdateOptions specifies the optional parameters to the
+// UsersService.Update method. Nil fields are left to the defaults of
+// Paddle, use Bool and String to set them.
 type UserUpdateOptions struct {
-	Currency        string
-	RecurringPrice  float64
-	BillImmediately bool
+	// RecurringPrice is the new price of the subscription, charged in its
+	// currency.
+	RecurringPrice  *Money
+	BillImmediately *bool
 	PlanID          int
-	Prorate         bool
-	KeepModifiers   bool
-	Passthrough     string
-	Pause           bool
+	Prorate         *bool
+	KeepModifiers   *bool
+
+	// Passthrough replaces the passthrough of the subscription. Use
+	// PassthroughCodec.Encode to send a typed, optionally signed value.
+	Passthrough *string
+	Pause       *bool
 }
 
 type UserUpdateResponse struct {
@@ -116,14 +136,14 @@ type UserUpdateResponse struct {
 //
 // Paddle API docs: https://developer.paddle.com/api-reference/subscription-api/users/updateuser
 func (s *UsersService) Update(ctx context.Context, subscriptionID, quantity int, options *UserUpdateOptions) (*User, *http.Response, error) {
-	u := "2.0/subscription/users/update"
-
 	update := &UserUpdate{
 		SubscriptionID: subscriptionID,
 		Quantity:       quantity,
 	}
 	if options != nil {
-		update.Currency = options.Currency
+		if options.RecurringPrice != nil {
+			update.Currency = options.RecurringPrice.Currency
+		}
 		update.RecurringPrice = options.RecurringPrice
 		update.BillImmediately = options.BillImmediately
 		update.PlanID = options.PlanID
@@ -132,6 +152,12 @@ func (s *UsersService) Update(ctx context.Context, subscriptionID, quantity int,
 		update.Passthrough = options.Passthrough
 		update.Pause = options.Pause
 	}
+	return s.update(withOperation(ctx, "Users.Update"), update)
+}
+
+// update sends update and returns the updated user.
+func (s *UsersService) update(ctx context.Context, update *UserUpdate) (*User, *http.Response, error) {
+	u := "2.0/subscription/users/update"
 	req, err := s.client.NewRequest("POST", u, update)
 	if err != nil {
 		return nil, nil, err
@@ -169,10 +195,93 @@ func (s *UsersService) Cancel(ctx context.Context, subscriptionID int) (bool, *h
 	}
 
 	userCancelResponse := new(UserCancelResponse)
-	response, err := s.client.Do(ctx, req, userCancelResponse)
+	response, err := s.client.Do(withOperation(ctx, "Users.Cancel"), req, userCancelResponse)
 	if err != nil {
 		return false, response, err
 	}
 
 	return userCancelResponse.Success, response, nil
 }
+
+// UsersIterator iterates over the users returned by UsersService.List,
+// fetching the pages as needed.
+type UsersIterator struct {
+	pager *pager
+}
+
+// Iter returns an iterator over all the users matching options, starting at
+// options.Page. Pages hold options.ResultsPerPage users, or 200 if unset.
+//
+// Example usage:
+//
+//	it := client.Users.Iter(ctx, nil)
+//	for it.Next() {
+//		user := it.User()
+//		...
+//	}
+//	if err := it.Err(); err != nil { ... }
+func (s *UsersService) Iter(ctx context.Context, options *UsersOptions) *UsersIterator {
+	opts := UsersOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.ResultsPerPage == 0 {
+		opts.ResultsPerPage = maxResultsPerPage
+	}
+
+	fetch := func(ctx context.Context, page int) ([]interface{}, bool, error) {
+		opts.Page = page
+		users, _, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, false, err
+		}
+		items := make([]interface{}, len(users))
+		for i, user := range users {
+			items[i] = user
+		}
+		// The endpoint doesn't return a total, a partial page is the last one.
+		return items, len(users) == opts.ResultsPerPage, nil
+	}
+	return &UsersIterator{pager: newPager(ctx, opts.Page, fetch)}
+}
+
+// Prefetch makes the iterator fetch the next page in the background while the
+// current one is consumed. It must be called before the first call to Next.
+func (it *UsersIterator) Prefetch() *UsersIterator {
+	it.pager.prefetch = true
+	return it
+}
+
+// Next advances the iterator to the next user. It returns false when there
+// are no more users or an error occurred.
+func (it *UsersIterator) Next() bool { return it.pager.next() }
+
+// User returns the current user.
+func (it *UsersIterator) User() *User {
+	user, _ := it.pager.current().(*User)
+	return user
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *UsersIterator) Err() error { return it.pager.err }
+
+// All returns all the remaining users.
+func (it *UsersIterator) All() ([]*User, error) {
+	var users []*User
+	for it.Next() {
+		users = append(users, it.User())
+	}
+	return users, it.Err()
+}
+
+// ListPages calls fn with each page of users matching options, until there
+// are no more pages or fn returns an error.
+func (s *UsersService) ListPages(ctx context.Context, options *UsersOptions, fn func(users []*User) error) error {
+	return s.Iter(ctx, options).pager.pages(func(items []interface{}) error {
+		users := make([]*User, len(items))
+		for i, item := range items {
+			users[i] = item.(*User)
+		}
+		return fn(users)
+	})
+}
@@ -0,0 +1,141 @@
+package paddle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Subscription states, as reported in User.State.
+const (
+	StateActive   = "active"
+	StateTrialing = "trialing"
+	StatePastDue  = "past_due"
+	StatePaused   = "paused"
+	StateDeleted  = "deleted"
+)
+
+// Proration tells how the price difference of a plan change is billed.
+type Proration int
+
+const (
+	// ProrateNone bills the new plan from the next payment, without
+	// prorating.
+	ProrateNone Proration = iota
+
+	// ProrateNextPayment adds the prorated difference to the next payment.
+	ProrateNextPayment
+
+	// ProrateImmediately bills the prorated difference immediately.
+	ProrateImmediately
+)
+
+// TransitionError is returned by the lifecycle methods of UsersService when
+// the current state of the subscription doesn't allow the change. No update
+// was sent.
+type TransitionError struct {
+	SubscriptionID int
+	State          string // Current state of the subscription.
+	Action         string // Change that was refused, for example "resume".
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("paddle: cannot %s subscription %d in state %q", e.Action, e.SubscriptionID, e.State)
+}
+
+// Pause pauses the subscription, which must be active, trialing or past
+// due. No payment is taken until it is resumed.
+//
+// Paddle API docs: https://developer.paddle.com/guides/how-tos/subscriptions/pause-subscriptions
+func (s *UsersService) Pause(ctx context.Context, subscriptionID int) (*User, *http.Response, error) {
+	update := &UserUpdate{SubscriptionID: subscriptionID, Pause: Bool(true)}
+	return s.transition(ctx, "Users.Pause", "pause", update, StateActive, StateTrialing, StatePastDue)
+}
+
+// Resume resumes the paused subscription.
+//
+// Paddle API docs: https://developer.paddle.com/guides/how-tos/subscriptions/pause-subscriptions
+func (s *UsersService) Resume(ctx context.Context, subscriptionID int) (*User, *http.Response, error) {
+	update := &UserUpdate{SubscriptionID: subscriptionID, Pause: Bool(false)}
+	return s.transition(ctx, "Users.Resume", "resume", update, StatePaused)
+}
+
+// ChangePlan moves the subscription to the plan planID, billing the price
+// difference as set by proration. The modifiers of the subscription are
+// kept.
+//
+// Paddle API docs: https://developer.paddle.com/api-reference/subscription-api/users/updateuser
+func (s *UsersService) ChangePlan(ctx context.Context, subscriptionID, planID int, proration Proration) (*User, *http.Response, error) {
+	update := &UserUpdate{
+		SubscriptionID:  subscriptionID,
+		PlanID:          planID,
+		Prorate:         Bool(proration != ProrateNone),
+		BillImmediately: Bool(proration == ProrateImmediately),
+		KeepModifiers:   Bool(true),
+	}
+	return s.transition(ctx, "Users.ChangePlan", "change the plan of", update, StateActive, StateTrialing, StatePastDue)
+}
+
+// ChangeQuantity sets the quantity of the subscription. The change is
+// prorated on the next payment.
+//
+// Paddle API docs: https://developer.paddle.com/api-reference/subscription-api/users/updateuser
+func (s *UsersService) ChangeQuantity(ctx context.Context, subscriptionID, quantity int) (*User, *http.Response, error) {
+	if quantity <= 0 {
+		return nil, nil, fmt.Errorf("paddle: invalid quantity %d", quantity)
+	}
+	update := &UserUpdate{SubscriptionID: subscriptionID, Quantity: quantity}
+	return s.transition(ctx, "Users.ChangeQuantity", "change the quantity of", update, StateActive, StateTrialing, StatePastDue)
+}
+
+// OverridePrice sets the recurring price of the subscription, per unit, from
+// the next payment. price must be in the currency of the subscription.
+//
+// Paddle API docs: https://developer.paddle.com/api-reference/subscription-api/users/updateuser
+func (s *UsersService) OverridePrice(ctx context.Context, subscriptionID int, price Money) (*User, *http.Response, error) {
+	update := &UserUpdate{
+		SubscriptionID: subscriptionID,
+		Currency:       price.Currency,
+		RecurringPrice: &price,
+	}
+	return s.transition(ctx, "Users.OverridePrice", "override the price of", update, StateActive, StateTrialing, StatePastDue)
+}
+
+// transition sends update if the subscription is in one of the allowed
+// states, and returns a *TransitionError otherwise.
+func (s *UsersService) transition(ctx context.Context, operation, action string, update *UserUpdate, allowed ...string) (*User, *http.Response, error) {
+	state, response, err := s.state(ctx, update.SubscriptionID)
+	if err != nil {
+		return nil, response, err
+	}
+	for _, a := range allowed {
+		if state == a {
+			return s.update(withOperation(ctx, operation), update)
+		}
+	}
+	return nil, response, &TransitionError{SubscriptionID: update.SubscriptionID, State: state, Action: action}
+}
+
+// state returns the current state of the subscription. Deleted
+// subscriptions are only listed when asked for, hence the second call.
+func (s *UsersService) state(ctx context.Context, subscriptionID int) (string, *http.Response, error) {
+	var response *http.Response
+	for _, state := range []string{"", StateDeleted} {
+		opts := &UsersOptions{SubscriptionID: strconv.Itoa(subscriptionID), State: state}
+		users, resp, err := s.List(ctx, opts)
+		if err != nil {
+			return "", resp, err
+		}
+		response = resp
+		for _, user := range users {
+			if user.SubscriptionID != nil && *user.SubscriptionID == subscriptionID && user.State != nil {
+				return *user.State, resp, nil
+			}
+		}
+	}
+	return "", response, &ErrorResponse{
+		Response:   response,
+		ErrorField: Error{Code: ErrCodeSubscriptionNotFound, Message: "Unable to find requested subscription"},
+	}
+}
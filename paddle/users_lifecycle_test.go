@@ -0,0 +1,164 @@
+package paddle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// handleState answers the listing of the users with a subscription in
+// state.
+func handleState(t *testing.T, mux *http.ServeMux, state string) {
+	mux.HandleFunc("/2.0/subscription/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if r.FormValue("subscription_id") != "1" {
+			t.Errorf("Request subscription_id = %q, want 1", r.FormValue("subscription_id"))
+		}
+		if state == StateDeleted && r.FormValue("state") != StateDeleted {
+			fmt.Fprint(w, `{"success":true, "response": []}`)
+			return
+		}
+		fmt.Fprintf(w, `{"success":true, "response": [{"subscription_id": 1, "state": %q}]}`, state)
+	})
+}
+
+func TestUsersService_Pause(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleState(t, mux, StateActive)
+	mux.HandleFunc("/2.0/subscription/users/update", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"subscription_id": "1", "pause": "true"})
+		fmt.Fprint(w, `{"success":true, "response": {"subscription_id": 1, "user_id": 2}}`)
+	})
+
+	user, _, err := client.Users.Pause(context.Background(), 1)
+	if err != nil {
+		t.Errorf("Users.Pause returned error: %v", err)
+	}
+
+	want := &User{SubscriptionID: Int(1), UserID: Int(2)}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("Users.Pause returned %+v, want %+v", user, want)
+	}
+}
+
+func TestUsersService_Resume(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleState(t, mux, StatePaused)
+	mux.HandleFunc("/2.0/subscription/users/update", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"subscription_id": "1", "pause": "false"})
+		fmt.Fprint(w, `{"success":true, "response": {"subscription_id": 1}}`)
+	})
+
+	if _, _, err := client.Users.Resume(context.Background(), 1); err != nil {
+		t.Errorf("Users.Resume returned error: %v", err)
+	}
+}
+
+func TestUsersService_ChangePlan(t *testing.T) {
+	tests := []struct {
+		proration Proration
+		prorate   string
+		bill      string
+	}{
+		{ProrateNone, "false", "false"},
+		{ProrateNextPayment, "true", "false"},
+		{ProrateImmediately, "true", "true"},
+	}
+	for _, tt := range tests {
+		client, mux, _, teardown := setup()
+
+		handleState(t, mux, StateTrialing)
+		mux.HandleFunc("/2.0/subscription/users/update", func(w http.ResponseWriter, r *http.Request) {
+			testFormValues(t, r, values{
+				"subscription_id":  "1",
+				"plan_id":          "2",
+				"prorate":          tt.prorate,
+				"bill_immediately": tt.bill,
+				"keep_modifiers":   "true",
+			})
+			fmt.Fprint(w, `{"success":true, "response": {"subscription_id": 1, "plan_id": 2}}`)
+		})
+
+		if _, _, err := client.Users.ChangePlan(context.Background(), 1, 2, tt.proration); err != nil {
+			t.Errorf("Users.ChangePlan(%v) returned error: %v", tt.proration, err)
+		}
+		teardown()
+	}
+}
+
+func TestUsersService_ChangeQuantityAndOverridePrice(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleState(t, mux, StatePastDue)
+	var got []values
+	mux.HandleFunc("/2.0/subscription/users/update", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		got = append(got, values{
+			"quantity":        r.PostForm.Get("quantity"),
+			"currency":        r.PostForm.Get("currency"),
+			"recurring_price": r.PostForm.Get("recurring_price"),
+		})
+		fmt.Fprint(w, `{"success":true, "response": {"subscription_id": 1}}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Users.ChangeQuantity(ctx, 1, 3); err != nil {
+		t.Errorf("Users.ChangeQuantity returned error: %v", err)
+	}
+	if _, _, err := client.Users.OverridePrice(ctx, 1, MustParseMoney("EUR", "4.5")); err != nil {
+		t.Errorf("Users.OverridePrice returned error: %v", err)
+	}
+	if _, _, err := client.Users.ChangeQuantity(ctx, 1, 0); err == nil {
+		t.Errorf("Users.ChangeQuantity with a zero quantity returned no error")
+	}
+
+	want := []values{
+		{"quantity": "3", "currency": "", "recurring_price": ""},
+		{"quantity": "", "currency": "EUR", "recurring_price": "4.50"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Updates sent %+v, want %+v", got, want)
+	}
+}
+
+func TestUsersService_transitionErrors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleState(t, mux, StateDeleted)
+	mux.HandleFunc("/2.0/subscription/users/update", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Update sent for a deleted subscription")
+	})
+
+	_, _, err := client.Users.Pause(context.Background(), 1)
+	var transitionErr *TransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("Users.Pause returned %v, want a *TransitionError", err)
+	}
+	want := &TransitionError{SubscriptionID: 1, State: StateDeleted, Action: "pause"}
+	if !reflect.DeepEqual(transitionErr, want) {
+		t.Errorf("Users.Pause returned %+v, want %+v", transitionErr, want)
+	}
+}
+
+func TestUsersService_transitionNotFound(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/2.0/subscription/users", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true, "response": []}`)
+	})
+
+	_, _, err := client.Users.Resume(context.Background(), 1)
+	if !IsNotFound(err) || ErrorCode(err) != ErrCodeSubscriptionNotFound {
+		t.Errorf("Users.Resume of an unknown subscription returned %v, want a not found error", err)
+	}
+}
@@ -15,7 +15,7 @@ func TestUsersService_List(t *testing.T) {
 	mux.HandleFunc("/2.0/subscription/users", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "POST")
 		testFormValues(t, r, values{"subscription_id": "1", "page": "2"})
-		fmt.Fprint(w, `{"success":true, "response": [{"user_id":2}]}`)
+		fmt.Fprint(w, `{"success":true, "response": [{"user_id":2, "last_payment": {"amount": 5.1, "currency": "USD"}}]}`)
 	})
 
 	opt := &UsersOptions{SubscriptionID: "1", ListOptions: ListOptions{Page: 2}}
@@ -24,7 +24,8 @@ func TestUsersService_List(t *testing.T) {
 		t.Errorf("Users.List returned error: %v", err)
 	}
 
-	want := []*User{{UserID: Int(2)}}
+	amount := MustParseMoney("USD", "5.10")
+	want := []*User{{UserID: Int(2), LastPayment: &UserPayment{Amount: &amount, Currency: String("USD")}}}
 	if !reflect.DeepEqual(users, want) {
 		t.Errorf("Users.List returned %+v, want %+v", users, want)
 	}
@@ -36,11 +37,12 @@ func TestUsersService_Update(t *testing.T) {
 
 	mux.HandleFunc("/2.0/subscription/users/update", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "POST")
-		testFormValues(t, r, values{"subscription_id": "1", "quantity": "2", "plan_id": "123"})
+		testFormValues(t, r, values{"subscription_id": "1", "quantity": "2", "plan_id": "123", "currency": "USD", "recurring_price": "9.90"})
 		fmt.Fprint(w, `{"success":true, "response": {"subscription_id": 1, "user_id":2}}`)
 	})
 
-	opt := &UserUpdateOptions{PlanID: 123}
+	price := MustParseMoney("USD", "9.9")
+	opt := &UserUpdateOptions{PlanID: 123, RecurringPrice: &price}
 	resp, _, err := client.Users.Update(context.Background(), 1, 2, opt)
 	if err != nil {
 		t.Errorf("Users.Update returned error: %v", err)
@@ -0,0 +1,313 @@
+package paddle
+
+import (
+	"bufio"
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultDeliveryStoreCapacity is the number of alert IDs remembered by a
+// MemoryDeliveryStore created with a capacity lower than 1.
+const defaultDeliveryStoreCapacity = 10000
+
+// ErrStaleAlert is returned when an alert is older than the maximum age
+// accepted by a WebhookHandler.
+var ErrStaleAlert = errors.New("paddle: stale alert")
+
+// DeliveryStore records the alerts that have been processed, keyed on their
+// alert_id, so that alerts sent again by Paddle are processed only once.
+// Implementations must be safe for concurrent use.
+type DeliveryStore interface {
+	// Reserve records alertID and reports whether it was not recorded yet.
+	Reserve(ctx context.Context, alertID string) (bool, error)
+
+	// Release forgets alertID, so that the alert is processed again the
+	// next time it is delivered. It is called when processing fails.
+	Release(ctx context.Context, alertID string) error
+}
+
+// Delivery describes the delivery of an alert to a WebhookHandler. It is
+// available to the callbacks through DeliveryFromContext.
+type Delivery struct {
+	// AlertID is the alert_id of the alert, or the event_id of a Paddle
+	// Billing notification. It is empty for fulfillment webhooks.
+	AlertID string
+
+	// KeyName is the name of the public key, or of the Paddle Billing secret
+	// key, the signature of the alert matched.
+	KeyName string
+
+	// Duplicate reports whether the alert has already been processed.
+	Duplicate bool
+}
+
+type deliveryKey struct{}
+
+// DeliveryFromContext returns the Delivery of the alert being dispatched by a
+// WebhookHandler, or nil.
+func DeliveryFromContext(ctx context.Context) *Delivery {
+	delivery, _ := ctx.Value(deliveryKey{}).(*Delivery)
+	return delivery
+}
+
+// checkAge returns ErrStaleAlert if sentAt is older than maxAge. sentAtErr is
+// the error parsing sentAt, which is not checked when zero.
+func checkAge(sentAt time.Time, sentAtErr error, maxAge time.Duration) error {
+	if sentAtErr != nil {
+		return sentAtErr
+	}
+	if !sentAt.IsZero() && time.Since(sentAt) > maxAge {
+		return fmt.Errorf("%w: sent at %v", ErrStaleAlert, sentAt)
+	}
+	return nil
+}
+
+// MemoryDeliveryStore is an in-memory DeliveryStore remembering a bounded
+// number of alert IDs. The least recently seen IDs are forgotten first.
+type MemoryDeliveryStore struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // Alert IDs, most recently seen first.
+	ids      map[string]*list.Element
+}
+
+// NewMemoryDeliveryStore returns a MemoryDeliveryStore remembering at most
+// capacity alert IDs.
+func NewMemoryDeliveryStore(capacity int) *MemoryDeliveryStore {
+	if capacity < 1 {
+		capacity = defaultDeliveryStoreCapacity
+	}
+	return &MemoryDeliveryStore{
+		capacity: capacity,
+		order:    list.New(),
+		ids:      map[string]*list.Element{},
+	}
+}
+
+// Reserve records alertID and reports whether it was not recorded yet.
+func (s *MemoryDeliveryStore) Reserve(ctx context.Context, alertID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.ids[alertID]; ok {
+		s.order.MoveToFront(e)
+		return false, nil
+	}
+
+	s.ids[alertID] = s.order.PushFront(alertID)
+	if s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.ids, oldest.Value.(string))
+	}
+	return true, nil
+}
+
+// Release forgets alertID.
+func (s *MemoryDeliveryStore) Release(ctx context.Context, alertID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.ids[alertID]; ok {
+		s.order.Remove(e)
+		delete(s.ids, alertID)
+	}
+	return nil
+}
+
+// FileDeliveryStore is a DeliveryStore persisting the alert IDs to a file, so
+// that they survive restarts. Each line of the file records an alert ID along
+// with the Unix time it was reserved at, or its release when prefixed with
+// "-". Every line is synced to disk before Reserve or Release returns.
+//
+// Alert IDs older than the maximum age of the store are forgotten, and the
+// file is rewritten without them and without the released IDs when it is
+// opened and as it grows.
+type FileDeliveryStore struct {
+	mu     sync.Mutex
+	path   string
+	maxAge time.Duration
+	file   *os.File
+	ids    map[string]time.Time // Reservation time of the alert IDs.
+	lines  int                  // Number of lines of the file.
+	pruned time.Time            // Last removal of the expired alert IDs from ids.
+	now    func() time.Time     // Overridden by the tests.
+}
+
+// minCompactLines is the number of lines below which the file of a
+// FileDeliveryStore is not rewritten.
+const minCompactLines = 1000
+
+// OpenFileDeliveryStore opens the FileDeliveryStore persisted at path,
+// creating the file if needed. Alert IDs are remembered for maxAge, or
+// forever if 0. Use at least the maximum age of the WebhookHandler, as older
+// alerts are rejected anyway.
+func OpenFileDeliveryStore(path string, maxAge time.Duration) (*FileDeliveryStore, error) {
+	s := &FileDeliveryStore{path: path, maxAge: maxAge, ids: map[string]time.Time{}, now: time.Now}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	if err := s.compact(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// load reads the alert IDs recorded in the file of s.
+func (s *FileDeliveryStore) load() error {
+	file, err := os.OpenFile(s.path, os.O_RDONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "-") {
+			delete(s.ids, line[1:])
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		// Lines written before the reservation time was recorded hold the
+		// alert ID only, and are kept for maxAge from now.
+		reserved := s.now()
+		if fields := strings.SplitN(line, " ", 2); len(fields) == 2 {
+			if unix, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
+				reserved, line = time.Unix(unix, 0), fields[1]
+			}
+		}
+		s.ids[line] = reserved
+	}
+	return scanner.Err()
+}
+
+// expired reports whether an alert ID reserved at the given time is to be
+// forgotten.
+func (s *FileDeliveryStore) expired(reserved time.Time) bool {
+	return s.maxAge > 0 && s.now().Sub(reserved) > s.maxAge
+}
+
+// compact rewrites the file of s with the alert IDs that have not expired,
+// and reopens it for appending.
+func (s *FileDeliveryStore) compact() error {
+	tmp, err := os.OpenFile(s.path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(tmp)
+	for id, reserved := range s.ids {
+		if s.expired(reserved) {
+			delete(s.ids, id)
+			continue
+		}
+		fmt.Fprintf(w, "%d %s\n", reserved.Unix(), id)
+	}
+	err = w.Flush()
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	if s.file != nil {
+		s.file.Close()
+	}
+	s.file, s.lines, s.pruned = file, len(s.ids), s.now()
+	return nil
+}
+
+// prune removes the expired alert IDs from ids, at most every half of the
+// maximum age, so that they are not counted as live when deciding whether to
+// compact the file.
+func (s *FileDeliveryStore) prune() {
+	now := s.now()
+	if s.maxAge <= 0 || now.Sub(s.pruned) < s.maxAge/2 {
+		return
+	}
+	for id, reserved := range s.ids {
+		if s.expired(reserved) {
+			delete(s.ids, id)
+		}
+	}
+	s.pruned = now
+}
+
+// appendLine appends line to the file of s and syncs it to disk, compacting
+// the file first if it mostly holds expired or released alert IDs.
+func (s *FileDeliveryStore) appendLine(line string) error {
+	s.prune()
+	if s.lines >= minCompactLines && s.lines > 2*len(s.ids) {
+		if err := s.compact(); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(s.file, line); err != nil {
+		return err
+	}
+	s.lines++
+	return s.file.Sync()
+}
+
+// Reserve records alertID and reports whether it was not recorded yet.
+func (s *FileDeliveryStore) Reserve(ctx context.Context, alertID string) (bool, error) {
+	if alertID == "" || strings.ContainsAny(alertID, " \r\n") || strings.HasPrefix(alertID, "-") {
+		return false, fmt.Errorf("invalid alert_id %q", alertID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if reserved, ok := s.ids[alertID]; ok && !s.expired(reserved) {
+		return false, nil
+	}
+	now := s.now()
+	if err := s.appendLine(fmt.Sprintf("%d %s", now.Unix(), alertID)); err != nil {
+		return false, err
+	}
+	s.ids[alertID] = now
+	return true, nil
+}
+
+// Release forgets alertID.
+func (s *FileDeliveryStore) Release(ctx context.Context, alertID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.ids[alertID]; !ok {
+		return nil
+	}
+	if err := s.appendLine("-" + alertID); err != nil {
+		return err
+	}
+	delete(s.ids, alertID)
+	return nil
+}
+
+// Close closes the file backing the store.
+func (s *FileDeliveryStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
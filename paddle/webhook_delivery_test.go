@@ -0,0 +1,198 @@
+package paddle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestMemoryDeliveryStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryDeliveryStore(2)
+
+	for _, tt := range []struct {
+		id   string
+		want bool
+	}{
+		{"1", true},
+		{"1", false},
+		{"2", true},
+		{"3", true}, // Evicts 1.
+		{"1", true},
+		{"3", false},
+	} {
+		if got, _ := s.Reserve(ctx, tt.id); got != tt.want {
+			t.Errorf("Reserve(%v) returned %v, want %v", tt.id, got, tt.want)
+		}
+	}
+
+	s.Release(ctx, "3")
+	if got, _ := s.Reserve(ctx, "3"); !got {
+		t.Errorf("Reserve returned false after Release")
+	}
+}
+
+func TestFileDeliveryStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "alerts")
+
+	s, err := OpenFileDeliveryStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("OpenFileDeliveryStore returned error: %v", err)
+	}
+	s.Reserve(ctx, "1")
+	s.Reserve(ctx, "2")
+	s.Release(ctx, "2")
+	if _, err := s.Reserve(ctx, "-1"); err == nil {
+		t.Errorf("Reserve accepted an invalid alert_id")
+	}
+	s.Close()
+
+	s, err = OpenFileDeliveryStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("OpenFileDeliveryStore returned error: %v", err)
+	}
+	defer s.Close()
+	if got, _ := s.Reserve(ctx, "1"); got {
+		t.Errorf("Reserve(1) returned true after reopening the store")
+	}
+	if got, _ := s.Reserve(ctx, "2"); !got {
+		t.Errorf("Reserve(2) returned false after its release")
+	}
+}
+
+func TestFileDeliveryStore_expiry(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "alerts")
+	old := time.Now().Add(-2 * time.Hour).Unix()
+	recent := time.Now().Add(-time.Minute).Unix()
+	content := fmt.Sprintf("%d 1\n%d 2\n%d 3\n-3\n", old, recent, recent)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := OpenFileDeliveryStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("OpenFileDeliveryStore returned error: %v", err)
+	}
+	defer s.Close()
+
+	if data, _ := ioutil.ReadFile(path); string(data) != fmt.Sprintf("%d 2\n", recent) {
+		t.Errorf("OpenFileDeliveryStore compacted the file to %q", data)
+	}
+	if got, _ := s.Reserve(ctx, "1"); !got {
+		t.Errorf("Reserve(1) returned false for an expired alert ID")
+	}
+	if got, _ := s.Reserve(ctx, "2"); got {
+		t.Errorf("Reserve(2) returned true for a recent alert ID")
+	}
+
+	s.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if got, _ := s.Reserve(ctx, "2"); !got {
+		t.Errorf("Reserve(2) returned false once expired")
+	}
+}
+
+func TestFileDeliveryStore_compactExpired(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "alerts")
+	s, err := OpenFileDeliveryStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("OpenFileDeliveryStore returned error: %v", err)
+	}
+	defer s.Close()
+
+	for i := 0; i < minCompactLines; i++ {
+		if _, err := s.Reserve(ctx, strconv.Itoa(i)); err != nil {
+			t.Fatalf("Reserve returned error: %v", err)
+		}
+	}
+	now := time.Now().Add(2 * time.Hour)
+	s.now = func() time.Time { return now }
+	if _, err := s.Reserve(ctx, "new"); err != nil {
+		t.Fatalf("Reserve returned error: %v", err)
+	}
+
+	if len(s.ids) != 1 {
+		t.Errorf("Store holds %d alert IDs after they expired, want 1", len(s.ids))
+	}
+	if data, _ := ioutil.ReadFile(path); string(data) != fmt.Sprintf("%d new\n", now.Unix()) {
+		t.Errorf("Reserve left the file with %d bytes, want the new alert ID only", len(data))
+	}
+}
+
+func TestWebhookHandler_Deduplicate(t *testing.T) {
+	privateKey, publicKey := testWebhookKey(t)
+	h := NewWebhookHandler(publicKey)
+	h.Deduplicate(NewMemoryDeliveryStore(10))
+
+	calls := 0
+	fail := true
+	h.OnTransferPaid(func(ctx context.Context, alert *TransferPaidAlert) error {
+		calls++
+		if d := DeliveryFromContext(ctx); d == nil || d.AlertID != "1" || d.Duplicate {
+			t.Errorf("DeliveryFromContext returned %+v", d)
+		}
+		if fail {
+			fail = false
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	form := url.Values{"alert_name": {"transfer_paid"}, "alert_id": {"1"}}
+	for _, want := range []int{http.StatusInternalServerError, http.StatusOK, http.StatusOK} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, testWebhookRequest(t, form, privateKey))
+		if w.Code != want {
+			t.Errorf("WebhookHandler responded %d, want %d", w.Code, want)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Callback called %d times, want 2", calls)
+	}
+
+	h.DeliverDuplicates(true)
+	h.OnTransferPaid(func(ctx context.Context, alert *TransferPaidAlert) error {
+		if d := DeliveryFromContext(ctx); d == nil || !d.Duplicate {
+			t.Errorf("DeliveryFromContext returned %+v, want a duplicate", d)
+		}
+		calls++
+		return nil
+	})
+	h.ServeHTTP(httptest.NewRecorder(), testWebhookRequest(t, form, privateKey))
+	if calls != 3 {
+		t.Errorf("Duplicate alert not delivered")
+	}
+}
+
+func TestWebhookHandler_MaxAge(t *testing.T) {
+	privateKey, publicKey := testWebhookKey(t)
+	h := NewWebhookHandler(publicKey)
+	h.MaxAge(time.Hour)
+
+	now := time.Now().UTC()
+	tests := []struct {
+		eventTime time.Time
+		want      int
+	}{
+		{now.Add(-time.Minute), http.StatusOK},
+		{now.Add(-2 * time.Hour), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		form := url.Values{"alert_name": {"transfer_paid"}, "event_time": {tt.eventTime.Format(alertTimeLayout)}}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, testWebhookRequest(t, form, privateKey))
+		if w.Code != tt.want {
+			t.Errorf("WebhookHandler responded %d for an alert sent at %v, want %d", w.Code, tt.eventTime, tt.want)
+		}
+	}
+}
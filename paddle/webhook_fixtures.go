@@ -0,0 +1,219 @@
+package paddle
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// AlertNames returns the names of all the alert types ParsePayload recognizes,
+// in alphabetical order.
+func AlertNames() []string {
+	names := make([]string, 0, len(alertTypes))
+	for name := range alertTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AlertValues encodes alert, a pointer to one of the alert types, into the
+// form values Paddle would send. Nil fields are left out. The alert_name
+// field is set from the type of alert.
+func AlertValues(alert interface{}) (url.Values, error) {
+	v := reflect.ValueOf(alert)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("alert must be a non-nil pointer to an alert struct, got %T", alert)
+	}
+	v = v.Elem()
+
+	values := url.Values{}
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Tag.Get("json")
+		field := v.Field(i)
+		if name == "" || field.Kind() != reflect.Ptr || field.IsNil() || field.Elem().Kind() != reflect.String {
+			continue
+		}
+		values.Set(name, field.Elem().String())
+	}
+
+	if alertName := alertNameOf(alert); alertName != "" {
+		values.Set("alert_name", alertName)
+	}
+	return values, nil
+}
+
+// alertNameOf returns the alert name of the type of alert, or an empty string
+// for fulfillment webhooks and unknown types.
+func alertNameOf(alert interface{}) string {
+	t := reflect.TypeOf(alert)
+	for name, newAlert := range alertTypes {
+		if reflect.TypeOf(newAlert()) == t {
+			return name
+		}
+	}
+	return ""
+}
+
+// NewWebhookRequest returns a POST request to target carrying alert, signed
+// with privateKey the way Paddle signs its alerts. alert is either a pointer
+// to one of the alert types or the url.Values to send.
+//
+// Example usage:
+//
+//	r, err := paddle.NewWebhookRequest("/webhooks", &paddle.SubscriptionCreatedAlert{
+//		SubscriptionID: paddle.String("1"),
+//	}, privateKey)
+//	handler.ServeHTTP(httptest.NewRecorder(), r)
+func NewWebhookRequest(target string, alert interface{}, privateKey *rsa.PrivateKey) (*http.Request, error) {
+	values, ok := alert.(url.Values)
+	if !ok {
+		var err error
+		if values, err = AlertValues(alert); err != nil {
+			return nil, err
+		}
+	}
+
+	signature, err := SignPayload(values, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := url.Values{}
+	for k, v := range values {
+		signed[k] = v
+	}
+	signed.Set("p_signature", signature)
+
+	r, err := http.NewRequest("POST", target, strings.NewReader(signed.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r, nil
+}
+
+// NewSampleWebhookRequest is like NewWebhookRequest but sends a sample alert
+// of the given type, as returned by SampleAlert.
+func NewSampleWebhookRequest(target, alertName string, privateKey *rsa.PrivateKey) (*http.Request, error) {
+	alert, err := SampleAlert(alertName)
+	if err != nil {
+		return nil, err
+	}
+	return NewWebhookRequest(target, alert, privateKey)
+}
+
+// SampleAlert returns an alert of the given type with every field set to a
+// realistic value. Use "fulfillment" to get a *FulfillmentWebhook. An error
+// is returned if a field has no known sample value.
+func SampleAlert(alertName string) (interface{}, error) {
+	var alert interface{}
+	if newAlert, ok := alertTypes[alertName]; ok {
+		alert = newAlert()
+	} else if alertName == fulfillmentAlertName {
+		alert = &FulfillmentWebhook{}
+	} else {
+		return nil, fmt.Errorf("unknown alert_type: %v", alertName)
+	}
+
+	v := reflect.ValueOf(alert).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Tag.Get("json")
+		value, ok := alertName, true
+		if name != "alert_name" {
+			value, ok = sampleValue(name)
+		}
+		if !ok {
+			return nil, fmt.Errorf("paddle: no sample value for the %s field of %s", name, alertName)
+		}
+		v.Field(i).Set(reflect.ValueOf(String(value)))
+	}
+	return alert, nil
+}
+
+// sampleValues holds the sample values of the fields that are not covered by
+// the naming rules of sampleValue.
+var sampleValues = map[string]string{
+	"alert_id":                       "1234567890",
+	"case_id":                        "4321",
+	"checkout_id":                    "12345678-chre8a1b2c3d4e5-6f7a8b9c0d",
+	"country":                        "US",
+	"coupon":                         "WELCOME10",
+	"customer_address":               "1 Main Street",
+	"customer_city":                  "Springfield",
+	"customer_company_number":        "12345678",
+	"customer_name":                  "Jane Doe",
+	"customer_state":                 "IL",
+	"customer_vat_number":            "GB123456789",
+	"customer_zipcode":               "62701",
+	"download":                       "https://example.com/download/app.dmg",
+	"instructions":                   "Enter the license code in the application.",
+	"ip":                             "192.0.2.1",
+	"licence":                        "ABCD-1234-EFGH-5678",
+	"old_status":                     "active",
+	"order_id":                       "123456-7890",
+	"p_country":                      "US",
+	"p_coupon":                       "WELCOME10",
+	"p_earnings":                     `{"12345":"0.85"}`,
+	"p_order_id":                     "123456",
+	"passthrough":                    `{"account_id":42}`,
+	"paused_reason":                  "delinquent",
+	"payment_method":                 "card",
+	"plan_name":                      "Pro Monthly",
+	"product_additional_information": "Annual license",
+	"product_name":                   "Pro",
+	"products":                       "12345",
+	"purchase_order_number":          "PO-2021-001",
+	"refund_reason":                  "Requested by the customer",
+	"refund_type":                    "full",
+	"risk_score":                     "72.5",
+	"source":                         "Checkout",
+	"status":                         "active",
+	"term_days":                      "30",
+}
+
+// sampleValue returns a realistic value for the alert field with the given
+// name, and false if the field is unknown.
+func sampleValue(name string) (string, bool) {
+	if value, ok := sampleValues[name]; ok {
+		return value, true
+	}
+
+	switch {
+	case strings.HasSuffix(name, "currency"):
+		return "USD", true
+	case strings.Contains(name, "email"):
+		return "customer@example.com", true
+	case strings.HasSuffix(name, "_url"):
+		return "https://checkout.paddle.com/subscription/" + strings.TrimSuffix(name, "_url"), true
+	case strings.Contains(name, "marketing_consent"), name == "subscribed",
+		name == "initial_payment", name == "checkout_recovery":
+		return "1", true
+	case strings.HasSuffix(name, "used_price_override"):
+		return "0", true
+	case strings.HasSuffix(name, "_date") || strings.HasPrefix(name, "paused_from"):
+		return "2021-07-01", true
+	case strings.HasSuffix(name, "_at") || strings.HasPrefix(name, "date_") || name == "event_time":
+		return "2021-06-01 12:00:00", true
+	case strings.HasSuffix(name, "_id"):
+		return "12345", true
+	case strings.Contains(name, "quantity"), name == "instalments", name == "attempt_number":
+		return "1", true
+	// Amounts, consistent with a sale of 11.99.
+	case strings.Contains(name, "tax"):
+		return "2.50", true
+	case strings.Contains(name, "fee"):
+		return "1.05", true
+	case strings.Contains(name, "earnings"):
+		return "8.44", true
+	case strings.Contains(name, "savings"):
+		return "1.00", true
+	case strings.Contains(name, "price"), strings.Contains(name, "amount"), strings.Contains(name, "gross"):
+		return "11.99", true
+	}
+	return "", false
+}
@@ -0,0 +1,91 @@
+package paddle
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSignPayload(t *testing.T) {
+	privateKey, publicKey := testWebhookKey(t)
+
+	form := url.Values{"alert_name": {"transfer_paid"}, "amount": {"10.00"}}
+	signature, err := SignPayload(form, privateKey)
+	if err != nil {
+		t.Fatalf("SignPayload returned error: %v", err)
+	}
+	v, err := NewWebhookVerifier(map[string][]byte{"test": publicKey})
+	if err != nil {
+		t.Fatalf("NewWebhookVerifier returned error: %v", err)
+	}
+
+	form.Set("p_signature", signature)
+	if _, err := v.Verify(form); err != nil {
+		t.Errorf("Verify returned error: %v", err)
+	}
+
+	form.Set("amount", "1000.00")
+	if _, err := v.Verify(form); err != ErrSignatureMismatch {
+		t.Errorf("Verify returned %v for a tampered payload, want %v", err, ErrSignatureMismatch)
+	}
+}
+
+func TestNewSampleWebhookRequest(t *testing.T) {
+	privateKey, publicKey := testWebhookKey(t)
+
+	for _, name := range append(AlertNames(), "fulfillment") {
+		r, err := NewSampleWebhookRequest("/webhooks", name, privateKey)
+		if err != nil {
+			t.Fatalf("NewSampleWebhookRequest(%q) returned error: %v", name, err)
+		}
+
+		payload, err := ValidatePayload(r, publicKey)
+		if err != nil {
+			t.Fatalf("ValidatePayload(%q) returned error: %v", name, err)
+		}
+		alert, err := ParsePayload(payload)
+		if err != nil {
+			t.Fatalf("ParsePayload(%q) returned error: %v", name, err)
+		}
+
+		want, _ := SampleAlert(name)
+		if !reflect.DeepEqual(alert, want) {
+			t.Errorf("ParsePayload(%q) returned %+v, want %+v", name, alert, want)
+		}
+
+		// Every typed field of the sample alerts must be parseable.
+		v := reflect.ValueOf(alert)
+		for i := 0; i < v.NumMethod(); i++ {
+			method := v.Type().Method(i)
+			if !strings.HasPrefix(method.Name, "Parse") {
+				continue
+			}
+			out := v.Method(i).Call(nil)
+			if err, _ := out[1].Interface().(error); err != nil {
+				t.Errorf("%T.%s returned error: %v", alert, method.Name, err)
+			}
+		}
+	}
+}
+
+func TestSampleValue_unknownField(t *testing.T) {
+	if value, ok := sampleValue("loyalty_tier"); ok {
+		t.Errorf("sampleValue of an unknown field returned %q, want none", value)
+	}
+	if value, ok := sampleValue("p_earnings"); !ok || value != `{"12345":"0.85"}` {
+		t.Errorf("sampleValue(%q) returned %q, want a vendor-keyed object", "p_earnings", value)
+	}
+}
+
+func TestAlertValues(t *testing.T) {
+	values, err := AlertValues(&SubscriptionCreatedAlert{SubscriptionID: String("1")})
+	if err != nil {
+		t.Fatalf("AlertValues returned error: %v", err)
+	}
+
+	want := url.Values{"alert_name": {"subscription_created"}, "subscription_id": {"1"}}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("AlertValues returned %v, want %v", values, want)
+	}
+}
@@ -0,0 +1,428 @@
+package paddle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// fulfillmentAlertName is the name under which fulfillment webhooks, sent
+// without an alert_name, are dispatched.
+const fulfillmentAlertName = "fulfillment"
+
+// WebhookHandler is an http.Handler validating and dispatching Paddle alerts
+// to the callbacks registered for their type.
+//
+// Requests with an invalid signature are rejected with a 403 status code.
+// When a callback returns an error, the handler responds with a 500 status
+// code so that Paddle sends the alert again later. Alerts no callback has been
+// registered for are acknowledged with a 200 status code.
+//
+// Alerts already processed are detected when a DeliveryStore is set with
+// Deduplicate, and alerts older than the duration set with MaxAge are
+// rejected with a 400 status code.
+//
+// Example usage:
+//
+//	h := paddle.NewWebhookHandler([]byte(config.PaddleWebHookPublicKey))
+//	h.OnSubscriptionCreated(func(ctx context.Context, alert *paddle.SubscriptionCreatedAlert) error {
+//		return activateAccount(ctx, *alert.Email)
+//	})
+//	http.Handle("/paddle/webhooks", h)
+type WebhookHandler struct {
+	verifier    *WebhookVerifier
+	verifierErr error // Error parsing the public key given to NewWebhookHandler.
+	handlers    map[string]func(ctx context.Context, alert interface{}) error
+
+	billingVerifier *BillingVerifier
+	billingHandlers map[string]func(ctx context.Context, event *BillingEvent) error
+
+	fallback func(ctx context.Context, alertName string, alert interface{}) error
+	onError  func(r *http.Request, err error)
+
+	store             DeliveryStore
+	maxAge            time.Duration
+	deliverDuplicates bool
+}
+
+// NewWebhookHandler returns a WebhookHandler validating the alerts with the
+// given Paddle public key. If the key cannot be parsed, every alert is
+// rejected and the *MalformedKeyError is reported to the OnError function.
+func NewWebhookHandler(publicKey []byte) *WebhookHandler {
+	v, err := NewWebhookVerifier(map[string][]byte{defaultKeyName: publicKey})
+	h := newWebhookHandler(v)
+	h.verifierErr = err
+	return h
+}
+
+// NewWebhookHandlerWithVerifier returns a WebhookHandler validating the alerts
+// with v. The name of the key an alert matched is available to the callbacks
+// through DeliveryFromContext. It panics if v is nil, as such a handler would
+// fail on the first alert.
+func NewWebhookHandlerWithVerifier(v *WebhookVerifier) *WebhookHandler {
+	if v == nil {
+		panic("paddle: NewWebhookHandlerWithVerifier called with a nil verifier")
+	}
+	return newWebhookHandler(v)
+}
+
+func newWebhookHandler(v *WebhookVerifier) *WebhookHandler {
+	return &WebhookHandler{
+		verifier: v,
+		handlers: map[string]func(ctx context.Context, alert interface{}) error{},
+
+		billingHandlers: map[string]func(ctx context.Context, event *BillingEvent) error{},
+	}
+}
+
+// notification is a verified alert or Paddle Billing notification.
+type notification struct {
+	delivery  *Delivery
+	sentAt    time.Time // Zero if unknown.
+	sentAtErr error
+	dispatch  func(ctx context.Context) error
+}
+
+// ServeHTTP validates the alert sent in r and dispatches it.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var n *notification
+	var err error
+	if r.Header.Get(billingSignatureHeader) != "" {
+		n, err = h.verifyBilling(r)
+	} else {
+		n, err = h.verify(r)
+	}
+	if err != nil {
+		h.reportError(r, err)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	if h.maxAge > 0 {
+		if err := checkAge(n.sentAt, n.sentAtErr, h.maxAge); err != nil {
+			h.reportError(r, err)
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+	}
+
+	delivery := n.delivery
+	reserved := false
+	if h.store != nil && delivery.AlertID != "" {
+		first, err := h.store.Reserve(r.Context(), delivery.AlertID)
+		if err != nil {
+			h.reportError(r, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		reserved = first
+		delivery.Duplicate = !first
+	}
+	if delivery.Duplicate && !h.deliverDuplicates {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), deliveryKey{}, delivery)
+	if err := n.dispatch(ctx); err != nil {
+		h.reportError(r, err)
+		if reserved {
+			// Let the alert be processed again when Paddle retries it.
+			if err := h.store.Release(r.Context(), delivery.AlertID); err != nil {
+				h.reportError(r, err)
+			}
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// verify verifies the classic alert sent in r.
+func (h *WebhookHandler) verify(r *http.Request) (*notification, error) {
+	if h.verifierErr != nil {
+		return nil, h.verifierErr
+	}
+	payload, keyName, err := h.verifier.VerifyRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &notification{
+		delivery: &Delivery{AlertID: payload["alert_id"], KeyName: keyName},
+		dispatch: func(ctx context.Context) error { return h.dispatch(ctx, payload) },
+	}
+	if eventTime, ok := payload["event_time"]; ok {
+		n.sentAt, n.sentAtErr = parseAlertTime("event_time", &eventTime)
+	}
+	return n, nil
+}
+
+// verifyBilling verifies the Paddle Billing notification sent in r.
+func (h *WebhookHandler) verifyBilling(r *http.Request) (*notification, error) {
+	if h.billingVerifier == nil {
+		return nil, errors.New("paddle: Paddle Billing notifications are not enabled")
+	}
+	event, secretName, err := h.billingVerifier.VerifyRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &notification{
+		delivery: &Delivery{KeyName: secretName},
+		dispatch: func(ctx context.Context) error { return h.dispatchBilling(ctx, event) },
+	}
+	if event.EventID != nil {
+		n.delivery.AlertID = *event.EventID
+	}
+	if event.OccurredAt != nil {
+		n.sentAt = *event.OccurredAt
+	}
+	return n, nil
+}
+
+// dispatchBilling calls the callback registered for the type of event.
+func (h *WebhookHandler) dispatchBilling(ctx context.Context, event *BillingEvent) error {
+	var eventType string
+	if event.EventType != nil {
+		eventType = *event.EventType
+	}
+
+	if handler, ok := h.billingHandlers[eventType]; ok {
+		return handler(ctx, event)
+	}
+	if h.fallback != nil {
+		return h.fallback(ctx, eventType, event)
+	}
+	return nil
+}
+
+// dispatch parses payload and calls the callback registered for its type.
+// Alerts of an unknown type are passed to the fallback as a map[string]string.
+func (h *WebhookHandler) dispatch(ctx context.Context, payload map[string]string) error {
+	alertName := payload["alert_name"]
+
+	alert, err := ParsePayload(payload)
+	if err != nil {
+		if h.fallback != nil {
+			return h.fallback(ctx, alertName, payload)
+		}
+		return nil
+	}
+	if _, ok := alert.(*FulfillmentWebhook); ok {
+		alertName = fulfillmentAlertName
+	}
+
+	if handler, ok := h.handlers[alertName]; ok {
+		return handler(ctx, alert)
+	}
+	if h.fallback != nil {
+		return h.fallback(ctx, alertName, alert)
+	}
+	return nil
+}
+
+func (h *WebhookHandler) reportError(r *http.Request, err error) {
+	if h.onError != nil {
+		h.onError(r, err)
+	}
+}
+
+// OnError registers a function called with the errors the handler responds
+// with, for logging purposes.
+func (h *WebhookHandler) OnError(fn func(r *http.Request, err error)) {
+	h.onError = fn
+}
+
+// Deduplicate makes the handler record the alert_id of the alerts it
+// processes in store, and acknowledge the alerts already recorded without
+// dispatching them. An alert is forgotten when its callback returns an error,
+// so that it is processed again when Paddle retries it.
+func (h *WebhookHandler) Deduplicate(store DeliveryStore) {
+	h.store = store
+}
+
+// DeliverDuplicates makes the handler dispatch the duplicate alerts detected
+// by Deduplicate too. Callbacks tell them apart with DeliveryFromContext.
+func (h *WebhookHandler) DeliverDuplicates(deliver bool) {
+	h.deliverDuplicates = deliver
+}
+
+// MaxAge makes the handler reject the alerts whose event_time is older than
+// d, so that captured alerts cannot be replayed later. Alerts without an
+// event_time, such as fulfillment webhooks, are not checked.
+func (h *WebhookHandler) MaxAge(d time.Duration) {
+	h.maxAge = d
+}
+
+// Fallback registers a catch-all callback, called for the alerts no typed
+// callback has been registered for. Alerts of an unknown type are passed as
+// a map[string]string holding the raw payload, and Paddle Billing
+// notifications as a *BillingEvent along with their event type.
+func (h *WebhookHandler) Fallback(fn func(ctx context.Context, alertName string, alert interface{}) error) {
+	h.fallback = fn
+}
+
+// AcceptBilling makes the handler accept Paddle Billing notifications too,
+// verified with v. They are told apart from classic alerts by their
+// Paddle-Signature header, which allows migrating to Paddle Billing with a
+// single endpoint.
+func (h *WebhookHandler) AcceptBilling(v *BillingVerifier) {
+	h.billingVerifier = v
+}
+
+// OnBillingEvent registers the callback for Paddle Billing notifications of
+// the given type, such as "subscription.created". BillingEvent.ParseData
+// returns the typed data of the event.
+func (h *WebhookHandler) OnBillingEvent(eventType string, fn func(ctx context.Context, event *BillingEvent) error) {
+	h.billingHandlers[eventType] = fn
+}
+
+// OnFulfillment registers the callback for fulfillment webhooks.
+func (h *WebhookHandler) OnFulfillment(fn func(ctx context.Context, alert *FulfillmentWebhook) error) {
+	h.handlers[fulfillmentAlertName] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*FulfillmentWebhook))
+	}
+}
+
+// OnSubscriptionCreated registers the callback for subscription_created alerts.
+func (h *WebhookHandler) OnSubscriptionCreated(fn func(ctx context.Context, alert *SubscriptionCreatedAlert) error) {
+	h.handlers["subscription_created"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*SubscriptionCreatedAlert))
+	}
+}
+
+// OnSubscriptionUpdated registers the callback for subscription_updated alerts.
+func (h *WebhookHandler) OnSubscriptionUpdated(fn func(ctx context.Context, alert *SubscriptionUpdatedAlert) error) {
+	h.handlers["subscription_updated"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*SubscriptionUpdatedAlert))
+	}
+}
+
+// OnSubscriptionCancelled registers the callback for subscription_cancelled alerts.
+func (h *WebhookHandler) OnSubscriptionCancelled(fn func(ctx context.Context, alert *SubscriptionCancelledAlert) error) {
+	h.handlers["subscription_cancelled"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*SubscriptionCancelledAlert))
+	}
+}
+
+// OnSubscriptionPaymentSucceeded registers the callback for subscription_payment_succeeded alerts.
+func (h *WebhookHandler) OnSubscriptionPaymentSucceeded(fn func(ctx context.Context, alert *SubscriptionPaymentSucceededAlert) error) {
+	h.handlers["subscription_payment_succeeded"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*SubscriptionPaymentSucceededAlert))
+	}
+}
+
+// OnSubscriptionPaymentFailed registers the callback for subscription_payment_failed alerts.
+func (h *WebhookHandler) OnSubscriptionPaymentFailed(fn func(ctx context.Context, alert *SubscriptionPaymentFailedAlert) error) {
+	h.handlers["subscription_payment_failed"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*SubscriptionPaymentFailedAlert))
+	}
+}
+
+// OnSubscriptionPaymentRefunded registers the callback for subscription_payment_refunded alerts.
+func (h *WebhookHandler) OnSubscriptionPaymentRefunded(fn func(ctx context.Context, alert *SubscriptionPaymentRefundedAlert) error) {
+	h.handlers["subscription_payment_refunded"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*SubscriptionPaymentRefundedAlert))
+	}
+}
+
+// OnPaymentSucceeded registers the callback for payment_succeeded alerts.
+func (h *WebhookHandler) OnPaymentSucceeded(fn func(ctx context.Context, alert *PaymentSucceededAlert) error) {
+	h.handlers["payment_succeeded"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*PaymentSucceededAlert))
+	}
+}
+
+// OnPaymentRefunded registers the callback for payment_refunded alerts.
+func (h *WebhookHandler) OnPaymentRefunded(fn func(ctx context.Context, alert *PaymentRefundedAlert) error) {
+	h.handlers["payment_refunded"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*PaymentRefundedAlert))
+	}
+}
+
+// OnLockerProcessed registers the callback for locker_processed alerts.
+func (h *WebhookHandler) OnLockerProcessed(fn func(ctx context.Context, alert *LockerProcessedAlert) error) {
+	h.handlers["locker_processed"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*LockerProcessedAlert))
+	}
+}
+
+// OnPaymentDisputeCreated registers the callback for payment_dispute_created alerts.
+func (h *WebhookHandler) OnPaymentDisputeCreated(fn func(ctx context.Context, alert *PaymentDisputeCreatedAlert) error) {
+	h.handlers["payment_dispute_created"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*PaymentDisputeCreatedAlert))
+	}
+}
+
+// OnPaymentDisputeClosed registers the callback for payment_dispute_closed alerts.
+func (h *WebhookHandler) OnPaymentDisputeClosed(fn func(ctx context.Context, alert *PaymentDisputeClosedAlert) error) {
+	h.handlers["payment_dispute_closed"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*PaymentDisputeClosedAlert))
+	}
+}
+
+// OnHighRiskTransactionCreated registers the callback for high_risk_transaction_created alerts.
+func (h *WebhookHandler) OnHighRiskTransactionCreated(fn func(ctx context.Context, alert *HighRiskTransactionCreatedAlert) error) {
+	h.handlers["high_risk_transaction_created"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*HighRiskTransactionCreatedAlert))
+	}
+}
+
+// OnHighRiskTransactionUpdated registers the callback for high_risk_transaction_updated alerts.
+func (h *WebhookHandler) OnHighRiskTransactionUpdated(fn func(ctx context.Context, alert *HighRiskTransactionUpdatedAlert) error) {
+	h.handlers["high_risk_transaction_updated"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*HighRiskTransactionUpdatedAlert))
+	}
+}
+
+// OnTransferCreated registers the callback for transfer_created alerts.
+func (h *WebhookHandler) OnTransferCreated(fn func(ctx context.Context, alert *TransferCreatedAlert) error) {
+	h.handlers["transfer_created"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*TransferCreatedAlert))
+	}
+}
+
+// OnTransferPaid registers the callback for transfer_paid alerts.
+func (h *WebhookHandler) OnTransferPaid(fn func(ctx context.Context, alert *TransferPaidAlert) error) {
+	h.handlers["transfer_paid"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*TransferPaidAlert))
+	}
+}
+
+// OnNewAudienceMember registers the callback for new_audience_member alerts.
+func (h *WebhookHandler) OnNewAudienceMember(fn func(ctx context.Context, alert *NewAudienceMemberAlert) error) {
+	h.handlers["new_audience_member"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*NewAudienceMemberAlert))
+	}
+}
+
+// OnUpdateAudienceMember registers the callback for update_audience_member alerts.
+func (h *WebhookHandler) OnUpdateAudienceMember(fn func(ctx context.Context, alert *UpdateAudienceMemberAlert) error) {
+	h.handlers["update_audience_member"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*UpdateAudienceMemberAlert))
+	}
+}
+
+// OnInvoicePaid registers the callback for invoice_paid alerts.
+func (h *WebhookHandler) OnInvoicePaid(fn func(ctx context.Context, alert *InvoicePaidAlert) error) {
+	h.handlers["invoice_paid"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*InvoicePaidAlert))
+	}
+}
+
+// OnInvoiceSent registers the callback for invoice_sent alerts.
+func (h *WebhookHandler) OnInvoiceSent(fn func(ctx context.Context, alert *InvoiceSentAlert) error) {
+	h.handlers["invoice_sent"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*InvoiceSentAlert))
+	}
+}
+
+// OnInvoiceOverdue registers the callback for invoice_overdue alerts.
+func (h *WebhookHandler) OnInvoiceOverdue(fn func(ctx context.Context, alert *InvoiceOverdueAlert) error) {
+	h.handlers["invoice_overdue"] = func(ctx context.Context, alert interface{}) error {
+		return fn(ctx, alert.(*InvoiceOverdueAlert))
+	}
+}
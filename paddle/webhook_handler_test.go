@@ -0,0 +1,138 @@
+package paddle
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// testWebhookKey generates a key pair and returns the private key along with
+// the PEM encoded public key.
+func testWebhookKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey returned error: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("x509.MarshalPKIXPublicKey returned error: %v", err)
+	}
+	return privateKey, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+// testWebhookRequest returns a webhook request whose form is signed with privateKey.
+func testWebhookRequest(t *testing.T, form url.Values, privateKey *rsa.PrivateKey) *http.Request {
+	r, err := NewWebhookRequest("/webhooks", form, privateKey)
+	if err != nil {
+		t.Fatalf("NewWebhookRequest returned error: %v", err)
+	}
+	return r
+}
+
+func TestWebhookHandler(t *testing.T) {
+	privateKey, publicKey := testWebhookKey(t)
+	h := NewWebhookHandler(publicKey)
+
+	var created *SubscriptionCreatedAlert
+	h.OnSubscriptionCreated(func(ctx context.Context, alert *SubscriptionCreatedAlert) error {
+		created = alert
+		return nil
+	})
+	h.OnSubscriptionCancelled(func(ctx context.Context, alert *SubscriptionCancelledAlert) error {
+		return errors.New("database unavailable")
+	})
+
+	tests := []struct {
+		name string
+		form url.Values
+		want int
+	}{
+		{"handled", url.Values{"alert_name": {"subscription_created"}, "email": {"a@b.c"}}, http.StatusOK},
+		{"handler error", url.Values{"alert_name": {"subscription_cancelled"}}, http.StatusInternalServerError},
+		{"not registered", url.Values{"alert_name": {"transfer_paid"}}, http.StatusOK},
+		{"unknown alert", url.Values{"alert_name": {"unknown"}}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, testWebhookRequest(t, tt.form, privateKey))
+		if w.Code != tt.want {
+			t.Errorf("%s: WebhookHandler responded %d, want %d", tt.name, w.Code, tt.want)
+		}
+	}
+
+	if created == nil || *created.Email != "a@b.c" {
+		t.Errorf("OnSubscriptionCreated callback received %+v", created)
+	}
+}
+
+func TestWebhookHandler_invalidSignature(t *testing.T) {
+	_, publicKey := testWebhookKey(t)
+	otherKey, _ := testWebhookKey(t)
+	h := NewWebhookHandler(publicKey)
+
+	called := false
+	h.Fallback(func(ctx context.Context, alertName string, alert interface{}) error {
+		called = true
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, testWebhookRequest(t, url.Values{"alert_name": {"transfer_paid"}}, otherKey))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("WebhookHandler responded %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if called {
+		t.Errorf("Fallback called for an invalid signature")
+	}
+}
+
+func TestWebhookHandler_Fallback(t *testing.T) {
+	privateKey, publicKey := testWebhookKey(t)
+	h := NewWebhookHandler(publicKey)
+
+	var names []string
+	h.Fallback(func(ctx context.Context, alertName string, alert interface{}) error {
+		names = append(names, alertName)
+		switch alertName {
+		case "transfer_paid":
+			if _, ok := alert.(*TransferPaidAlert); !ok {
+				t.Errorf("Fallback received %T, want *TransferPaidAlert", alert)
+			}
+		case "fulfillment":
+			if _, ok := alert.(*FulfillmentWebhook); !ok {
+				t.Errorf("Fallback received %T, want *FulfillmentWebhook", alert)
+			}
+		default:
+			if _, ok := alert.(map[string]string); !ok {
+				t.Errorf("Fallback received %T, want map[string]string", alert)
+			}
+		}
+		return nil
+	})
+
+	forms := []url.Values{
+		{"alert_name": {"transfer_paid"}},
+		{"p_order_id": {"1"}},
+		{"alert_name": {"unknown"}},
+	}
+	for _, form := range forms {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, testWebhookRequest(t, form, privateKey))
+		if w.Code != http.StatusOK {
+			t.Errorf("WebhookHandler responded %d, want %d", w.Code, http.StatusOK)
+		}
+	}
+
+	if got, want := strings.Join(names, ","), "transfer_paid,fulfillment,unknown"; got != want {
+		t.Errorf("Fallback called with %v, want %v", got, want)
+	}
+}
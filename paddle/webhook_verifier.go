@@ -0,0 +1,176 @@
+package paddle
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// defaultKeyName is the name of the public key of the verifiers built from a
+// single key.
+const defaultKeyName = "default"
+
+var (
+	// ErrMissingSignature is returned when an alert has no p_signature.
+	ErrMissingSignature = errors.New("paddle: missing p_signature")
+
+	// ErrSignatureMismatch is returned when the p_signature of an alert does
+	// not match any of the public keys.
+	ErrSignatureMismatch = errors.New("paddle: signature mismatch")
+)
+
+// MalformedKeyError is returned when a public key cannot be parsed.
+type MalformedKeyError struct {
+	Name string // Name of the key.
+	Err  error  // Parse error.
+}
+
+func (e *MalformedKeyError) Error() string {
+	return fmt.Sprintf("paddle: malformed public key %q: %v", e.Name, e.Err)
+}
+
+func (e *MalformedKeyError) Unwrap() error { return e.Err }
+
+// SignatureEncodingError is returned when the p_signature of an alert is not
+// valid base64.
+type SignatureEncodingError struct {
+	Err error // Decoding error.
+}
+
+func (e *SignatureEncodingError) Error() string {
+	return fmt.Sprintf("paddle: p_signature is not valid base64: %v", e.Err)
+}
+
+func (e *SignatureEncodingError) Unwrap() error { return e.Err }
+
+// namedKey is a parsed public key along with its name.
+type namedKey struct {
+	name string
+	key  *rsa.PublicKey
+}
+
+// WebhookVerifier verifies the signature of Paddle alerts against a set of
+// named public keys, parsed once. Several keys allow alerts of different
+// vendors, such as sandbox and production ones, or of a key being rotated,
+// to be accepted side by side.
+//
+// Example usage:
+//
+//	v, err := paddle.NewWebhookVerifier(map[string][]byte{
+//		"production": []byte(config.PaddlePublicKey),
+//		"sandbox":    []byte(config.PaddleSandboxPublicKey),
+//	})
+//	payload, keyName, err := v.VerifyRequest(r)
+type WebhookVerifier struct {
+	keys []namedKey
+}
+
+// NewWebhookVerifier returns a WebhookVerifier for the given PEM encoded
+// public keys, indexed by name. A *MalformedKeyError is returned if one of the
+// keys cannot be parsed.
+func NewWebhookVerifier(keys map[string][]byte) (*WebhookVerifier, error) {
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	v := &WebhookVerifier{}
+	for _, name := range names {
+		key, err := parsePublicKey(keys[name])
+		if err != nil {
+			return nil, &MalformedKeyError{Name: name, Err: err}
+		}
+		v.keys = append(v.keys, namedKey{name: name, key: key})
+	}
+	return v, nil
+}
+
+// parsePublicKey parses a PEM encoded RSA public key in PKIX form.
+func parsePublicKey(publicKey []byte) (*rsa.PublicKey, error) {
+	// Find PEM public key block.
+	der, _ := pem.Decode(publicKey)
+	if der == nil {
+		return nil, errors.New("Could not parse public key pem")
+	}
+
+	// Parse public key in PKIX, ASN.1 DER form.
+	pub, err := x509.ParsePKIXPublicKey(der.Bytes)
+	if err != nil {
+		return nil, errors.New("Could not parse public key pem der")
+	}
+
+	signingKey, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("Not the correct key format")
+	}
+	return signingKey, nil
+}
+
+// Verify verifies the p_signature of form, the fields of an alert, and
+// returns the name of the key it matches.
+func (v *WebhookVerifier) Verify(form url.Values) (string, error) {
+	p_signature := form.Get("p_signature")
+	if p_signature == "" {
+		return "", ErrMissingSignature
+	}
+
+	// base64 decode p_signature
+	sig, err := base64.StdEncoding.DecodeString(p_signature)
+	if err != nil {
+		return "", &SignatureEncodingError{Err: err}
+	}
+
+	signed := url.Values{}
+	for k, values := range form {
+		if k != "p_signature" {
+			signed[k] = values
+		}
+	}
+
+	// ksort() and serialize the Form
+	sha1Sum := sha1.Sum(phpserialize(signed))
+
+	for _, k := range v.keys {
+		if rsa.VerifyPKCS1v15(k.key, crypto.SHA1, sha1Sum[:], sig) == nil {
+			return k.name, nil
+		}
+	}
+	return "", ErrSignatureMismatch
+}
+
+// VerifyRequest is like ValidatePayload but verifies the alert sent in r
+// against the keys of v. It also returns the name of the matching key.
+func (v *WebhookVerifier) VerifyRequest(r *http.Request) (map[string]string, string, error) {
+	ct := r.Header.Get("Content-Type")
+	if ct != "application/x-www-form-urlencoded" {
+		return nil, "", fmt.Errorf("Webhook request has unsupported Content-Type %q", ct)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return nil, "", err
+	}
+
+	// Verify signature to make sure the request was sent by Paddle
+	keyName, err := v.Verify(r.Form)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Construct payload from fields sent in the request
+	payload := map[string]string{}
+	for k := range r.Form {
+		if k != "p_signature" {
+			payload[k] = r.Form.Get(k)
+		}
+	}
+	return payload, keyName, nil
+}
@@ -0,0 +1,92 @@
+package paddle
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestNewWebhookVerifier_malformedKey(t *testing.T) {
+	_, publicKey := testWebhookKey(t)
+
+	_, err := NewWebhookVerifier(map[string][]byte{"production": publicKey, "sandbox": []byte("not a key")})
+	var keyErr *MalformedKeyError
+	if !errors.As(err, &keyErr) || keyErr.Name != "sandbox" {
+		t.Errorf("NewWebhookVerifier returned %v, want a *MalformedKeyError for sandbox", err)
+	}
+}
+
+func TestWebhookVerifier_Verify(t *testing.T) {
+	productionKey, productionPublicKey := testWebhookKey(t)
+	sandboxKey, sandboxPublicKey := testWebhookKey(t)
+	otherKey, _ := testWebhookKey(t)
+
+	v, err := NewWebhookVerifier(map[string][]byte{"production": productionPublicKey, "sandbox": sandboxPublicKey})
+	if err != nil {
+		t.Fatalf("NewWebhookVerifier returned error: %v", err)
+	}
+
+	signed := func(form url.Values, privateKey *rsa.PrivateKey) url.Values {
+		r := testWebhookRequest(t, form, privateKey)
+		r.ParseForm()
+		return r.PostForm
+	}
+
+	form := url.Values{"alert_name": {"transfer_paid"}}
+	for _, tt := range []struct {
+		name string
+		form url.Values
+		want string
+	}{
+		{"production", signed(form, productionKey), "production"},
+		{"sandbox", signed(form, sandboxKey), "sandbox"},
+	} {
+		got, err := v.Verify(tt.form)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: Verify returned %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+
+	if _, err := v.Verify(signed(form, otherKey)); err != ErrSignatureMismatch {
+		t.Errorf("Verify returned %v, want %v", err, ErrSignatureMismatch)
+	}
+	if _, err := v.Verify(form); err != ErrMissingSignature {
+		t.Errorf("Verify returned %v, want %v", err, ErrMissingSignature)
+	}
+	var encodingErr *SignatureEncodingError
+	if _, err := v.Verify(url.Values{"p_signature": {"%%%"}}); !errors.As(err, &encodingErr) {
+		t.Errorf("Verify returned %v, want a *SignatureEncodingError", err)
+	}
+}
+
+func TestNewWebhookHandlerWithVerifier(t *testing.T) {
+	privateKey, publicKey := testWebhookKey(t)
+	v, err := NewWebhookVerifier(map[string][]byte{"sandbox": publicKey})
+	if err != nil {
+		t.Fatalf("NewWebhookVerifier returned error: %v", err)
+	}
+
+	h := NewWebhookHandlerWithVerifier(v)
+	var keyName string
+	h.OnTransferPaid(func(ctx context.Context, alert *TransferPaidAlert) error {
+		keyName = DeliveryFromContext(ctx).KeyName
+		return nil
+	})
+	h.ServeHTTP(httptest.NewRecorder(), testWebhookRequest(t, url.Values{"alert_name": {"transfer_paid"}}, privateKey))
+
+	if keyName != "sandbox" {
+		t.Errorf("Delivery.KeyName is %q, want %q", keyName, "sandbox")
+	}
+}
+
+func TestNewWebhookHandlerWithVerifier_nil(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewWebhookHandlerWithVerifier(nil) did not panic")
+		}
+	}()
+	NewWebhookHandlerWithVerifier(nil)
+}
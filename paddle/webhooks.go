@@ -69,10 +69,84 @@ func (s *WebhooksService) Get(ctx context.Context, options *WebhookEventOptions)
 	}
 
 	eventResponse := new(WebhookEventResponse)
-	response, err := s.client.Do(ctx, req, eventResponse)
+	response, err := s.client.Do(withOperation(ctx, "Webhooks.Get"), req, eventResponse)
 	if err != nil {
 		return nil, response, err
 	}
 
 	return eventResponse.Response, response, nil
 }
+
+// WebhookEventsIterator iterates over the past events returned by
+// WebhooksService.Get, fetching the pages as needed.
+type WebhookEventsIterator struct {
+	pager *pager
+}
+
+// Iter returns an iterator over all the past events matching options,
+// starting at options.Page. It stops after the last page reported by the API.
+func (s *WebhooksService) Iter(ctx context.Context, options *WebhookEventOptions) *WebhookEventsIterator {
+	opts := WebhookEventOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	fetch := func(ctx context.Context, page int) ([]interface{}, bool, error) {
+		opts.Page = page
+		event, _, err := s.Get(ctx, &opts)
+		if err != nil {
+			return nil, false, err
+		}
+		if event == nil {
+			return nil, false, nil
+		}
+		items := make([]interface{}, len(event.Data))
+		for i, data := range event.Data {
+			items[i] = data
+		}
+		more := event.TotalPages != nil && page < *event.TotalPages
+		return items, more, nil
+	}
+	return &WebhookEventsIterator{pager: newPager(ctx, opts.Page, fetch)}
+}
+
+// Prefetch makes the iterator fetch the next page in the background while the
+// current one is consumed. It must be called before the first call to Next.
+func (it *WebhookEventsIterator) Prefetch() *WebhookEventsIterator {
+	it.pager.prefetch = true
+	return it
+}
+
+// Next advances the iterator to the next event. It returns false when there
+// are no more events or an error occurred.
+func (it *WebhookEventsIterator) Next() bool { return it.pager.next() }
+
+// Event returns the current event.
+func (it *WebhookEventsIterator) Event() *EventData {
+	event, _ := it.pager.current().(*EventData)
+	return event
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *WebhookEventsIterator) Err() error { return it.pager.err }
+
+// All returns all the remaining events.
+func (it *WebhookEventsIterator) All() ([]*EventData, error) {
+	var events []*EventData
+	for it.Next() {
+		events = append(events, it.Event())
+	}
+	return events, it.Err()
+}
+
+// GetPages calls fn with each page of past events matching options, until
+// there are no more pages or fn returns an error.
+func (s *WebhooksService) GetPages(ctx context.Context, options *WebhookEventOptions, fn func(events []*EventData) error) error {
+	return s.Iter(ctx, options).pager.pages(func(items []interface{}) error {
+		events := make([]*EventData, len(items))
+		for i, item := range items {
+			events[i] = item.(*EventData)
+		}
+		return fn(events)
+	})
+}